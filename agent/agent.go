@@ -5,6 +5,7 @@ import (
 	"net/url"
 	"os"
 	"runtime"
+	"sync"
 
 	"github.com/Masterminds/semver"
 	"github.com/pkg/errors"
@@ -13,6 +14,7 @@ import (
 	"github.com/shellhub-io/shellhub/pkg/api/client"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/shellhub-io/shellhub/pkg/revdial"
+	"github.com/sirupsen/logrus"
 )
 
 type Agent struct {
@@ -25,6 +27,10 @@ type Agent struct {
 	serverInfo    *models.Info
 	serverAddress *url.URL
 	sessions      []string
+	// listener is the current reverse listener, closed by the authentication to reconnect the agent. As it is set and
+	// closed from different goroutines, it is guarded by listenerMu.
+	listener   *revdial.Listener
+	listenerMu sync.Mutex
 }
 
 func NewAgent(opts *ConfigOptions) (*Agent, error) {
@@ -164,9 +170,39 @@ func (a *Agent) authorize() error {
 
 	a.authData = authData
 
-	return err
+	if err != nil {
+		return err
+	}
+
+	// When the device was transferred to another namespace, the server answers with the new tenant, so the agent starts
+	// to use it and reconnects to the server to be available on the new namespace.
+	if authData.TenantID != "" && authData.TenantID != a.opts.TenantID {
+		logrus.WithFields(logrus.Fields{
+			"from": a.opts.TenantID,
+			"to":   authData.TenantID,
+		}).Info("Device transferred to another namespace")
+
+		a.opts.TenantID = authData.TenantID
+
+		a.listenerMu.Lock()
+		if a.listener != nil {
+			a.listener.Close()
+		}
+		a.listenerMu.Unlock()
+	}
+
+	return nil
 }
 
 func (a *Agent) newReverseListener() (*revdial.Listener, error) {
-	return a.cli.NewReverseListener(a.authData.Token)
+	listener, err := a.cli.NewReverseListener(a.authData.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	a.listenerMu.Lock()
+	a.listener = listener
+	a.listenerMu.Unlock()
+
+	return listener, nil
 }
@@ -9,4 +9,7 @@ type Cache interface {
 	Get(ctx context.Context, key string, value interface{}) error
 	Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error
 	Delete(ctx context.Context, key string) error
+	// Incr atomically increments the counter at key, created as zero when it does not exist, and resets its expire
+	// time. It returns the counter's new value. A counter is read by Get into a string.
+	Incr(ctx context.Context, key string, ttl time.Duration) (int64, error)
 }
@@ -0,0 +1,113 @@
+// Package exporter streams the activity of the API, as the sessions, the authentication failures and the audit logs, to
+// external systems like a syslog server or a SIEM.
+package exporter
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Types of the exported events.
+const (
+	EventSessionStarted  = "session_started"
+	EventSessionFinished = "session_finished"
+	EventAuthFailed      = "auth_failed"
+	EventAudit           = "audit"
+)
+
+// Methods of an authentication that failed.
+const (
+	// AuthMethodLogin is a login on the API with a username, or email, and a password.
+	AuthMethodLogin = "login"
+	// AuthMethodSSH is a SSH connection to a device.
+	AuthMethodSSH = "ssh"
+)
+
+// Event is an activity of the API exported to the external systems.
+type Event struct {
+	Type string `json:"type"`
+	// TenantID is the namespace of the activity. It is empty when the activity is not bound to a namespace.
+	TenantID string      `json:"tenant_id,omitempty"`
+	Time     time.Time   `json:"time"`
+	Data     interface{} `json:"data"`
+}
+
+// AuthFailure is the data of an EventAuthFailed.
+type AuthFailure struct {
+	Method    string `json:"method"`
+	Username  string `json:"username,omitempty"`
+	IPAddress string `json:"ip_address,omitempty"`
+	// Device is the UID of the device of a SSH connection.
+	Device string `json:"device,omitempty"`
+}
+
+// Exporter sends the events to an external system.
+type Exporter interface {
+	Export(ctx context.Context, event *Event) error
+}
+
+type multi []Exporter
+
+// NewMulti creates an Exporter sending the events to all the exporters. An event not sent by one of them is still sent
+// by the others, returning the first error.
+func NewMulti(exporters ...Exporter) Exporter {
+	return multi(exporters)
+}
+
+func (m multi) Export(ctx context.Context, event *Event) error {
+	var first error
+	for _, exporter := range m {
+		if err := exporter.Export(ctx, event); err != nil && first == nil {
+			first = err
+		}
+	}
+
+	return first
+}
+
+// Queue is an Exporter sending the events in background, so a slow external system does not delay the requests.
+//
+// The events are sent in the order they are queued. When the queue is full, the events are dropped and logged.
+type Queue struct {
+	exporter Exporter
+	events   chan *Event
+	timeout  time.Duration
+}
+
+// NewQueue creates a Queue with room for size events, sending them through exporter. Each event is given up after the
+// timeout.
+func NewQueue(exporter Exporter, size int, timeout time.Duration) *Queue {
+	q := &Queue{
+		exporter: exporter,
+		events:   make(chan *Event, size),
+		timeout:  timeout,
+	}
+
+	go q.run()
+
+	return q
+}
+
+func (q *Queue) Export(_ context.Context, event *Event) error {
+	select {
+	case q.events <- event:
+	default:
+		logrus.WithField("type", event.Type).Warn("Export queue is full, dropping the event")
+	}
+
+	return nil
+}
+
+func (q *Queue) run() {
+	for event := range q.events {
+		ctx, cancel := context.WithTimeout(context.Background(), q.timeout)
+
+		if err := q.exporter.Export(ctx, event); err != nil {
+			logrus.WithError(err).WithField("type", event.Type).Error("Failed to export the event")
+		}
+
+		cancel()
+	}
+}
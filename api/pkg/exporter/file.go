@@ -0,0 +1,43 @@
+package exporter
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+)
+
+// File is an Exporter appending the events to a file as JSON lines, one event per line.
+type File struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFile creates an Exporter appending the events to the file at path, created when it does not exist.
+func NewFile(path string) (*File, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	return &File{file: file}, nil
+}
+
+func (f *File) Export(_ context.Context, event *Event) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// The line is written at once, so the lines of concurrent writers are never mixed.
+	_, err = f.file.Write(append(line, '\n'))
+
+	return err
+}
+
+func (f *File) Close() error {
+	return f.file.Close()
+}
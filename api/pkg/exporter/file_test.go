@@ -0,0 +1,54 @@
+package exporter
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "exporter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "events.jsonl")
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	file, err := NewFile(path)
+	assert.NoError(t, err)
+
+	assert.NoError(t, file.Export(context.TODO(), &Event{Type: EventSessionStarted, TenantID: "tenant", Time: now, Data: map[string]string{"uid": "uid"}}))
+	assert.NoError(t, file.Export(context.TODO(), &Event{Type: EventAuthFailed, Time: now, Data: &AuthFailure{Method: AuthMethodLogin, Username: "john"}}))
+	assert.NoError(t, file.Close())
+
+	// The events are appended to the existing file.
+	file, err = NewFile(path)
+	assert.NoError(t, err)
+
+	assert.NoError(t, file.Export(context.TODO(), &Event{Type: EventSessionFinished, TenantID: "tenant", Time: now}))
+	assert.NoError(t, file.Close())
+
+	data, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	assert.Len(t, lines, 3)
+
+	var event map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(lines[1]), &event))
+	assert.Equal(t, map[string]interface{}{
+		"type": "auth_failed",
+		"time": "2024-01-02T03:04:05Z",
+		"data": map[string]interface{}{"method": "login", "username": "john"},
+	}, event)
+}
@@ -0,0 +1,185 @@
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// syslogFacility is the facility of the messages, local0.
+	syslogFacility = 16
+	// syslogVersion is the version of the RFC 5424 protocol.
+	syslogVersion = 1
+
+	syslogWarning = 4
+	syslogNotice  = 5
+	syslogInfo    = 6
+)
+
+// Syslog is an Exporter sending the events to a syslog server over TCP, or TLS when TLSConfig is set, as RFC 5424
+// messages.
+//
+// The messages are framed by octet counting, as RFC 5425 and RFC 6587 describe, and their content is the event as JSON.
+// The connection is kept between the events and opened again when it fails.
+type Syslog struct {
+	// Address is the host and port of the syslog server.
+	Address   string
+	TLSConfig *tls.Config
+	// Hostname and AppName identify the sender on the messages.
+	Hostname string
+	AppName  string
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// NewSyslog creates an Exporter sending the events to the syslog server at address, using TLS when tlsConfig is not
+// nil.
+func NewSyslog(address string, tlsConfig *tls.Config) *Syslog {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "-"
+	}
+
+	return &Syslog{
+		Address:   address,
+		TLSConfig: tlsConfig,
+		Hostname:  hostname,
+		AppName:   "shellhub",
+	}
+}
+
+func (s *Syslog) Export(ctx context.Context, event *Event) error {
+	message, err := s.build(event)
+	if err != nil {
+		return err
+	}
+
+	frame := append([]byte(fmt.Sprintf("%d ", len(message))), message...)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn != nil && !alive(s.conn) {
+		s.conn.Close()
+		s.conn = nil
+	}
+
+	// A connection failing on the write is opened again, sending the message once more.
+	for attempt := 0; ; attempt++ {
+		if s.conn == nil {
+			if s.conn, err = s.dial(ctx); err != nil {
+				return err
+			}
+		}
+
+		if deadline, ok := ctx.Deadline(); ok {
+			s.conn.SetWriteDeadline(deadline) // nolint:errcheck
+		}
+
+		if _, err = s.conn.Write(frame); err == nil {
+			return nil
+		}
+
+		s.conn.Close()
+		s.conn = nil
+
+		if attempt > 0 {
+			return err
+		}
+	}
+}
+
+// alive checks if a connection was not closed by the server. As the syslog server never sends data, anything but a
+// timeout reading the connection means it was closed. Without checking it, the first message written to a connection
+// closed by the server would be lost.
+func alive(conn net.Conn) bool {
+	conn.SetReadDeadline(time.Now().Add(time.Millisecond)) // nolint:errcheck
+	defer conn.SetReadDeadline(time.Time{})                // nolint:errcheck
+
+	_, err := conn.Read(make([]byte, 1))
+	if err, ok := err.(net.Error); ok && err.Timeout() {
+		return true
+	}
+
+	return false
+}
+
+func (s *Syslog) dial(ctx context.Context) (net.Conn, error) {
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", s.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.TLSConfig == nil {
+		return conn, nil
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline) // nolint:errcheck
+	}
+
+	client := tls.Client(conn, s.TLSConfig)
+	if err := client.Handshake(); err != nil {
+		conn.Close()
+
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// build builds the RFC 5424 message of an event, without structured data, identifying the event by its type.
+func (s *Syslog) build(event *Event) ([]byte, error) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	severity := syslogInfo
+	switch event.Type {
+	case EventAuthFailed:
+		severity = syslogWarning
+	case EventAudit:
+		severity = syslogNotice
+	}
+
+	var buffer bytes.Buffer
+
+	fmt.Fprintf(&buffer, "<%d>%d %s %s %s %d %s - ",
+		syslogFacility*8+severity,
+		syslogVersion,
+		event.Time.UTC().Format("2006-01-02T15:04:05.000000Z07:00"),
+		header(s.Hostname),
+		header(s.AppName),
+		os.Getpid(),
+		header(event.Type),
+	)
+	buffer.Write(data)
+
+	return buffer.Bytes(), nil
+}
+
+// header converts a field of the header to the printable characters RFC 5424 allows, being the nil value, "-", when it
+// is empty.
+func header(value string) string {
+	if value == "" {
+		return "-"
+	}
+
+	return strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return '_'
+		}
+
+		return r
+	}, value)
+}
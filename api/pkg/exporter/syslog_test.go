@@ -0,0 +1,102 @@
+package exporter
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// readFrame reads a message framed by octet counting.
+func readFrame(reader *bufio.Reader) (string, error) {
+	length, err := reader.ReadString(' ')
+	if err != nil {
+		return "", err
+	}
+
+	size, err := strconv.Atoi(strings.TrimSuffix(length, " "))
+	if err != nil {
+		return "", err
+	}
+
+	message := make([]byte, size)
+	if _, err := io.ReadFull(reader, message); err != nil {
+		return "", err
+	}
+
+	return string(message), nil
+}
+
+func TestSyslog(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	messages := make(chan string)
+	go func() {
+		// The first connection is closed after a message, so the exporter has to connect again.
+		for connections := 0; connections < 2; connections++ {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			reader := bufio.NewReader(conn)
+			for {
+				message, err := readFrame(reader)
+				if err != nil {
+					break
+				}
+
+				messages <- message
+
+				if connections == 0 {
+					break
+				}
+			}
+
+			conn.Close()
+		}
+	}()
+
+	exporter := NewSyslog(listener.Addr().String(), nil)
+	exporter.Hostname = "shellhub host"
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 6000, time.UTC)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	assert.NoError(t, exporter.Export(ctx, &Event{Type: EventAuthFailed, Time: now, Data: &AuthFailure{Method: AuthMethodSSH, Device: "uid"}}))
+	assert.Equal(t,
+		fmt.Sprintf(`<132>1 2024-01-02T03:04:05.000006Z shellhub_host shellhub %d auth_failed - `, os.Getpid())+
+			`{"type":"auth_failed","time":"2024-01-02T03:04:05.000006Z","data":{"method":"ssh","device":"uid"}}`,
+		<-messages,
+	)
+
+	// Waits the server to close the first connection.
+	time.Sleep(100 * time.Millisecond)
+
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, exporter.Export(ctx, &Event{Type: EventAudit, TenantID: "tenant", Time: now}))
+	}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case message := <-messages:
+			assert.True(t, strings.HasPrefix(message, "<133>1 "))
+			assert.Contains(t, message, `"tenant_id":"tenant"`)
+		case <-ctx.Done():
+			t.Fatal("message not received")
+		}
+	}
+}
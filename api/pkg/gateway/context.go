@@ -5,6 +5,7 @@ import (
 
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
 	"github.com/shellhub-io/shellhub/pkg/models"
 )
 
@@ -21,10 +22,16 @@ func (c *Context) Service() interface{} {
 	return c.service
 }
 
-// Role returns the user's namespace role got from JWT through gateway.
+// Role returns the user's namespace role got from JWT through gateway. A custom role is qualified by the namespace's
+// tenant, as expected by guard.EvaluatePermission.
 // Notice: it can be empty if the user has no namespaces.
 func (c *Context) Role() string {
-	return c.Request().Header.Get("X-Role")
+	role := c.Request().Header.Get("X-Role")
+	if tenant := c.Tenant(); tenant != nil {
+		return guard.CustomRole(tenant.ID, role)
+	}
+
+	return role
 }
 
 // Tenant returns the namespace's tenant got from JWT through gateway.
@@ -4,20 +4,26 @@ type Action int
 
 // AllActions is a struct to act like an Enum and facilitate to indicate the action used in the service.
 type AllActions struct {
-	Device    DeviceActions
-	Session   SessionActions
-	Firewall  FirewallActions
-	PublicKey PublicKeyActions
-	Namespace NamespaceActions
-	Billing   BillingActions
+	Device      DeviceActions
+	DeviceGroup DeviceGroupActions
+	Session     SessionActions
+	Firewall    FirewallActions
+	PublicKey   PublicKeyActions
+	Webhook     WebhookActions
+	Namespace   NamespaceActions
+	Billing     BillingActions
 }
 
 type DeviceActions struct {
 	Accept, Reject, Remove, Connect, Rename, CreateTag, UpdateTag, RemoveTag, RenameTag, DeleteTag int
 }
 
+type DeviceGroupActions struct {
+	Create, Edit, Remove int
+}
+
 type SessionActions struct {
-	Play, Close, Remove, Details int
+	Play, Close, Remove, Details, Approve int
 }
 
 type FirewallActions struct {
@@ -28,8 +34,12 @@ type PublicKeyActions struct {
 	Create, Edit, Remove, AddTag, RemoveTag, UpdateTag int
 }
 
+type WebhookActions struct {
+	Create, Edit, Remove int
+}
+
 type NamespaceActions struct {
-	Rename, AddMember, RemoveMember, EditMember, EnableSessionRecord, Delete int
+	Rename, AddMember, RemoveMember, EditMember, EnableSessionRecord, EditDeviceNaming, ApplyPolicy, EditGeoAccess, EditSessionTypes, EditRoles, ReadAuditLog, Delete, TransferOwnership int
 }
 
 type BillingActions struct {
@@ -51,11 +61,17 @@ var Actions = AllActions{
 		RenameTag: DeviceRenameTag,
 		DeleteTag: DeviceDeleteTag,
 	},
+	DeviceGroup: DeviceGroupActions{
+		Create: DeviceGroupCreate,
+		Edit:   DeviceGroupEdit,
+		Remove: DeviceGroupRemove,
+	},
 	Session: SessionActions{
 		Play:    SessionPlay,
 		Close:   SessionClose,
 		Remove:  SessionRemove,
 		Details: SessionDetails,
+		Approve: SessionApprove,
 	},
 	Firewall: FirewallActions{
 		Create: FirewallCreate,
@@ -70,13 +86,25 @@ var Actions = AllActions{
 		RemoveTag: PublicKeyRemoveTag,
 		UpdateTag: PublicKeyUpdateTag,
 	},
+	Webhook: WebhookActions{
+		Create: WebhookCreate,
+		Edit:   WebhookEdit,
+		Remove: WebhookRemove,
+	},
 	Namespace: NamespaceActions{
 		Rename:              NamespaceRename,
 		AddMember:           NamespaceAddMember,
 		RemoveMember:        NamespaceRemoveMember,
 		EditMember:          NamespaceEditMember,
 		EnableSessionRecord: NamespaceEnableSessionRecord,
+		EditDeviceNaming:    NamespaceEditDeviceNaming,
+		ApplyPolicy:         NamespaceApplyPolicy,
+		EditGeoAccess:       NamespaceEditGeoAccess,
+		EditSessionTypes:    NamespaceEditSessionTypes,
+		EditRoles:           NamespaceEditRoles,
+		ReadAuditLog:        NamespaceReadAuditLog,
 		Delete:              NamespaceDelete,
+		TransferOwnership:   NamespaceTransferOwnership,
 	},
 	Billing: BillingActions{
 		ChooseDevices:       BillingChooseDevices,
@@ -2,6 +2,8 @@
 package guard
 
 import (
+	"strings"
+
 	"github.com/shellhub-io/shellhub/pkg/models"
 )
 
@@ -42,6 +44,45 @@ var RolePermissions = map[string]Permissions{
 	RoleOwner:         ownerPermissions,
 }
 
+// customRoleSeparator separates the tenant from the name of a custom role qualified by CustomRole.
+const customRoleSeparator = "/"
+
+// RoleResolver resolves the permissions of a custom role of a namespace, returning false when the role does not exist.
+type RoleResolver func(tenant, name string) (Permissions, bool)
+
+var roleResolver RoleResolver
+
+// SetRoleResolver configures how EvaluatePermission resolves the custom roles. Without it, only the built-in roles have
+// permissions.
+func SetRoleResolver(resolver RoleResolver) {
+	roleResolver = resolver
+}
+
+// CustomRole returns the role of a namespace's member to be evaluated by EvaluatePermission. As the name of a custom
+// role is unique only in its namespace, it is qualified by the namespace's tenant. The built-in roles are returned as
+// they are.
+func CustomRole(tenant, role string) string {
+	if _, ok := Roles[role]; ok || role == "" {
+		return role
+	}
+
+	return tenant + customRoleSeparator + role
+}
+
+// getPermissions gets the permissions of a built-in role or of a custom role qualified by CustomRole.
+func getPermissions(role string) (Permissions, bool) {
+	if permissions, ok := RolePermissions[role]; ok {
+		return permissions, true
+	}
+
+	parts := strings.SplitN(role, customRoleSeparator, 2)
+	if len(parts) != 2 || roleResolver == nil {
+		return nil, false
+	}
+
+	return roleResolver(parts[0], parts[1])
+}
+
 // CheckMember checks if a models.User's ID is a models.Namespace's member. A models.User is a member if its ID is in
 // the models.Namespace's members list.
 func CheckMember(namespace *models.Namespace, id string) (*models.Member, bool) {
@@ -96,7 +137,7 @@ func CheckRole(active, passive string) bool {
 }
 
 // EvaluatePermission checks if a models.Namespace's member has the role that allows an action. Each role has a list of
-// allowed actions.
+// allowed actions, being the built-in roles or the custom roles of the namespace qualified by CustomRole.
 //
 // Role is the member's role from who is acting, Action is the action that is being performed and callback is a function
 // to be called if the action is allowed.
@@ -111,7 +152,7 @@ func EvaluatePermission(role string, action int, callback func() error) error {
 		return false
 	}
 
-	permission, ok := RolePermissions[role]
+	permission, ok := getPermissions(role)
 	if !ok {
 		return ErrForbidden
 	}
@@ -129,5 +170,5 @@ func EvaluateNamespace(namespace *models.Namespace, userID string, action int, c
 		return ErrForbidden
 	}
 
-	return EvaluatePermission(member.Role, action, callback)
+	return EvaluatePermission(CustomRole(namespace.TenantID, member.Role), action, callback)
 }
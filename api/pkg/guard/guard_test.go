@@ -84,6 +84,77 @@ func TestEvaluatePermission(t *testing.T) {
 	}
 }
 
+func TestEvaluateCustomRole(t *testing.T) {
+	SetRoleResolver(func(tenant, name string) (Permissions, bool) {
+		if tenant != "tenant" || name != "support" {
+			return nil, false
+		}
+
+		return Permissions{SessionPlay}, true
+	})
+	defer SetRoleResolver(nil)
+
+	cases := []struct {
+		description string
+		role        string
+		action      int
+		expected    error
+	}{
+		{
+			description: "fails when the custom role does not allow the action",
+			role:        CustomRole("tenant", "support"),
+			action:      Actions.Device.Accept,
+			expected:    ErrForbidden,
+		},
+		{
+			description: "fails when the custom role is of another namespace",
+			role:        CustomRole("other", "support"),
+			action:      Actions.Session.Play,
+			expected:    ErrForbidden,
+		},
+		{
+			description: "fails when the custom role is not qualified by the tenant",
+			role:        "support",
+			action:      Actions.Session.Play,
+			expected:    ErrForbidden,
+		},
+		{
+			description: "succeeds when the custom role allows the action",
+			role:        CustomRole("tenant", "support"),
+			action:      Actions.Session.Play,
+			expected:    nil,
+		},
+		{
+			description: "succeeds with a built-in role",
+			role:        CustomRole("tenant", RoleOwner),
+			action:      Actions.Device.Accept,
+			expected:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, EvaluatePermission(tc.role, tc.action, func() error {
+				return nil
+			}))
+		})
+	}
+}
+
+func TestGetCustomRolePermissions(t *testing.T) {
+	permissions, ok := GetCustomRolePermissions([]string{"session_play", "device_connect"})
+	assert.True(t, ok)
+	assert.Equal(t, Permissions{SessionPlay, DeviceConnect}, permissions)
+
+	_, ok = GetCustomRolePermissions([]string{"session_play", "namespace_edit_member"})
+	assert.False(t, ok)
+
+	// The custom roles cannot grant more than the administrators have.
+	for _, code := range CustomRolePermissions {
+		assert.Contains(t, adminPermissions, code)
+	}
+}
+
 func TestEvaluateSubject(t *testing.T) {
 	mock := &mocks.Store{}
 
@@ -360,10 +431,15 @@ func TestCheckPermission(t *testing.T) {
 				Actions.Device.RenameTag,
 				Actions.Device.DeleteTag,
 
+				Actions.DeviceGroup.Create,
+				Actions.DeviceGroup.Edit,
+				Actions.DeviceGroup.Remove,
+
 				Actions.Session.Play,
 				Actions.Session.Close,
 				Actions.Session.Remove,
 				Actions.Session.Details,
+				Actions.Session.Approve,
 
 				Actions.Firewall.Create,
 				Actions.Firewall.Edit,
@@ -373,11 +449,21 @@ func TestCheckPermission(t *testing.T) {
 				Actions.PublicKey.Edit,
 				Actions.PublicKey.Remove,
 
+				Actions.Webhook.Create,
+				Actions.Webhook.Edit,
+				Actions.Webhook.Remove,
+
 				Actions.Namespace.Rename,
 				Actions.Namespace.AddMember,
 				Actions.Namespace.RemoveMember,
 				Actions.Namespace.EditMember,
 				Actions.Namespace.EnableSessionRecord,
+				Actions.Namespace.EditDeviceNaming,
+				Actions.Namespace.ApplyPolicy,
+				Actions.Namespace.EditGeoAccess,
+				Actions.Namespace.EditSessionTypes,
+				Actions.Namespace.EditRoles,
+				Actions.Namespace.ReadAuditLog,
 			},
 			requiredMocks: func() {
 			},
@@ -399,10 +485,15 @@ func TestCheckPermission(t *testing.T) {
 				Actions.Device.RenameTag,
 				Actions.Device.DeleteTag,
 
+				Actions.DeviceGroup.Create,
+				Actions.DeviceGroup.Edit,
+				Actions.DeviceGroup.Remove,
+
 				Actions.Session.Play,
 				Actions.Session.Close,
 				Actions.Session.Remove,
 				Actions.Session.Details,
+				Actions.Session.Approve,
 
 				Actions.Firewall.Create,
 				Actions.Firewall.Edit,
@@ -412,12 +503,23 @@ func TestCheckPermission(t *testing.T) {
 				Actions.PublicKey.Edit,
 				Actions.PublicKey.Remove,
 
+				Actions.Webhook.Create,
+				Actions.Webhook.Edit,
+				Actions.Webhook.Remove,
+
 				Actions.Namespace.Rename,
 				Actions.Namespace.AddMember,
 				Actions.Namespace.RemoveMember,
 				Actions.Namespace.EditMember,
 				Actions.Namespace.EnableSessionRecord,
+				Actions.Namespace.EditDeviceNaming,
+				Actions.Namespace.ApplyPolicy,
+				Actions.Namespace.EditGeoAccess,
+				Actions.Namespace.EditSessionTypes,
+				Actions.Namespace.EditRoles,
+				Actions.Namespace.ReadAuditLog,
 				Actions.Namespace.Delete,
+				Actions.Namespace.TransferOwnership,
 
 				Actions.Billing.AddPaymentMethod,
 				Actions.Billing.UpdatePaymentMethod,
@@ -16,10 +16,15 @@ const (
 	DeviceRenameTag
 	DeviceDeleteTag
 
+	DeviceGroupCreate
+	DeviceGroupEdit
+	DeviceGroupRemove
+
 	SessionPlay
 	SessionClose
 	SessionRemove
 	SessionDetails
+	SessionApprove
 
 	FirewallCreate
 	FirewallEdit
@@ -37,12 +42,23 @@ const (
 	PublicKeyRemoveTag
 	PublicKeyUpdateTag
 
+	WebhookCreate
+	WebhookEdit
+	WebhookRemove
+
 	NamespaceRename
 	NamespaceAddMember
 	NamespaceRemoveMember
 	NamespaceEditMember
 	NamespaceEnableSessionRecord
+	NamespaceEditDeviceNaming
+	NamespaceApplyPolicy
+	NamespaceEditGeoAccess
+	NamespaceEditSessionTypes
+	NamespaceEditRoles
+	NamespaceReadAuditLog
 	NamespaceDelete
+	NamespaceTransferOwnership
 
 	BillingChooseDevices
 	BillingAddPaymentMethod
@@ -90,10 +106,15 @@ var adminPermissions = Permissions{
 	DeviceRenameTag,
 	DeviceDeleteTag,
 
+	DeviceGroupCreate,
+	DeviceGroupEdit,
+	DeviceGroupRemove,
+
 	SessionPlay,
 	SessionClose,
 	SessionRemove,
 	SessionDetails,
+	SessionApprove,
 
 	FirewallCreate,
 	FirewallEdit,
@@ -109,11 +130,21 @@ var adminPermissions = Permissions{
 	PublicKeyRemoveTag,
 	PublicKeyUpdateTag,
 
+	WebhookCreate,
+	WebhookEdit,
+	WebhookRemove,
+
 	NamespaceRename,
 	NamespaceAddMember,
 	NamespaceRemoveMember,
 	NamespaceEditMember,
 	NamespaceEnableSessionRecord,
+	NamespaceEditDeviceNaming,
+	NamespaceApplyPolicy,
+	NamespaceEditGeoAccess,
+	NamespaceEditSessionTypes,
+	NamespaceEditRoles,
+	NamespaceReadAuditLog,
 }
 
 var ownerPermissions = Permissions{
@@ -130,10 +161,15 @@ var ownerPermissions = Permissions{
 	DeviceRenameTag,
 	DeviceDeleteTag,
 
+	DeviceGroupCreate,
+	DeviceGroupEdit,
+	DeviceGroupRemove,
+
 	SessionPlay,
 	SessionClose,
 	SessionRemove,
 	SessionDetails,
+	SessionApprove,
 
 	FirewallCreate,
 	FirewallEdit,
@@ -149,12 +185,23 @@ var ownerPermissions = Permissions{
 	PublicKeyRemoveTag,
 	PublicKeyUpdateTag,
 
+	WebhookCreate,
+	WebhookEdit,
+	WebhookRemove,
+
 	NamespaceRename,
 	NamespaceAddMember,
 	NamespaceRemoveMember,
 	NamespaceEditMember,
 	NamespaceEnableSessionRecord,
+	NamespaceEditDeviceNaming,
+	NamespaceApplyPolicy,
+	NamespaceEditGeoAccess,
+	NamespaceEditSessionTypes,
+	NamespaceEditRoles,
+	NamespaceReadAuditLog,
 	NamespaceDelete,
+	NamespaceTransferOwnership,
 
 	BillingChooseDevices,
 	BillingAddPaymentMethod,
@@ -164,3 +211,74 @@ var ownerPermissions = Permissions{
 	BillingCreateSubscription,
 	BillingGetSubscription,
 }
+
+// CustomRolePermissions maps the names of the actions that can be granted to a custom role to their codes.
+//
+// A custom role cannot grant the actions restricted to the owner, nor manage the members and the roles of the
+// namespace, so a member with a custom role is never able to raise its own permissions.
+var CustomRolePermissions = map[string]int{
+	"device_accept":  DeviceAccept,
+	"device_reject":  DeviceReject,
+	"device_remove":  DeviceRemove,
+	"device_connect": DeviceConnect,
+	"device_rename":  DeviceRename,
+	"device_details": DeviceDetails,
+
+	"device_create_tag": DeviceCreateTag,
+	"device_update_tag": DeviceUpdateTag,
+	"device_remove_tag": DeviceRemoveTag,
+	"device_rename_tag": DeviceRenameTag,
+	"device_delete_tag": DeviceDeleteTag,
+
+	"device_group_create": DeviceGroupCreate,
+	"device_group_edit":   DeviceGroupEdit,
+	"device_group_remove": DeviceGroupRemove,
+
+	"session_play":    SessionPlay,
+	"session_close":   SessionClose,
+	"session_remove":  SessionRemove,
+	"session_details": SessionDetails,
+	"session_approve": SessionApprove,
+
+	"firewall_create":     FirewallCreate,
+	"firewall_edit":       FirewallEdit,
+	"firewall_remove":     FirewallRemove,
+	"firewall_add_tag":    FirewallAddTag,
+	"firewall_remove_tag": FirewallRemoveTag,
+	"firewall_update_tag": FirewallUpdateTag,
+
+	"public_key_create":     PublicKeyCreate,
+	"public_key_edit":       PublicKeyEdit,
+	"public_key_remove":     PublicKeyRemove,
+	"public_key_add_tag":    PublicKeyAddTag,
+	"public_key_remove_tag": PublicKeyRemoveTag,
+	"public_key_update_tag": PublicKeyUpdateTag,
+
+	"webhook_create": WebhookCreate,
+	"webhook_edit":   WebhookEdit,
+	"webhook_remove": WebhookRemove,
+
+	"namespace_rename":                NamespaceRename,
+	"namespace_enable_session_record": NamespaceEnableSessionRecord,
+	"namespace_edit_device_naming":    NamespaceEditDeviceNaming,
+	"namespace_apply_policy":          NamespaceApplyPolicy,
+	"namespace_edit_geo_access":       NamespaceEditGeoAccess,
+	"namespace_edit_session_types":    NamespaceEditSessionTypes,
+	"namespace_read_audit_log":        NamespaceReadAuditLog,
+}
+
+// GetCustomRolePermissions converts the names of the actions granted to a custom role to their codes. It returns false
+// when any of them cannot be granted to a custom role.
+func GetCustomRolePermissions(names []string) (Permissions, bool) {
+	permissions := make(Permissions, 0, len(names))
+	for _, name := range names {
+		code, ok := CustomRolePermissions[name]
+		if !ok {
+			return nil, false
+		}
+
+		permissions = append(permissions, code)
+	}
+
+	return permissions, true
+}
@@ -0,0 +1,42 @@
+// Package mailer sends the emails of the API, as the invitations to join a namespace.
+package mailer
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ErrNoRecipients is returned when a message has no recipients.
+var ErrNoRecipients = errors.New("message has no recipients")
+
+// Message is an email message in plain text.
+type Message struct {
+	To      []string
+	Subject string
+	Body    string
+}
+
+// Mailer sends email messages.
+type Mailer interface {
+	Send(ctx context.Context, message *Message) error
+}
+
+type nullMailer struct{}
+
+// NewNullMailer creates a Mailer that only logs the messages, used when no mail server is configured. The message's
+// body is logged too, so the administrator can forward it, as the invitation links.
+func NewNullMailer() Mailer {
+	return &nullMailer{}
+}
+
+func (m *nullMailer) Send(_ context.Context, message *Message) error {
+	logrus.WithFields(logrus.Fields{
+		"to":      message.To,
+		"subject": message.Subject,
+		"body":    message.Body,
+	}).Warn("Email not sent as no mail server is configured")
+
+	return nil
+}
@@ -0,0 +1,165 @@
+package mailer
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	clockmocks "github.com/shellhub-io/shellhub/pkg/clock/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSMTP is a SMTP server accepting a single connection and keeping the received message.
+type fakeSMTP struct {
+	listener net.Listener
+	// auth is the decoded response of the AUTH PLAIN command.
+	auth string
+	from string
+	to   []string
+	data string
+	done chan struct{}
+}
+
+func newFakeSMTP(t *testing.T) *fakeSMTP {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := &fakeSMTP{listener: listener, done: make(chan struct{})}
+	go server.serve()
+
+	return server
+}
+
+func (s *fakeSMTP) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *fakeSMTP) serve() {
+	defer close(s.done)
+
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	reply := func(line string) {
+		conn.Write([]byte(line + "\r\n")) // nolint:errcheck
+	}
+
+	reply("220 localhost ESMTP")
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+		command := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+
+		switch command {
+		case "EHLO":
+			reply("250-localhost")
+			reply("250 AUTH PLAIN")
+		case "AUTH":
+			fields := strings.Fields(line)
+			decoded, _ := base64.StdEncoding.DecodeString(fields[len(fields)-1])
+			s.auth = string(decoded)
+			reply("235 2.7.0 Authentication successful")
+		case "MAIL":
+			s.from = strings.Trim(strings.TrimPrefix(line, "MAIL FROM:"), "<>")
+			reply("250 OK")
+		case "RCPT":
+			s.to = append(s.to, strings.Trim(strings.TrimPrefix(line, "RCPT TO:"), "<>"))
+			reply("250 OK")
+		case "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+
+			var data strings.Builder
+			for {
+				line, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+
+				if line == ".\r\n" {
+					break
+				}
+
+				data.WriteString(line)
+			}
+
+			s.data = data.String()
+			reply("250 OK")
+		case "QUIT":
+			reply("221 Bye")
+
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+func TestSMTPSend(t *testing.T) {
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	clockMock := &clockmocks.Clock{}
+	clock.DefaultBackend = clockMock
+	clockMock.On("Now").Return(now)
+
+	server := newFakeSMTP(t)
+	defer server.listener.Close()
+
+	mailer := NewSMTP("127.0.0.1", server.port(), "user", "password", "shellhub@example.com", false)
+
+	err := mailer.Send(context.Background(), &Message{
+		To:      []string{"john@example.com"},
+		Subject: "Invitation",
+		Body:    "Hello\nWelcome",
+	})
+	assert.NoError(t, err)
+
+	<-server.done
+
+	assert.Equal(t, "\x00user\x00password", server.auth)
+	assert.Equal(t, "shellhub@example.com", server.from)
+	assert.Equal(t, []string{"john@example.com"}, server.to)
+	assert.Equal(t, "From: shellhub@example.com\r\n"+
+		"To: john@example.com\r\n"+
+		"Subject: Invitation\r\n"+
+		"Date: Tue, 01 Jun 2021 12:00:00 +0000\r\n"+
+		"MIME-Version: 1.0\r\n"+
+		"Content-Type: text/plain; charset=UTF-8\r\n"+
+		"\r\n"+
+		"Hello\r\n"+
+		"Welcome\r\n", server.data)
+}
+
+func TestSMTPSendFails(t *testing.T) {
+	mailer := NewSMTP("127.0.0.1", 1, "", "", "shellhub@example.com", false)
+
+	assert.Equal(t, ErrNoRecipients, mailer.Send(context.Background(), &Message{Subject: "Invitation"}))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	// The port is closed before sending, so the connection is refused.
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	mailer = NewSMTP("127.0.0.1", port, "", "", "shellhub@example.com", false)
+	err = mailer.Send(context.Background(), &Message{To: []string{"john@example.com"}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), strconv.Itoa(port))
+}
@@ -0,0 +1,47 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	GetAuditLogListURL = "/audit-logs"
+)
+
+// GetAuditLogList lists the audit logs of the namespace, the latest first, filtered by the actor, the action, the
+// target and the time range set on the query.
+func (h *Handler) GetAuditLogList(c gateway.Context) error {
+	query := paginator.NewQuery()
+	if err := c.Bind(query); err != nil {
+		return err
+	}
+
+	query.Normalize()
+
+	var filter models.AuditLogFilter
+	if err := c.Bind(&filter); err != nil {
+		return err
+	}
+
+	var logs []models.AuditLog
+	var count int
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Namespace.ReadAuditLog, func() error {
+		var err error
+		logs, count, err = h.service.ListAuditLogs(c.Ctx(), *query, &filter)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, logs)
+}
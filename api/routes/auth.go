@@ -2,6 +2,7 @@ package routes
 
 import (
 	"net/http"
+	"net/url"
 
 	jwt "github.com/golang-jwt/jwt"
 	"github.com/labstack/echo/v4"
@@ -14,13 +15,17 @@ import (
 )
 
 const (
-	AuthRequestURL   = "/auth"
-	AuthDeviceURL    = "/devices/auth"
-	AuthDeviceURLV2  = "/auth/device"
-	AuthUserURL      = "/login"
-	AuthUserURLV2    = "/auth/user"
-	AuthUserTokenURL = "/auth/token/:tenant" //nolint:gosec
-	AuthPublicKeyURL = "/auth/ssh"
+	AuthRequestURL         = "/auth"
+	AuthDeviceURL          = "/devices/auth"
+	AuthDeviceURLV2        = "/auth/device"
+	AuthUserURL            = "/login"
+	AuthUserURLV2          = "/auth/user"
+	AuthUserTokenURL       = "/auth/token/:tenant" //nolint:gosec
+	AuthUserScopedTokenURL = "/auth/token"         //nolint:gosec
+	AuthPublicKeyURL       = "/auth/ssh"
+
+	GetLoginLockoutsURL = "/auth/lockouts"
+	UnlockLoginURL      = "/auth/lockouts"
 )
 
 func (h *Handler) AuthRequest(c gateway.Context) error {
@@ -42,6 +47,24 @@ func (h *Handler) AuthRequest(c gateway.Context) error {
 			return err
 		}
 
+		// A user-scoped token has no namespace, so the namespace must be set explicitly on every request but the one
+		// renewing the token. Otherwise, the routes listing the namespace's resources would list every namespace's ones.
+		if claims.Scoped && c.Request().Header.Get("X-Tenant-ID") == "" && !isScopedTokenRequest(c.Request()) {
+			return svc.NewErrAuthUnathorized(nil)
+		}
+
+		// A namespace set explicitly on the request replaces the token's one when the user is a member of it, with the
+		// user's role on it.
+		if tenant := c.Request().Header.Get("X-Tenant-ID"); tenant != "" && tenant != claims.Tenant {
+			role, err := h.service.AuthTenant(c.Ctx(), claims.ID, tenant)
+			if err != nil {
+				return err
+			}
+
+			claims.Tenant = tenant
+			claims.Role = role
+		}
+
 		// Extract tenant and username from JWT
 		c.Response().Header().Set("X-Tenant-ID", claims.Tenant)
 		c.Response().Header().Set("X-Username", claims.Username)
@@ -65,6 +88,17 @@ func (h *Handler) AuthRequest(c gateway.Context) error {
 	return svc.NewErrAuthUnathorized(nil)
 }
 
+// isScopedTokenRequest checks if the request authenticated by the gateway, whose URI is sent on the X-Original-URI
+// header, renews a user-scoped token.
+func isScopedTokenRequest(req *http.Request) bool {
+	uri, err := url.Parse(req.Header.Get("X-Original-URI"))
+	if err != nil {
+		return false
+	}
+
+	return uri.Path == "/api"+AuthUserScopedTokenURL
+}
+
 func (h *Handler) AuthDevice(c gateway.Context) error {
 	var req models.DeviceAuthRequest
 
@@ -93,7 +127,8 @@ func (h *Handler) AuthUser(c gateway.Context) error {
 		return err
 	}
 
-	res, err := h.service.AuthUser(c.Ctx(), req)
+	ip := c.Request().Header.Get("X-Real-IP")
+	res, err := h.service.AuthUser(c.Ctx(), req, ip)
 	if err != nil {
 		return err
 	}
@@ -137,6 +172,20 @@ func (h *Handler) AuthSwapToken(c gateway.Context) error {
 	return c.JSON(http.StatusOK, res)
 }
 
+func (h *Handler) AuthUserToken(c gateway.Context) error {
+	id := ""
+	if v := c.ID(); v != nil {
+		id = v.ID
+	}
+
+	res, err := h.service.AuthUserToken(c.Ctx(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
 func (h *Handler) AuthPublicKey(c gateway.Context) error {
 	var req models.PublicKeyAuthRequest
 
@@ -183,3 +232,38 @@ func DecodeMap(input, output interface{}) error {
 
 	return decoder.Decode(input)
 }
+
+func (h *Handler) GetLoginLockouts(c gateway.Context) error {
+	var query struct {
+		Username  string `query:"username"`
+		IPAddress string `query:"ip_address"`
+	}
+
+	if err := c.Bind(&query); err != nil {
+		return err
+	}
+
+	lockouts, err := h.service.GetLoginLockouts(c.Ctx(), query.Username, query.IPAddress)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, lockouts)
+}
+
+func (h *Handler) UnlockLogin(c gateway.Context) error {
+	var query struct {
+		Username  string `query:"username"`
+		IPAddress string `query:"ip_address"`
+	}
+
+	if err := c.Bind(&query); err != nil {
+		return err
+	}
+
+	if err := h.service.UnlockLogin(c.Ctx(), query.Username, query.IPAddress); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
@@ -3,6 +3,7 @@ package routes
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/shellhub-io/shellhub/api/pkg/gateway"
 	"github.com/shellhub-io/shellhub/api/pkg/guard"
@@ -23,6 +24,10 @@ const (
 	CreateTagURL       = "/devices/:uid/tags"       // Add a tag to a device.
 	UpdateTagURL       = "/devices/:uid/tags"       // Update device's tags with a new set.
 	RemoveTagURL       = "/devices/:uid/tags/:name" // Delete a tag from a device.
+
+	GetDeviceHistoryURL      = "/devices/:uid/history"      // List the connection events of a device.
+	GetDeviceAvailabilityURL = "/devices/:uid/availability" // Get the availability of a device in a period.
+	TransferDeviceURL        = "/devices/:uid/transfer"     // Transfer a device to another namespace.
 )
 
 const (
@@ -47,7 +52,16 @@ func (h *Handler) GetDeviceList(c gateway.Context) error {
 
 	query.Normalize()
 
-	devices, count, err := h.service.ListDevices(c.Ctx(), query.Query, query.Filter, query.Status, query.SortBy, query.OrderBy)
+	var tenantID, userID string
+	if c.Tenant() != nil {
+		tenantID = c.Tenant().ID
+	}
+
+	if c.ID() != nil {
+		userID = c.ID().ID
+	}
+
+	devices, count, err := h.service.ListDevices(c.Ctx(), query.Query, query.Filter, query.Status, query.SortBy, query.OrderBy, tenantID, userID)
 	if err != nil {
 		return err
 	}
@@ -57,12 +71,68 @@ func (h *Handler) GetDeviceList(c gateway.Context) error {
 	return c.JSON(http.StatusOK, devices)
 }
 
+type periodQuery struct {
+	From time.Time `query:"from"`
+	To   time.Time `query:"to"`
+}
+
+type historyQuery struct {
+	From time.Time `query:"from"`
+	To   time.Time `query:"to"`
+	paginator.Query
+}
+
+func (h *Handler) GetDeviceHistory(c gateway.Context) error {
+	query := historyQuery{}
+	if err := c.Bind(&query); err != nil {
+		return err
+	}
+
+	query.Normalize()
+
+	events, count, err := h.service.ListDeviceHistory(c.Ctx(), models.UID(c.Param(ParamDeviceID)), query.Query, query.From, query.To)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, events)
+}
+
+func (h *Handler) GetDeviceAvailability(c gateway.Context) error {
+	query := periodQuery{}
+	if err := c.Bind(&query); err != nil {
+		return err
+	}
+
+	availability, err := h.service.GetDeviceAvailability(c.Ctx(), models.UID(c.Param(ParamDeviceID)), query.From, query.To)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, availability)
+}
+
 func (h *Handler) GetDevice(c gateway.Context) error {
 	device, err := h.service.GetDevice(c.Ctx(), models.UID(c.Param(ParamDeviceID)))
 	if err != nil {
 		return err
 	}
 
+	var tenantID, userID string
+	if c.Tenant() != nil {
+		tenantID = c.Tenant().ID
+	}
+
+	if c.ID() != nil {
+		userID = c.ID().ID
+	}
+
+	if err := h.service.EvaluateMemberScope(c.Ctx(), tenantID, userID, models.UID(device.UID)); err != nil {
+		return err
+	}
+
 	return c.JSON(http.StatusOK, device)
 }
 
@@ -110,6 +180,44 @@ func (h *Handler) RenameDevice(c gateway.Context) error {
 	return c.NoContent(http.StatusOK)
 }
 
+func (h *Handler) TransferDevice(c gateway.Context) error {
+	var req struct {
+		Tenant   string `json:"tenant" validate:"required"`
+		Sessions bool   `json:"sessions"`
+	}
+
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	tenantID := ""
+	if c.Tenant() != nil {
+		tenantID = c.Tenant().ID
+	}
+
+	userID := ""
+	if v := c.ID(); v != nil {
+		userID = v.ID
+	}
+
+	var device *models.Device
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Device.Remove, func() error {
+		var err error
+		device, err = h.service.TransferDevice(c.Ctx(), models.UID(c.Param(ParamDeviceID)), tenantID, req.Tenant, userID, req.Sessions)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, device)
+}
+
 func (h *Handler) OfflineDevice(c gateway.Context) error {
 	if err := h.service.UpdateDeviceStatus(c.Ctx(), models.UID(c.Param(ParamDeviceID)), false); err != nil {
 		return err
@@ -124,6 +232,10 @@ func (h *Handler) LookupDevice(c gateway.Context) error {
 		Name      string `query:"name"`
 		Username  string `query:"username"`
 		IPAddress string `query:"ip_address"`
+		// Member is the ID of the namespace member opening the connection, when it is known.
+		Member string `query:"member"`
+		// PublicKey is the fingerprint of the namespace public key authenticating the connection, when it is known.
+		PublicKey string `query:"public_key"`
 	}
 
 	if err := c.Bind(&query); err != nil {
@@ -137,6 +249,20 @@ func (h *Handler) LookupDevice(c gateway.Context) error {
 		return err
 	}
 
+	if err := h.service.EvaluateMemberScope(c.Ctx(), device.TenantID, query.Member, models.UID(device.UID)); err != nil {
+		return err
+	}
+
+	if query.PublicKey != "" {
+		if err := h.service.EvaluateKeyScope(c.Ctx(), device.TenantID, query.PublicKey, models.UID(device.UID)); err != nil {
+			return err
+		}
+	}
+
+	if err := h.service.EvaluateGeoAccess(c.Ctx(), device, query.IPAddress); err != nil {
+		return err
+	}
+
 	return c.JSON(http.StatusOK, device)
 }
 
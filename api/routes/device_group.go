@@ -0,0 +1,177 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	GetDeviceGroupListURL        = "/groups"
+	GetDeviceGroupURL            = "/groups/:id"
+	CreateDeviceGroupURL         = "/groups"
+	UpdateDeviceGroupURL         = "/groups/:id"
+	DeleteDeviceGroupURL         = "/groups/:id"
+	GetDeviceGroupMembersURL     = "/groups/:id/devices"    // List the devices that belong to a group.
+	ApplyDeviceGroupOperationURL = "/groups/:id/operations" // Apply an operation to each device of a group.
+)
+
+const (
+	ParamDeviceGroupID = "id"
+)
+
+func (h *Handler) GetDeviceGroupList(c gateway.Context) error {
+	query := paginator.NewQuery()
+	if err := c.Bind(query); err != nil {
+		return err
+	}
+
+	query.Normalize()
+
+	groups, count, err := h.service.ListDeviceGroups(c.Ctx(), *query)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, groups)
+}
+
+func (h *Handler) GetDeviceGroup(c gateway.Context) error {
+	tenant := ""
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	group, err := h.service.GetDeviceGroup(c.Ctx(), c.Param(ParamDeviceGroupID), tenant)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, group)
+}
+
+func (h *Handler) CreateDeviceGroup(c gateway.Context) error {
+	var group models.DeviceGroup
+	if err := c.Bind(&group); err != nil {
+		return err
+	}
+
+	tenant := ""
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.DeviceGroup.Create, func() error {
+		return h.service.CreateDeviceGroup(c.Ctx(), &group, tenant)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, group)
+}
+
+func (h *Handler) UpdateDeviceGroup(c gateway.Context) error {
+	var params models.DeviceGroupUpdate
+	if err := c.Bind(&params); err != nil {
+		return err
+	}
+
+	tenant := ""
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var group *models.DeviceGroup
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.DeviceGroup.Edit, func() error {
+		var err error
+		group, err = h.service.UpdateDeviceGroup(c.Ctx(), c.Param(ParamDeviceGroupID), tenant, &params)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, group)
+}
+
+func (h *Handler) DeleteDeviceGroup(c gateway.Context) error {
+	tenant := ""
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.DeviceGroup.Remove, func() error {
+		return h.service.DeleteDeviceGroup(c.Ctx(), c.Param(ParamDeviceGroupID), tenant)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) GetDeviceGroupMembers(c gateway.Context) error {
+	tenant := ""
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	uids, err := h.service.ListDeviceGroupMembers(c.Ctx(), c.Param(ParamDeviceGroupID), tenant)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, uids)
+}
+
+func (h *Handler) ApplyDeviceGroupOperation(c gateway.Context) error {
+	var req struct {
+		Operation string `json:"operation"`
+		Value     string `json:"value"`
+	}
+
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	tenant := ""
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	// The operation requires the same permission needed to apply it to a single device.
+	actions := map[string]int{
+		services.DeviceGroupOperationAccept: guard.Actions.Device.Accept,
+		services.DeviceGroupOperationReject: guard.Actions.Device.Reject,
+		services.DeviceGroupOperationRemove: guard.Actions.Device.Remove,
+		services.DeviceGroupOperationTag:    guard.Actions.Device.CreateTag,
+		services.DeviceGroupOperationUntag:  guard.Actions.Device.RemoveTag,
+	}
+
+	action, ok := actions[req.Operation]
+	if !ok {
+		return services.NewErrDeviceGroupOperation(req.Operation, nil)
+	}
+
+	var result *models.DeviceGroupBulkResult
+	err := guard.EvaluatePermission(c.Role(), action, func() error {
+		var err error
+		result, err = h.service.ApplyDeviceGroupOperation(c.Ctx(), c.Param(ParamDeviceGroupID), tenant, req.Operation, req.Value)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,269 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	GetFirewallRuleListURL = "/firewall/rules"
+	GetFirewallRuleURL     = "/firewall/rules/:id"
+	CreateFirewallRuleURL  = "/firewall/rules"
+	UpdateFirewallRuleURL  = "/firewall/rules/:id"
+	DeleteFirewallRuleURL  = "/firewall/rules/:id"
+	EvaluateFirewallURL    = "/firewall/rules/evaluate" // Evaluate a connection to a device against the firewall rules.
+	SimulateFirewallURL    = "/firewall/rules/simulate" // Evaluate a hypothetical connection against the firewall rules.
+
+	GetIPSetListURL = "/firewall/ipsets"
+	GetIPSetURL     = "/firewall/ipsets/:id"
+	CreateIPSetURL  = "/firewall/ipsets"
+	UpdateIPSetURL  = "/firewall/ipsets/:id"
+	DeleteIPSetURL  = "/firewall/ipsets/:id"
+)
+
+const (
+	ParamFirewallRuleID = "id"
+	ParamIPSetID        = "id"
+)
+
+func (h *Handler) GetFirewallRuleList(c gateway.Context) error {
+	query := paginator.NewQuery()
+	if err := c.Bind(query); err != nil {
+		return err
+	}
+
+	query.Normalize()
+
+	rules, count, err := h.service.ListFirewallRules(c.Ctx(), *query)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, rules)
+}
+
+func (h *Handler) GetFirewallRule(c gateway.Context) error {
+	tenant := ""
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	rule, err := h.service.GetFirewallRule(c.Ctx(), c.Param(ParamFirewallRuleID), tenant)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, rule)
+}
+
+func (h *Handler) CreateFirewallRule(c gateway.Context) error {
+	var req models.FirewallRuleFields
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	tenant := ""
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var rule *models.FirewallRule
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Firewall.Create, func() error {
+		var err error
+		rule, err = h.service.CreateFirewallRule(c.Ctx(), tenant, req)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, rule)
+}
+
+func (h *Handler) UpdateFirewallRule(c gateway.Context) error {
+	var req models.FirewallRuleUpdate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	tenant := ""
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var rule *models.FirewallRule
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Firewall.Edit, func() error {
+		var err error
+		rule, err = h.service.UpdateFirewallRule(c.Ctx(), c.Param(ParamFirewallRuleID), tenant, req)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, rule)
+}
+
+func (h *Handler) DeleteFirewallRule(c gateway.Context) error {
+	tenant := ""
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Firewall.Remove, func() error {
+		return h.service.DeleteFirewallRule(c.Ctx(), c.Param(ParamFirewallRuleID), tenant)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) EvaluateFirewall(c gateway.Context) error {
+	var query models.FirewallEvaluation
+	if err := c.Bind(&query); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&query); err != nil {
+		return err
+	}
+
+	decision, err := h.service.EvaluateFirewall(c.Ctx(), &query)
+	if err != nil {
+		return err
+	}
+
+	if !decision.Allowed {
+		return c.JSON(http.StatusForbidden, decision)
+	}
+
+	return c.JSON(http.StatusOK, decision)
+}
+
+func (h *Handler) SimulateFirewall(c gateway.Context) error {
+	var req models.FirewallSimulation
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	tenant := ""
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	decision, err := h.service.SimulateFirewall(c.Ctx(), tenant, &req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, decision)
+}
+
+func (h *Handler) GetIPSetList(c gateway.Context) error {
+	query := paginator.NewQuery()
+	if err := c.Bind(query); err != nil {
+		return err
+	}
+
+	query.Normalize()
+
+	sets, count, err := h.service.ListIPSets(c.Ctx(), *query)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, sets)
+}
+
+func (h *Handler) GetIPSet(c gateway.Context) error {
+	tenant := ""
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	set, err := h.service.GetIPSet(c.Ctx(), c.Param(ParamIPSetID), tenant)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, set)
+}
+
+func (h *Handler) CreateIPSet(c gateway.Context) error {
+	var set models.IPSet
+	if err := c.Bind(&set); err != nil {
+		return err
+	}
+
+	tenant := ""
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Firewall.Create, func() error {
+		return h.service.CreateIPSet(c.Ctx(), &set, tenant)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, set)
+}
+
+func (h *Handler) UpdateIPSet(c gateway.Context) error {
+	var req models.IPSetUpdate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	tenant := ""
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var set *models.IPSet
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Firewall.Edit, func() error {
+		var err error
+		set, err = h.service.UpdateIPSet(c.Ctx(), c.Param(ParamIPSetID), tenant, &req)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, set)
+}
+
+func (h *Handler) DeleteIPSet(c gateway.Context) error {
+	tenant := ""
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Firewall.Remove, func() error {
+		return h.service.DeleteIPSet(c.Ctx(), c.Param(ParamIPSetID), tenant)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
@@ -24,6 +24,8 @@ func FromErrServiceToHTTPStatus(code int) int {
 		return http.StatusUnauthorized
 	case services.ErrCodeForbidden:
 		return http.StatusForbidden
+	case services.ErrCodeTooManyRequests:
+		return http.StatusTooManyRequests
 	default:
 		return http.StatusInternalServerError
 	}
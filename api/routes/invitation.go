@@ -0,0 +1,134 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	GetInvitationListURL = "/invitations"
+	CreateInvitationURL  = "/invitations"
+	DeleteInvitationURL  = "/invitations/:id"
+	AcceptInvitationURL  = "/invitations/accept"
+	DeclineInvitationURL = "/invitations/decline"
+)
+
+const (
+	ParamInvitationID = "id"
+)
+
+func (h *Handler) GetInvitationList(c gateway.Context) error {
+	query := paginator.NewQuery()
+	if err := c.Bind(query); err != nil {
+		return err
+	}
+
+	query.Normalize()
+
+	invitations, count, err := h.service.ListInvitations(c.Ctx(), *query)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, invitations)
+}
+
+func (h *Handler) CreateInvitation(c gateway.Context) error {
+	var req models.InvitationFields
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	tenant := ""
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var uid string
+	if c.ID() != nil {
+		uid = c.ID().ID
+	}
+
+	var invitation *models.Invitation
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Namespace.AddMember, func() error {
+		var err error
+		invitation, err = h.service.CreateInvitation(c.Ctx(), tenant, uid, &req)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, invitation)
+}
+
+func (h *Handler) DeleteInvitation(c gateway.Context) error {
+	tenant := ""
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Namespace.RemoveMember, func() error {
+		return h.service.DeleteInvitation(c.Ctx(), c.Param(ParamInvitationID), tenant)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// AcceptInvitation adds the authenticated user to the namespace of the invitation. As the user is not yet a member, the
+// route does not depend on the namespace of the user's token.
+func (h *Handler) AcceptInvitation(c gateway.Context) error {
+	var req models.InvitationAnswer
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var uid string
+	if c.ID() != nil {
+		uid = c.ID().ID
+	}
+
+	namespace, err := h.service.AcceptInvitation(c.Ctx(), uid, req.Token)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, namespace)
+}
+
+func (h *Handler) DeclineInvitation(c gateway.Context) error {
+	var req models.InvitationAnswer
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var uid string
+	if c.ID() != nil {
+		uid = c.ID().ID
+	}
+
+	if err := h.service.DeclineInvitation(c.Ctx(), uid, req.Token); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
@@ -18,8 +18,18 @@ const (
 	AddNamespaceUserURL        = "/namespaces/:tenant/members"
 	RemoveNamespaceUserURL     = "/namespaces/:tenant/members/:uid"
 	EditNamespaceUserURL       = "/namespaces/:tenant/members/:uid"
+	EditMemberScopeURL         = "/namespaces/:tenant/members/:uid/scope"
+	DeleteMemberScopeURL       = "/namespaces/:tenant/members/:uid/scope"
+	TransferNamespaceURL       = "/namespaces/:tenant/members/:uid/transfer"
 	GetSessionRecordURL        = "/users/security"
 	EditSessionRecordStatusURL = "/users/security/:tenant"
+	EditDeviceNamingURL        = "/namespaces/:tenant/device-naming"
+	DeleteDeviceNamingURL      = "/namespaces/:tenant/device-naming"
+	EditGeoAccessURL           = "/namespaces/:tenant/geo-access"
+	DeleteGeoAccessURL         = "/namespaces/:tenant/geo-access"
+	EditSessionTypesURL        = "/namespaces/:tenant/session-types"
+	DeleteSessionTypesURL      = "/namespaces/:tenant/session-types"
+	EvaluateSessionTypeURL     = "/ssh/session-types"
 )
 
 const (
@@ -229,6 +239,66 @@ func (h *Handler) EditNamespaceUser(c gateway.Context) error {
 	return c.NoContent(http.StatusOK)
 }
 
+// TransferNamespace makes the member the owner of the namespace. Only the owner can transfer the ownership.
+func (h *Handler) TransferNamespace(c gateway.Context) error {
+	var uid string
+	if c.ID() != nil {
+		uid = c.ID().ID
+	}
+
+	ns, err := h.service.GetNamespace(c.Ctx(), c.Param(ParamNamespaceTenant))
+	if err != nil || ns == nil {
+		return c.NoContent(http.StatusNotFound)
+	}
+
+	var namespace *models.Namespace
+	err = guard.EvaluateNamespace(ns, uid, guard.Actions.Namespace.TransferOwnership, func() error {
+		var err error
+		namespace, err = h.service.TransferNamespaceOwnership(c.Ctx(), ns.TenantID, uid, c.Param(ParamNamespaceMemberID))
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, namespace)
+}
+
+func (h *Handler) EditMemberScope(c gateway.Context) error {
+	var req models.MemberScope
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	return h.editMemberScope(c, &req)
+}
+
+func (h *Handler) DeleteMemberScope(c gateway.Context) error {
+	return h.editMemberScope(c, nil)
+}
+
+func (h *Handler) editMemberScope(c gateway.Context, scope *models.MemberScope) error {
+	var uid string
+	if c.ID() != nil {
+		uid = c.ID().ID
+	}
+
+	ns, err := h.service.GetNamespace(c.Ctx(), c.Param(ParamNamespaceTenant))
+	if err != nil || ns == nil {
+		return c.NoContent(http.StatusNotFound)
+	}
+
+	err = guard.EvaluateNamespace(ns, uid, guard.Actions.Namespace.EditMember, func() error {
+		return h.service.EditMemberScope(c.Ctx(), ns.TenantID, uid, c.Param(ParamNamespaceMemberID), scope)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
 func (h *Handler) EditSessionRecordStatus(c gateway.Context) error {
 	var req struct {
 		SessionRecord bool `json:"session_record"`
@@ -259,6 +329,125 @@ func (h *Handler) EditSessionRecordStatus(c gateway.Context) error {
 	return c.NoContent(http.StatusOK)
 }
 
+func (h *Handler) EditDeviceNaming(c gateway.Context) error {
+	var req models.DeviceNaming
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	return h.editDeviceNaming(c, &req)
+}
+
+func (h *Handler) DeleteDeviceNaming(c gateway.Context) error {
+	return h.editDeviceNaming(c, nil)
+}
+
+func (h *Handler) editDeviceNaming(c gateway.Context, naming *models.DeviceNaming) error {
+	var uid string
+	if c.ID() != nil {
+		uid = c.ID().ID
+	}
+
+	ns, err := h.service.GetNamespace(c.Ctx(), c.Param(ParamNamespaceTenant))
+	if err != nil || ns == nil {
+		return c.NoContent(http.StatusNotFound)
+	}
+
+	err = guard.EvaluateNamespace(ns, uid, guard.Actions.Namespace.EditDeviceNaming, func() error {
+		return h.service.EditDeviceNaming(c.Ctx(), ns.TenantID, naming)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) EditGeoAccess(c gateway.Context) error {
+	var req models.GeoAccess
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	return h.editGeoAccess(c, &req)
+}
+
+func (h *Handler) DeleteGeoAccess(c gateway.Context) error {
+	return h.editGeoAccess(c, nil)
+}
+
+func (h *Handler) editGeoAccess(c gateway.Context, geo *models.GeoAccess) error {
+	var uid string
+	if c.ID() != nil {
+		uid = c.ID().ID
+	}
+
+	ns, err := h.service.GetNamespace(c.Ctx(), c.Param(ParamNamespaceTenant))
+	if err != nil || ns == nil {
+		return c.NoContent(http.StatusNotFound)
+	}
+
+	err = guard.EvaluateNamespace(ns, uid, guard.Actions.Namespace.EditGeoAccess, func() error {
+		return h.service.EditGeoAccess(c.Ctx(), ns.TenantID, geo)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) EditSessionTypes(c gateway.Context) error {
+	var req models.SessionTypes
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	return h.editSessionTypes(c, &req)
+}
+
+func (h *Handler) DeleteSessionTypes(c gateway.Context) error {
+	return h.editSessionTypes(c, nil)
+}
+
+func (h *Handler) editSessionTypes(c gateway.Context, types *models.SessionTypes) error {
+	var uid string
+	if c.ID() != nil {
+		uid = c.ID().ID
+	}
+
+	ns, err := h.service.GetNamespace(c.Ctx(), c.Param(ParamNamespaceTenant))
+	if err != nil || ns == nil {
+		return c.NoContent(http.StatusNotFound)
+	}
+
+	err = guard.EvaluateNamespace(ns, uid, guard.Actions.Namespace.EditSessionTypes, func() error {
+		return h.service.EditSessionTypes(c.Ctx(), ns.TenantID, types)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) EvaluateSessionType(c gateway.Context) error {
+	var query struct {
+		Device string `query:"device"`
+		Type   string `query:"type"`
+	}
+
+	if err := c.Bind(&query); err != nil {
+		return err
+	}
+
+	if err := h.service.EvaluateSessionType(c.Ctx(), models.UID(query.Device), query.Type); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
 func (h *Handler) GetSessionRecord(c gateway.Context) error {
 	tenantID := ""
 	if v := c.Tenant(); v != nil {
@@ -0,0 +1,152 @@
+package routes
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	ExportPolicyURL = "/policy"
+	ApplyPolicyURL  = "/policy"
+)
+
+const (
+	// PolicyFormatJSON and PolicyFormatYAML are the values of the "format" query parameter of the policy export.
+	PolicyFormatJSON = "json"
+	PolicyFormatYAML = "yaml"
+)
+
+// MIMEApplicationYAML is the media type of a policy document in YAML.
+const MIMEApplicationYAML = "application/yaml"
+
+func (h *Handler) ExportPolicy(c gateway.Context) error {
+	tenant := ""
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	policy, err := h.service.ExportPolicy(c.Ctx(), tenant)
+	if err != nil {
+		return err
+	}
+
+	if c.QueryParam("format") != PolicyFormatYAML {
+		return c.JSON(http.StatusOK, policy)
+	}
+
+	data, err := encodePolicyYAML(policy)
+	if err != nil {
+		return err
+	}
+
+	return c.Blob(http.StatusOK, MIMEApplicationYAML, data)
+}
+
+func (h *Handler) ApplyPolicy(c gateway.Context) error {
+	body, err := ioutil.ReadAll(c.Request().Body)
+	if err != nil {
+		return err
+	}
+
+	policy := new(models.Policy)
+	if contentType := c.Request().Header.Get(echo.HeaderContentType); strings.Contains(contentType, "yaml") {
+		err = decodePolicyYAML(body, policy)
+	} else {
+		err = json.Unmarshal(body, policy)
+	}
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	tenant := ""
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	dryRun, _ := strconv.ParseBool(c.QueryParam("dry_run"))
+	if dryRun {
+		diff, err := h.service.ApplyPolicy(c.Ctx(), tenant, policy, true)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, diff)
+	}
+
+	var diff *models.PolicyDiff
+	err = guard.EvaluatePermission(c.Role(), guard.Actions.Namespace.ApplyPolicy, func() error {
+		var err error
+		diff, err = h.service.ApplyPolicy(c.Ctx(), tenant, policy, false)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, diff)
+}
+
+// encodePolicyYAML encodes a policy as YAML with the same field names and order of its JSON encoding.
+func encodePolicyYAML(policy *models.Policy) ([]byte, error) {
+	data, err := json.Marshal(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	// As a JSON document is also a YAML document, decoding it to a MapSlice keeps the order of its fields.
+	var document yaml.MapSlice
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+
+	return yaml.Marshal(document)
+}
+
+// decodePolicyYAML decodes a policy from YAML, using the field names of its JSON encoding.
+func decodePolicyYAML(data []byte, policy *models.Policy) error {
+	var document interface{}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(convertYAMLMaps(document))
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, policy)
+}
+
+// convertYAMLMaps converts the maps decoded from YAML, which have keys of any type, to maps with string keys, so they
+// can be encoded as JSON.
+func convertYAMLMaps(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			m[fmt.Sprint(key)] = convertYAMLMaps(item)
+		}
+
+		return m
+	case []interface{}:
+		for i := range v {
+			v[i] = convertYAMLMaps(v[i])
+		}
+
+		return v
+	default:
+		return v
+	}
+}
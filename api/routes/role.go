@@ -0,0 +1,117 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	GetRoleListURL = "/roles"
+	GetRoleURL     = "/roles/:id"
+	CreateRoleURL  = "/roles"
+	UpdateRoleURL  = "/roles/:id"
+	DeleteRoleURL  = "/roles/:id"
+)
+
+const (
+	ParamRoleID = "id"
+)
+
+func (h *Handler) GetRoleList(c gateway.Context) error {
+	query := paginator.NewQuery()
+	if err := c.Bind(query); err != nil {
+		return err
+	}
+
+	query.Normalize()
+
+	roles, count, err := h.service.ListRoles(c.Ctx(), *query)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, roles)
+}
+
+func (h *Handler) GetRole(c gateway.Context) error {
+	tenant := ""
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	role, err := h.service.GetRole(c.Ctx(), c.Param(ParamRoleID), tenant)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, role)
+}
+
+func (h *Handler) CreateRole(c gateway.Context) error {
+	var role models.Role
+	if err := c.Bind(&role); err != nil {
+		return err
+	}
+
+	tenant := ""
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Namespace.EditRoles, func() error {
+		return h.service.CreateRole(c.Ctx(), &role, tenant)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, role)
+}
+
+func (h *Handler) UpdateRole(c gateway.Context) error {
+	var req models.RoleUpdate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	tenant := ""
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var role *models.Role
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Namespace.EditRoles, func() error {
+		var err error
+		role, err = h.service.UpdateRole(c.Ctx(), c.Param(ParamRoleID), tenant, &req)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, role)
+}
+
+func (h *Handler) DeleteRole(c gateway.Context) error {
+	tenant := ""
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Namespace.EditRoles, func() error {
+		return h.service.DeleteRole(c.Ctx(), c.Param(ParamRoleID), tenant)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
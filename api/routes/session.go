@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
 	"github.com/shellhub-io/shellhub/pkg/api/paginator"
 	"github.com/shellhub-io/shellhub/pkg/models"
 )
@@ -18,6 +19,10 @@ const (
 	KeepAliveSessionURL        = "/sessions/:uid/keepalive"
 	RecordSessionURL           = "/sessions/:uid/record"
 	PlaySessionURL             = "/sessions/:uid/play"
+	GetSessionApprovalListURL  = "/sessions/approvals"
+	GetSessionApprovalURL      = "/sessions/:uid/approval"
+	CreateSessionApprovalURL   = "/sessions/:uid/approval"
+	DecideSessionApprovalURL   = "/sessions/:uid/approval"
 )
 
 const (
@@ -104,3 +109,90 @@ func (h *Handler) PlaySession(c gateway.Context) error {
 func (h *Handler) DeleteRecordedSession(c gateway.Context) error {
 	return c.NoContent(http.StatusOK)
 }
+
+func (h *Handler) GetSessionApprovalList(c gateway.Context) error {
+	query := paginator.NewQuery()
+	if err := c.Bind(query); err != nil {
+		return err
+	}
+
+	query.Normalize()
+
+	approvals, count, err := h.service.ListSessionApprovals(c.Ctx(), *query)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, approvals)
+}
+
+// GetSessionApproval gets a session approval of the namespace of the request, so it is refused without a namespace.
+func (h *Handler) GetSessionApproval(c gateway.Context) error {
+	if c.Tenant() == nil || c.Tenant().ID == "" {
+		return c.NoContent(http.StatusForbidden)
+	}
+
+	approval, err := h.service.GetSessionApproval(c.Ctx(), models.UID(c.Param(ParamSessionID)), c.Tenant().ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, approval)
+}
+
+// GetInternalSessionApproval gets a session approval of any namespace to the SSH server waiting for its decision.
+func (h *Handler) GetInternalSessionApproval(c gateway.Context) error {
+	approval, err := h.service.GetSessionApproval(c.Ctx(), models.UID(c.Param(ParamSessionID)), "")
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, approval)
+}
+
+func (h *Handler) CreateSessionApproval(c gateway.Context) error {
+	var approval models.SessionApproval
+	if err := c.Bind(&approval); err != nil {
+		return err
+	}
+
+	approval.UID = c.Param(ParamSessionID)
+
+	if err := h.service.CreateSessionApproval(c.Ctx(), &approval); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, approval)
+}
+
+func (h *Handler) DecideSessionApproval(c gateway.Context) error {
+	var req models.SessionApprovalDecision
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	tenant := ""
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	id := ""
+	if c.ID() != nil {
+		id = c.ID().ID
+	}
+
+	var approval *models.SessionApproval
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Session.Approve, func() error {
+		var err error
+		approval, err = h.service.DecideSessionApproval(c.Ctx(), models.UID(c.Param(ParamSessionID)), tenant, id, req.Approved)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, approval)
+}
@@ -0,0 +1,67 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	GetSSHLimitsURL     = "/ssh/limits"
+	CheckSSHAttemptURL  = "/ssh/attempts"
+	RecordSSHAttemptURL = "/ssh/attempts"
+)
+
+func (h *Handler) GetSSHLimits(c gateway.Context) error {
+	var query struct {
+		IPAddress string `query:"ip_address"`
+		Device    string `query:"device"`
+	}
+
+	if err := c.Bind(&query); err != nil {
+		return err
+	}
+
+	tenant := ""
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	limits, err := h.service.GetSSHLimits(c.Ctx(), tenant, query.IPAddress, query.Device)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, limits)
+}
+
+func (h *Handler) CheckSSHAttempt(c gateway.Context) error {
+	var query struct {
+		IPAddress string `query:"ip_address"`
+		Device    string `query:"device"`
+	}
+
+	if err := c.Bind(&query); err != nil {
+		return err
+	}
+
+	if err := h.service.CheckSSHAttempt(c.Ctx(), query.IPAddress, query.Device); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) RecordSSHAttempt(c gateway.Context) error {
+	attempt := new(models.SSHAttempt)
+	if err := c.Bind(attempt); err != nil {
+		return err
+	}
+
+	if err := h.service.RecordSSHAttempt(c.Ctx(), attempt); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,141 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	GetWebhookListURL         = "/webhooks"
+	GetWebhookURL             = "/webhooks/:id"
+	CreateWebhookURL          = "/webhooks"
+	UpdateWebhookURL          = "/webhooks/:id"
+	DeleteWebhookURL          = "/webhooks/:id"
+	GetWebhookDeliveryListURL = "/webhooks/:id/deliveries"
+)
+
+const (
+	ParamWebhookID = "id"
+)
+
+func (h *Handler) GetWebhookList(c gateway.Context) error {
+	query := paginator.NewQuery()
+	if err := c.Bind(query); err != nil {
+		return err
+	}
+
+	query.Normalize()
+
+	webhooks, count, err := h.service.ListWebhooks(c.Ctx(), *query)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, webhooks)
+}
+
+func (h *Handler) GetWebhook(c gateway.Context) error {
+	tenant := ""
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	webhook, err := h.service.GetWebhook(c.Ctx(), c.Param(ParamWebhookID), tenant)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, webhook)
+}
+
+func (h *Handler) CreateWebhook(c gateway.Context) error {
+	var webhook models.Webhook
+	if err := c.Bind(&webhook); err != nil {
+		return err
+	}
+
+	tenant := ""
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Webhook.Create, func() error {
+		return h.service.CreateWebhook(c.Ctx(), &webhook, tenant)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, webhook)
+}
+
+func (h *Handler) UpdateWebhook(c gateway.Context) error {
+	var req models.WebhookUpdate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	tenant := ""
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var webhook *models.Webhook
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Webhook.Edit, func() error {
+		var err error
+		webhook, err = h.service.UpdateWebhook(c.Ctx(), c.Param(ParamWebhookID), tenant, &req)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, webhook)
+}
+
+func (h *Handler) DeleteWebhook(c gateway.Context) error {
+	tenant := ""
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Webhook.Remove, func() error {
+		return h.service.DeleteWebhook(c.Ctx(), c.Param(ParamWebhookID), tenant)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) GetWebhookDeliveryList(c gateway.Context) error {
+	query := paginator.NewQuery()
+	if err := c.Bind(query); err != nil {
+		return err
+	}
+
+	query.Normalize()
+
+	tenant := ""
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	deliveries, count, err := h.service.ListWebhookDeliveries(c.Ctx(), c.Param(ParamWebhookID), tenant, *query)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, deliveries)
+}
@@ -2,12 +2,21 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io/ioutil"
+	"net"
 	"os"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	echoMiddleware "github.com/labstack/echo/v4/middleware"
 	storecache "github.com/shellhub-io/shellhub/api/cache"
+	"github.com/shellhub-io/shellhub/api/pkg/exporter"
 	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/pkg/mailer"
 	"github.com/shellhub-io/shellhub/api/routes"
 	"github.com/shellhub-io/shellhub/api/routes/handlers"
 	apiMiddleware "github.com/shellhub-io/shellhub/api/routes/middleware"
@@ -31,12 +40,6 @@ var serverCmd = &cobra.Command{
 			logrus.Fatal("Failed to retrieve environment config from context")
 		}
 
-		go func() {
-			if err := startWorker(cfg); err != nil {
-				logrus.Fatal(err)
-			}
-		}()
-
 		return startServer(cfg)
 	},
 }
@@ -54,6 +57,37 @@ type config struct {
 	GeoIP bool `envconfig:"geoip" default:"false"`
 	// Session record cleanup worker schedule
 	SessionRecordCleanupSchedule string `envconfig:"session_record_cleanup_schedule" default:"@daily"`
+	// Decommissioned device cleanup worker schedule
+	DeviceCleanupSchedule string `envconfig:"device_cleanup_schedule" default:"@daily"`
+	// Number of consecutive failed logins of a username before it is locked out (0 disables it)
+	LoginUsernameFailures int `envconfig:"login_username_failures" default:"5"`
+	// Number of consecutive failed logins from a source IP address before it is locked out (0 disables it)
+	LoginIPAddressFailures int `envconfig:"login_ip_address_failures" default:"20"`
+	// Duration of a login lockout. The failed logins are counted only when the store cache is enabled
+	LoginLockoutDuration time.Duration `envconfig:"login_lockout_duration" default:"15m"`
+	// SMTP server sending the invitations to join a namespace. When empty, the invitations are only logged
+	SMTPHost     string `envconfig:"smtp_host"`
+	SMTPPort     int    `envconfig:"smtp_port" default:"587"`
+	SMTPUsername string `envconfig:"smtp_username"`
+	SMTPPassword string `envconfig:"smtp_password"`
+	SMTPFrom     string `envconfig:"smtp_from"`
+	// Use TLS from the start of the SMTP connection instead of STARTTLS
+	SMTPTLS bool `envconfig:"smtp_tls" default:"false"`
+	// Page where the invitations are answered, receiving the invitation token on the "token" query parameter
+	InvitationURL string `envconfig:"invitation_url"`
+	// Audit log cleanup worker schedule
+	AuditCleanupSchedule string `envconfig:"audit_cleanup_schedule" default:"@daily"`
+	// Device connection history retention time in days
+	DeviceHistoryRetention int `envconfig:"device_history_retention" default:"30"`
+	// Syslog server, as host:port, receiving the exported activity as RFC 5424 messages over TCP. When empty, the
+	// activity is not sent to syslog
+	ExportSyslogAddress string `envconfig:"export_syslog_address"`
+	// Use TLS on the connection to the syslog server
+	ExportSyslogTLS bool `envconfig:"export_syslog_tls" default:"false"`
+	// PEM file with the certificate authorities trusted to verify the syslog server instead of the system ones
+	ExportSyslogCA string `envconfig:"export_syslog_ca"`
+	// File where the exported activity is appended as JSON lines. When empty, the activity is not written to a file
+	ExportFile string `envconfig:"export_file"`
 }
 
 func startServer(cfg *config) error {
@@ -93,6 +127,10 @@ func startServer(cfg *config) error {
 		logrus.WithError(err).Fatal("Failed to apply mongo migrations")
 	}
 
+	if err := mongo.ApplyDeviceHistoryRetention(client.Database(connStr.Database), cfg.DeviceHistoryRetention); err != nil {
+		logrus.WithError(err).Fatal("Failed to apply the device history retention")
+	}
+
 	var cache storecache.Cache
 	if cfg.StoreCache {
 		logrus.Info("Using redis as store cache backend")
@@ -102,7 +140,7 @@ func startServer(cfg *config) error {
 			logrus.WithError(err).Error("Failed to configure redis store cache")
 		}
 	} else {
-		logrus.Info("Store cache disabled")
+		logrus.Warn("Store cache disabled, so the SSH attempts are not rate limited and the logins are not locked out")
 		cache = storecache.NewNullCache()
 	}
 
@@ -123,9 +161,56 @@ func startServer(cfg *config) error {
 		locator = geoip.NewNullGeoLite()
 	}
 
-	service := services.NewService(store, nil, nil, cache, requestClient, locator)
+	queue, err := newWebhookQueue(cfg)
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to configure the webhook queue")
+	}
+
+	var sender mailer.Mailer
+	if cfg.SMTPHost != "" {
+		logrus.WithField("host", cfg.SMTPHost).Info("Sending the invitations through SMTP")
+		sender = mailer.NewSMTP(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPFrom, cfg.SMTPTLS)
+	} else {
+		logrus.Info("SMTP is disabled")
+		sender = mailer.NewNullMailer()
+	}
+
+	opts := []services.Option{
+		services.WithLoginLockout(services.LoginLockout{
+			UsernameFailures:  cfg.LoginUsernameFailures,
+			IPAddressFailures: cfg.LoginIPAddressFailures,
+			Duration:          cfg.LoginLockoutDuration,
+		}),
+		services.WithWebhookQueue(queue),
+		services.WithMailer(sender, cfg.InvitationURL),
+		services.WithAuditLog(),
+	}
+
+	activity, err := newExporter(cfg)
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to configure the activity exporter")
+	}
+
+	if activity != nil {
+		opts = append(opts, services.WithExporter(activity))
+	} else {
+		logrus.Info("Activity export is disabled")
+	}
+
+	service := services.NewService(store, nil, nil, cache, requestClient, locator, opts...)
 	handler := routes.NewHandler(service)
 
+	guard.SetRoleResolver(func(tenant, name string) (guard.Permissions, bool) {
+		return service.GetRolePermissions(context.Background(), tenant, name)
+	})
+
+	// The worker delivers the webhooks through the service, so it is started after it.
+	go func() {
+		if err := startWorker(cfg, service); err != nil {
+			logrus.Fatal(err)
+		}
+	}()
+
 	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			apicontext := gateway.NewContext(service, c)
@@ -147,8 +232,11 @@ func startServer(cfg *config) error {
 	publicAPI.POST(routes.AuthUserURLV2, gateway.Handler(handler.AuthUser))
 	publicAPI.GET(routes.AuthUserURLV2, gateway.Handler(handler.AuthUserInfo))
 	internalAPI.GET(routes.AuthUserTokenURL, gateway.Handler(handler.AuthGetToken))
+	internalAPI.GET(routes.GetLoginLockoutsURL, gateway.Handler(handler.GetLoginLockouts))
+	internalAPI.DELETE(routes.UnlockLoginURL, gateway.Handler(handler.UnlockLogin))
 	publicAPI.POST(routes.AuthPublicKeyURL, gateway.Handler(handler.AuthPublicKey))
 	publicAPI.GET(routes.AuthUserTokenURL, gateway.Handler(handler.AuthSwapToken))
+	publicAPI.GET(routes.AuthUserScopedTokenURL, gateway.Handler(handler.AuthUserToken))
 
 	publicAPI.PATCH(routes.UpdateUserDataURL, gateway.Handler(handler.UpdateUserData))
 	publicAPI.PATCH(routes.UpdateUserPasswordURL, gateway.Handler(handler.UpdateUserPassword))
@@ -159,8 +247,13 @@ func startServer(cfg *config) error {
 		apiMiddleware.Authorize(gateway.Handler(handler.GetDeviceList)))
 	publicAPI.GET(routes.GetDeviceURL,
 		apiMiddleware.Authorize(gateway.Handler(handler.GetDevice)))
+	publicAPI.GET(routes.GetDeviceHistoryURL,
+		apiMiddleware.Authorize(gateway.Handler(handler.GetDeviceHistory)))
+	publicAPI.GET(routes.GetDeviceAvailabilityURL,
+		apiMiddleware.Authorize(gateway.Handler(handler.GetDeviceAvailability)))
 	publicAPI.DELETE(routes.DeleteDeviceURL, gateway.Handler(handler.DeleteDevice))
 	publicAPI.PATCH(routes.RenameDeviceURL, gateway.Handler(handler.RenameDevice))
+	publicAPI.POST(routes.TransferDeviceURL, gateway.Handler(handler.TransferDevice))
 	internalAPI.POST(routes.OfflineDeviceURL, gateway.Handler(handler.OfflineDevice))
 	internalAPI.POST(routes.HeartbeatDeviceURL, gateway.Handler(handler.HeartbeatDevice))
 	internalAPI.GET(routes.LookupDeviceURL, gateway.Handler(handler.LookupDevice))
@@ -170,6 +263,17 @@ func startServer(cfg *config) error {
 	publicAPI.DELETE(routes.RemoveTagURL, gateway.Handler(handler.RemoveDeviceTag))
 	publicAPI.PUT(routes.UpdateTagURL, gateway.Handler(handler.UpdateDeviceTag))
 
+	publicAPI.GET(routes.GetDeviceGroupListURL,
+		apiMiddleware.Authorize(gateway.Handler(handler.GetDeviceGroupList)))
+	publicAPI.GET(routes.GetDeviceGroupURL,
+		apiMiddleware.Authorize(gateway.Handler(handler.GetDeviceGroup)))
+	publicAPI.POST(routes.CreateDeviceGroupURL, gateway.Handler(handler.CreateDeviceGroup))
+	publicAPI.PUT(routes.UpdateDeviceGroupURL, gateway.Handler(handler.UpdateDeviceGroup))
+	publicAPI.DELETE(routes.DeleteDeviceGroupURL, gateway.Handler(handler.DeleteDeviceGroup))
+	publicAPI.GET(routes.GetDeviceGroupMembersURL,
+		apiMiddleware.Authorize(gateway.Handler(handler.GetDeviceGroupMembers)))
+	publicAPI.POST(routes.ApplyDeviceGroupOperationURL, gateway.Handler(handler.ApplyDeviceGroupOperation))
+
 	publicAPI.GET(routes.GetTagsURL, gateway.Handler(handler.GetTags))
 	publicAPI.PUT(routes.RenameTagURL, gateway.Handler(handler.RenameTag))
 	publicAPI.DELETE(routes.DeleteTagsURL, gateway.Handler(handler.DeleteTag))
@@ -185,11 +289,19 @@ func startServer(cfg *config) error {
 	internalAPI.POST(routes.RecordSessionURL, gateway.Handler(handler.RecordSession))
 	publicAPI.GET(routes.PlaySessionURL, gateway.Handler(handler.PlaySession))
 	publicAPI.DELETE(routes.RecordSessionURL, gateway.Handler(handler.DeleteRecordedSession))
+	publicAPI.GET(routes.GetSessionApprovalListURL,
+		apiMiddleware.Authorize(gateway.Handler(handler.GetSessionApprovalList)))
+	publicAPI.GET(routes.GetSessionApprovalURL,
+		apiMiddleware.Authorize(gateway.Handler(handler.GetSessionApproval)))
+	publicAPI.POST(routes.DecideSessionApprovalURL, gateway.Handler(handler.DecideSessionApproval))
+	internalAPI.GET(routes.GetSessionApprovalURL, gateway.Handler(handler.GetInternalSessionApproval))
+	internalAPI.POST(routes.CreateSessionApprovalURL, gateway.Handler(handler.CreateSessionApproval))
 
 	publicAPI.GET(routes.GetStatsURL,
 		apiMiddleware.Authorize(gateway.Handler(handler.GetStats)))
 
-	publicAPI.GET(routes.GetPublicKeysURL, gateway.Handler(handler.GetPublicKeys))
+	publicAPI.GET(routes.GetPublicKeysURL,
+		apiMiddleware.Authorize(gateway.Handler(handler.GetPublicKeys)))
 	publicAPI.POST(routes.CreatePublicKeyURL, gateway.Handler(handler.CreatePublicKey))
 	publicAPI.PUT(routes.UpdatePublicKeyURL, gateway.Handler(handler.UpdatePublicKey))
 	publicAPI.DELETE(routes.DeletePublicKeyURL, gateway.Handler(handler.DeletePublicKey))
@@ -201,6 +313,53 @@ func startServer(cfg *config) error {
 	publicAPI.DELETE(routes.RemovePublicKeyTagURL, gateway.Handler(handler.RemovePublicKeyTag))
 	publicAPI.PUT(routes.UpdatePublicKeyTagsURL, gateway.Handler(handler.UpdatePublicKeyTags))
 
+	publicAPI.GET(routes.GetFirewallRuleListURL,
+		apiMiddleware.Authorize(gateway.Handler(handler.GetFirewallRuleList)))
+	publicAPI.GET(routes.GetFirewallRuleURL, gateway.Handler(handler.GetFirewallRule))
+	publicAPI.POST(routes.CreateFirewallRuleURL, gateway.Handler(handler.CreateFirewallRule))
+	publicAPI.PUT(routes.UpdateFirewallRuleURL, gateway.Handler(handler.UpdateFirewallRule))
+	publicAPI.DELETE(routes.DeleteFirewallRuleURL, gateway.Handler(handler.DeleteFirewallRule))
+	publicAPI.POST(routes.SimulateFirewallURL, gateway.Handler(handler.SimulateFirewall))
+
+	publicAPI.GET(routes.ExportPolicyURL, gateway.Handler(handler.ExportPolicy))
+	publicAPI.POST(routes.ApplyPolicyURL, gateway.Handler(handler.ApplyPolicy))
+	internalAPI.GET(routes.EvaluateFirewallURL, gateway.Handler(handler.EvaluateFirewall))
+	publicAPI.GET(routes.GetIPSetListURL,
+		apiMiddleware.Authorize(gateway.Handler(handler.GetIPSetList)))
+	publicAPI.GET(routes.GetIPSetURL, gateway.Handler(handler.GetIPSet))
+	publicAPI.POST(routes.CreateIPSetURL, gateway.Handler(handler.CreateIPSet))
+	publicAPI.PUT(routes.UpdateIPSetURL, gateway.Handler(handler.UpdateIPSet))
+	publicAPI.DELETE(routes.DeleteIPSetURL, gateway.Handler(handler.DeleteIPSet))
+
+	publicAPI.GET(routes.GetRoleListURL,
+		apiMiddleware.Authorize(gateway.Handler(handler.GetRoleList)))
+	publicAPI.GET(routes.GetRoleURL, gateway.Handler(handler.GetRole))
+	publicAPI.POST(routes.CreateRoleURL, gateway.Handler(handler.CreateRole))
+	publicAPI.PUT(routes.UpdateRoleURL, gateway.Handler(handler.UpdateRole))
+	publicAPI.DELETE(routes.DeleteRoleURL, gateway.Handler(handler.DeleteRole))
+
+	publicAPI.GET(routes.GetWebhookListURL,
+		apiMiddleware.Authorize(gateway.Handler(handler.GetWebhookList)))
+	publicAPI.GET(routes.GetWebhookURL, gateway.Handler(handler.GetWebhook))
+	publicAPI.POST(routes.CreateWebhookURL, gateway.Handler(handler.CreateWebhook))
+	publicAPI.PUT(routes.UpdateWebhookURL, gateway.Handler(handler.UpdateWebhook))
+	publicAPI.DELETE(routes.DeleteWebhookURL, gateway.Handler(handler.DeleteWebhook))
+	publicAPI.GET(routes.GetWebhookDeliveryListURL, gateway.Handler(handler.GetWebhookDeliveryList))
+
+	publicAPI.GET(routes.GetInvitationListURL,
+		apiMiddleware.Authorize(gateway.Handler(handler.GetInvitationList)))
+	publicAPI.POST(routes.CreateInvitationURL, gateway.Handler(handler.CreateInvitation))
+	publicAPI.DELETE(routes.DeleteInvitationURL, gateway.Handler(handler.DeleteInvitation))
+	publicAPI.POST(routes.AcceptInvitationURL, gateway.Handler(handler.AcceptInvitation))
+	publicAPI.POST(routes.DeclineInvitationURL, gateway.Handler(handler.DeclineInvitation))
+
+	publicAPI.GET(routes.GetAuditLogListURL,
+		apiMiddleware.Authorize(gateway.Handler(handler.GetAuditLogList)))
+
+	publicAPI.GET(routes.GetSSHLimitsURL, gateway.Handler(handler.GetSSHLimits))
+	internalAPI.GET(routes.CheckSSHAttemptURL, gateway.Handler(handler.CheckSSHAttempt))
+	internalAPI.POST(routes.RecordSSHAttemptURL, gateway.Handler(handler.RecordSSHAttempt))
+
 	publicAPI.GET(routes.ListNamespaceURL, gateway.Handler(handler.GetNamespaceList))
 	publicAPI.GET(routes.GetNamespaceURL, gateway.Handler(handler.GetNamespace))
 	publicAPI.POST(routes.CreateNamespaceURL, gateway.Handler(handler.CreateNamespace))
@@ -209,8 +368,67 @@ func startServer(cfg *config) error {
 	publicAPI.POST(routes.AddNamespaceUserURL, gateway.Handler(handler.AddNamespaceUser))
 	publicAPI.DELETE(routes.RemoveNamespaceUserURL, gateway.Handler(handler.RemoveNamespaceUser))
 	publicAPI.PATCH(routes.EditNamespaceUserURL, gateway.Handler(handler.EditNamespaceUser))
+	publicAPI.PUT(routes.EditMemberScopeURL, gateway.Handler(handler.EditMemberScope))
+	publicAPI.DELETE(routes.DeleteMemberScopeURL, gateway.Handler(handler.DeleteMemberScope))
+	publicAPI.POST(routes.TransferNamespaceURL, gateway.Handler(handler.TransferNamespace))
+	publicAPI.PUT(routes.EditDeviceNamingURL, gateway.Handler(handler.EditDeviceNaming))
+	publicAPI.DELETE(routes.DeleteDeviceNamingURL, gateway.Handler(handler.DeleteDeviceNaming))
+	publicAPI.PUT(routes.EditGeoAccessURL, gateway.Handler(handler.EditGeoAccess))
+	publicAPI.DELETE(routes.DeleteGeoAccessURL, gateway.Handler(handler.DeleteGeoAccess))
+	publicAPI.PUT(routes.EditSessionTypesURL, gateway.Handler(handler.EditSessionTypes))
+	publicAPI.DELETE(routes.DeleteSessionTypesURL, gateway.Handler(handler.DeleteSessionTypes))
+	internalAPI.GET(routes.EvaluateSessionTypeURL, gateway.Handler(handler.EvaluateSessionType))
 
 	e.Logger.Fatal(e.Start(":8080"))
 
 	return nil
 }
+
+// newExporter creates the exporter of the activity to the syslog server and to the file configured, sending the events
+// in background. It returns nil when none is configured.
+func newExporter(cfg *config) (exporter.Exporter, error) {
+	var exporters []exporter.Exporter
+
+	if cfg.ExportSyslogAddress != "" {
+		var tlsConfig *tls.Config
+		if cfg.ExportSyslogTLS {
+			host, _, err := net.SplitHostPort(cfg.ExportSyslogAddress)
+			if err != nil {
+				return nil, err
+			}
+
+			tlsConfig = &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}
+
+			if cfg.ExportSyslogCA != "" {
+				data, err := ioutil.ReadFile(cfg.ExportSyslogCA)
+				if err != nil {
+					return nil, err
+				}
+
+				tlsConfig.RootCAs = x509.NewCertPool()
+				if !tlsConfig.RootCAs.AppendCertsFromPEM(data) {
+					return nil, errors.New("no certificate found on the syslog CA file")
+				}
+			}
+		}
+
+		logrus.WithField("address", cfg.ExportSyslogAddress).Info("Exporting the activity to syslog")
+		exporters = append(exporters, exporter.NewSyslog(cfg.ExportSyslogAddress, tlsConfig))
+	}
+
+	if cfg.ExportFile != "" {
+		file, err := exporter.NewFile(cfg.ExportFile)
+		if err != nil {
+			return nil, err
+		}
+
+		logrus.WithField("file", cfg.ExportFile).Info("Exporting the activity to file")
+		exporters = append(exporters, file)
+	}
+
+	if len(exporters) == 0 {
+		return nil, nil
+	}
+
+	return exporter.NewQueue(exporter.NewMulti(exporters...), 1024, 10*time.Second), nil
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/shellhub-io/shellhub/api/pkg/exporter"
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/sirupsen/logrus"
+)
+
+// WithAuditLog enables the audit log, recording the mutating actions over the namespaces' resources.
+func WithAuditLog() Option {
+	return func(s *service) {
+		s.auditLog = true
+	}
+}
+
+type AuditService interface {
+	ListAuditLogs(ctx context.Context, pagination paginator.Query, filter *models.AuditLogFilter) ([]models.AuditLog, int, error)
+}
+
+func (s *service) ListAuditLogs(ctx context.Context, pagination paginator.Query, filter *models.AuditLogFilter) ([]models.AuditLog, int, error) {
+	return s.store.AuditList(ctx, pagination, filter)
+}
+
+// auditing checks if the actions are recorded on the audit log or exported.
+func (s *service) auditing() bool {
+	return s.auditLog || s.exporter != nil
+}
+
+// audit records an action over a resource of a namespace on the audit log, with the resource before and after it, and
+// exports it. The actor is the user of the request, got from the gateway.
+//
+// A failure to record the action is only logged, as the action was already done.
+func (s *service) audit(ctx context.Context, tenant, action, target string, before, after interface{}) {
+	if !s.auditing() {
+		return
+	}
+
+	log := &models.AuditLog{
+		TenantID:  tenant,
+		Action:    action,
+		Target:    target,
+		Before:    auditState(before),
+		After:     auditState(after),
+		CreatedAt: clock.Now(),
+	}
+
+	if id := gateway.IDFromContext(ctx); id != nil {
+		log.Actor = id.ID
+	}
+
+	if s.auditLog {
+		if err := s.store.AuditCreate(ctx, log); err != nil {
+			logrus.WithError(err).WithFields(logrus.Fields{
+				"tenant_id": tenant,
+				"action":    action,
+				"target":    target,
+			}).Error("failed to record the audit log")
+		}
+	}
+
+	s.export(ctx, exporter.EventAudit, tenant, log)
+}
+
+// auditGet gets a resource as it is before an action, to be recorded on the audit log. The resource is only got when
+// the actions are recorded or exported, being nil when it cannot be got.
+func (s *service) auditGet(get func() (interface{}, error)) interface{} {
+	if !s.auditing() {
+		return nil
+	}
+
+	resource, err := get()
+	if err != nil {
+		return nil
+	}
+
+	return resource
+}
+
+// auditSettings gets the settings of a namespace to be recorded on the audit log, before or after they are changed.
+func (s *service) auditSettings(ctx context.Context, tenant string) interface{} {
+	return s.auditGet(func() (interface{}, error) {
+		namespace, err := s.store.NamespaceGet(ctx, tenant)
+		if err != nil {
+			return nil, err
+		}
+
+		return namespace.Settings, nil
+	})
+}
+
+// auditState converts a resource to how the API returns it, so the audit log never keeps the fields the API hides, as
+// the secrets. A nil resource is converted to nil.
+func auditState(resource interface{}) map[string]interface{} {
+	if resource == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return nil
+	}
+
+	var state map[string]interface{}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil
+	}
+
+	return state
+}
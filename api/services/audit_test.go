@@ -0,0 +1,114 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	storecache "github.com/shellhub-io/shellhub/api/cache"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	mocklib "github.com/stretchr/testify/mock"
+)
+
+func TestListAuditLogs(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	query := paginator.Query{Page: 1, PerPage: 10}
+	filter := &models.AuditLogFilter{Action: models.AuditDeviceRemove}
+	logs := []models.AuditLog{{ID: "id", TenantID: "tenant", Action: models.AuditDeviceRemove, Target: "uid"}}
+
+	mock.On("AuditList", ctx, query, filter).Return(logs, len(logs), nil).Once()
+
+	returned, count, err := s.ListAuditLogs(ctx, query, filter)
+	assert.NoError(t, err)
+	assert.Equal(t, logs, returned)
+	assert.Equal(t, len(logs), count)
+
+	mock.AssertExpectations(t)
+}
+
+func TestAudit(t *testing.T) {
+	ctx := context.TODO()
+
+	rule := &models.FirewallRule{
+		ID:       "id",
+		TenantID: "tenant",
+		FirewallRuleFields: models.FirewallRuleFields{
+			Priority: 1,
+			Action:   "allow",
+			Active:   true,
+			SourceIP: "*",
+			Username: ".*",
+		},
+	}
+
+	Err := errors.New("error", "", 0)
+
+	cases := []struct {
+		description   string
+		enabled       bool
+		requiredMocks func(mock *mocks.Store)
+	}{
+		{
+			description: "does not record the action when the audit log is disabled",
+			enabled:     false,
+			requiredMocks: func(mock *mocks.Store) {
+				mock.On("FirewallRuleGet", ctx, "id").Return(rule, nil).Once()
+				mock.On("FirewallRuleDelete", ctx, "id").Return(nil).Once()
+			},
+		},
+		{
+			description: "records the action with the resource before it",
+			enabled:     true,
+			requiredMocks: func(mock *mocks.Store) {
+				mock.On("FirewallRuleGet", ctx, "id").Return(rule, nil).Once()
+				mock.On("FirewallRuleDelete", ctx, "id").Return(nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("AuditCreate", ctx, mocklib.MatchedBy(func(log *models.AuditLog) bool {
+					return log.TenantID == "tenant" &&
+						log.Action == models.AuditFirewallRemove &&
+						log.Target == "id" &&
+						log.Before["id"] == "id" &&
+						log.After == nil &&
+						log.CreatedAt.Equal(now)
+				})).Return(nil).Once()
+			},
+		},
+		{
+			description: "succeeds when the action is not recorded",
+			enabled:     true,
+			requiredMocks: func(mock *mocks.Store) {
+				mock.On("FirewallRuleGet", ctx, "id").Return(rule, nil).Once()
+				mock.On("FirewallRuleDelete", ctx, "id").Return(nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("AuditCreate", ctx, mocklib.AnythingOfType("*models.AuditLog")).Return(Err).Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			mock := &mocks.Store{}
+
+			var opts []Option
+			if tc.enabled {
+				opts = append(opts, WithAuditLog())
+			}
+
+			s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil, opts...)
+
+			tc.requiredMocks(mock)
+
+			assert.NoError(t, s.DeleteFirewallRule(ctx, "id", "tenant"))
+
+			mock.AssertExpectations(t)
+		})
+	}
+}
@@ -15,18 +15,24 @@ import (
 
 	"github.com/cnf/structhash"
 	jwt "github.com/golang-jwt/jwt/v4"
+	"github.com/shellhub-io/shellhub/api/pkg/exporter"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/pkg/clock"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/shellhub-io/shellhub/pkg/validator"
+	"github.com/sirupsen/logrus"
 )
 
 type AuthService interface {
 	AuthDevice(ctx context.Context, req *models.DeviceAuthRequest, remoteAddr string) (*models.DeviceAuthResponse, error)
-	AuthUser(ctx context.Context, req models.UserAuthRequest) (*models.UserAuthResponse, error)
+	AuthUser(ctx context.Context, req models.UserAuthRequest, remoteAddr string) (*models.UserAuthResponse, error)
 	AuthGetToken(ctx context.Context, tenant string) (*models.UserAuthResponse, error)
 	AuthPublicKey(ctx context.Context, req *models.PublicKeyAuthRequest) (*models.PublicKeyAuthResponse, error)
 	AuthSwapToken(ctx context.Context, ID, tenant string) (*models.UserAuthResponse, error)
 	AuthUserInfo(ctx context.Context, username, tenant, token string) (*models.UserAuthResponse, error)
+	AuthUserToken(ctx context.Context, id string) (*models.UserAuthResponse, error)
+	AuthTenant(ctx context.Context, id, tenant string) (string, error)
 	PublicKey() *rsa.PublicKey
 }
 
@@ -35,9 +41,9 @@ func (s *service) AuthDevice(ctx context.Context, req *models.DeviceAuthRequest,
 		return nil, err
 	}
 
-	uid := sha256.Sum256(structhash.Dump(req.DeviceAuth, 1))
-
-	key := hex.EncodeToString(uid[:])
+	// A transferred device keeps authenticating with the tenant it had before the transfer until it learns the new one
+	// from the response, so its request is redirected to the namespace where the device is now.
+	key := s.resolveDeviceTransfer(ctx, req.DeviceAuth)
 
 	token := jwt.NewWithClaims(jwt.SigningMethodRS256, models.DeviceAuthClaims{
 		UID: key,
@@ -54,6 +60,7 @@ func (s *service) AuthDevice(ctx context.Context, req *models.DeviceAuthRequest,
 	type Device struct {
 		Name      string
 		Namespace string
+		TenantID  string
 	}
 
 	var value *Device
@@ -64,6 +71,7 @@ func (s *service) AuthDevice(ctx context.Context, req *models.DeviceAuthRequest,
 			Token:     tokenStr,
 			Name:      value.Name,
 			Namespace: value.Namespace,
+			TenantID:  value.TenantID,
 		}, nil
 	}
 	device := models.Device{
@@ -88,6 +96,19 @@ func (s *service) AuthDevice(ctx context.Context, req *models.DeviceAuthRequest,
 
 	hostname := strings.ToLower(req.DeviceAuth.Hostname)
 
+	// The namespace's naming template is applied only when the device registers, keeping the current device's name.
+	if namespace.Settings != nil && namespace.Settings.DeviceNaming != nil {
+		_, err := s.store.DeviceGetByUID(ctx, models.UID(device.UID), device.TenantID)
+		switch {
+		case err == store.ErrNoDocuments:
+			if hostname, err = s.generateDeviceName(ctx, namespace.Settings.DeviceNaming, hostname, &device); err != nil {
+				return nil, err
+			}
+		case err != nil:
+			return nil, NewErrDeviceNotFound(models.UID(device.UID), err)
+		}
+	}
+
 	if err := s.store.DeviceCreate(ctx, device, hostname); err != nil {
 		return nil, NewErrDeviceCreate(device, err)
 	}
@@ -106,7 +127,13 @@ func (s *service) AuthDevice(ctx context.Context, req *models.DeviceAuthRequest,
 	if err != nil {
 		return nil, NewErrDeviceNotFound(models.UID(device.UID), err)
 	}
-	if err := s.cache.Set(ctx, strings.Join([]string{"auth_device", key}, "/"), &Device{Name: dev.Name, Namespace: namespace.Name}, time.Second*30); err != nil {
+
+	// The device's creation date is set by the store only when it is inserted, so it is not before the authentication
+	// when the device was registered by it. The comparison is done in milliseconds, the precision kept by the store.
+	if !dev.CreatedAt.Before(device.LastSeen.Truncate(time.Millisecond)) {
+		s.dispatchWebhookEvent(ctx, dev.TenantID, models.WebhookEventDeviceRegistered, dev)
+	}
+	if err := s.cache.Set(ctx, strings.Join([]string{"auth_device", key}, "/"), &Device{Name: dev.Name, Namespace: namespace.Name, TenantID: namespace.TenantID}, time.Second*30); err != nil {
 		return nil, err
 	}
 
@@ -115,18 +142,70 @@ func (s *service) AuthDevice(ctx context.Context, req *models.DeviceAuthRequest,
 		Token:     tokenStr,
 		Name:      dev.Name,
 		Namespace: namespace.Name,
+		TenantID:  namespace.TenantID,
 	}, nil
 }
 
-func (s *service) AuthUser(ctx context.Context, req models.UserAuthRequest) (*models.UserAuthResponse, error) {
+// deviceUID returns the device's UID derived from the data sent by the agent on authentication.
+func deviceUID(auth *models.DeviceAuth) string {
+	uid := sha256.Sum256(structhash.Dump(auth, 1))
+
+	return hex.EncodeToString(uid[:])
+}
+
+// DeviceMaxTransfers is the max number of transfers followed when a device authenticates.
+const DeviceMaxTransfers = 10
+
+// resolveDeviceTransfer follows the transfers of a device, updating auth's tenant to the namespace where the device
+// is now, and returns the device's UID on it.
+func (s *service) resolveDeviceTransfer(ctx context.Context, auth *models.DeviceAuth) string {
+	key := deviceUID(auth)
+
+	for i := 0; i < DeviceMaxTransfers; i++ {
+		transfer, err := s.store.DeviceTransferGet(ctx, models.UID(key))
+		if err != nil {
+			if err != store.ErrNoDocuments {
+				logrus.WithError(err).WithField("uid", key).Error("failed to get the device transfer")
+			}
+
+			break
+		}
+
+		auth.TenantID = transfer.TenantID
+		key = deviceUID(auth)
+	}
+
+	return key
+}
+
+// AuthUser authenticates a user with its username, or email, and password.
+//
+// The consecutive failed logins are counted by username and by source IP address. When they reach the limits of the
+// service's LoginLockout, the logins are refused with NewErrAuthLocked until the lockout ends.
+func (s *service) AuthUser(ctx context.Context, req models.UserAuthRequest, remoteAddr string) (*models.UserAuthResponse, error) {
+	if err := s.checkLoginLockout(ctx, "", remoteAddr); err != nil {
+		return nil, err
+	}
+
 	user, err := s.store.UserGetByUsername(ctx, strings.ToLower(req.Username))
 	if err != nil {
 		user, err = s.store.UserGetByEmail(ctx, strings.ToLower(req.Username))
 		if err != nil {
+			s.recordLoginFailure(ctx, "", remoteAddr)
+			s.export(ctx, exporter.EventAuthFailed, "", &exporter.AuthFailure{
+				Method:    exporter.AuthMethodLogin,
+				Username:  req.Username,
+				IPAddress: remoteAddr,
+			})
+
 			return nil, NewErrUserNotFound(req.Username, err)
 		}
 	}
 
+	if err := s.checkLoginLockout(ctx, user.Username, ""); err != nil {
+		return nil, err
+	}
+
 	if !user.Confirmed {
 		return nil, NewErrUserNotConfirmed(nil)
 	}
@@ -174,6 +253,8 @@ func (s *service) AuthUser(ctx context.Context, req models.UserAuthRequest) (*mo
 			return nil, NewErrUserUpdate(user, err)
 		}
 
+		s.clearLoginFailures(ctx, user.Username)
+
 		return &models.UserAuthResponse{
 			Token:  tokenStr,
 			Name:   user.Name,
@@ -185,6 +266,13 @@ func (s *service) AuthUser(ctx context.Context, req models.UserAuthRequest) (*mo
 		}, nil
 	}
 
+	s.recordLoginFailure(ctx, user.Username, remoteAddr)
+	s.export(ctx, exporter.EventAuthFailed, "", &exporter.AuthFailure{
+		Method:    exporter.AuthMethodLogin,
+		Username:  user.Username,
+		IPAddress: remoteAddr,
+	})
+
 	return nil, NewErrAuthUnathorized(nil)
 }
 
@@ -323,6 +411,76 @@ func (s *service) AuthSwapToken(ctx context.Context, id, tenant string) (*models
 	return nil, nil
 }
 
+// AuthUserToken creates a token of the user not bound to a namespace, listing the namespaces of which the user is a
+// member. The namespace of each request made with it is set on the X-Tenant-ID header and checked by AuthTenant.
+func (s *service) AuthUserToken(ctx context.Context, id string) (*models.UserAuthResponse, error) {
+	user, _, err := s.store.UserGetByID(ctx, id, false)
+	if err != nil {
+		return nil, NewErrUserNotFound(id, err)
+	}
+
+	namespaces, err := s.store.NamespaceListByMember(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	memberships := make([]models.Membership, 0, len(namespaces))
+	for i := range namespaces {
+		if member, ok := guard.CheckMember(&namespaces[i], user.ID); ok {
+			memberships = append(memberships, models.Membership{
+				TenantID: namespaces[i].TenantID,
+				Name:     namespaces[i].Name,
+				Role:     member.Role,
+			})
+		}
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, models.UserAuthClaims{
+		Username: user.Username,
+		Admin:    true,
+		ID:       user.ID,
+		Scoped:   true,
+		AuthClaims: models.AuthClaims{
+			Claims: "user",
+		},
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(clock.Now().Add(time.Hour * 72)),
+		},
+	})
+
+	tokenStr, err := token.SignedString(s.privKey)
+	if err != nil {
+		return nil, NewErrTokenSigned(err)
+	}
+
+	return &models.UserAuthResponse{
+		Token:      tokenStr,
+		Name:       user.Name,
+		ID:         user.ID,
+		User:       user.Username,
+		Email:      user.Email,
+		Namespaces: memberships,
+	}, nil
+}
+
+// AuthTenant checks if the user is a member of a namespace set explicitly on a request, returning the user's current
+// role on it.
+//
+// It returns NewErrNamespaceNotMember when the user is not a member or the namespace is not found.
+func (s *service) AuthTenant(ctx context.Context, id, tenant string) (string, error) {
+	namespace, err := s.store.NamespaceGet(ctx, tenant)
+	if err != nil {
+		return "", NewErrNamespaceNotMember(tenant, err)
+	}
+
+	member, ok := guard.CheckMember(namespace, id)
+	if !ok {
+		return "", NewErrNamespaceNotMember(tenant, nil)
+	}
+
+	return member.Role, nil
+}
+
 func (s *service) AuthUserInfo(ctx context.Context, username, tenant, token string) (*models.UserAuthResponse, error) {
 	user, err := s.store.UserGetByUsername(ctx, username)
 	if err != nil || user == nil {
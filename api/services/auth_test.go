@@ -10,7 +10,9 @@ import (
 	"time"
 
 	"github.com/cnf/structhash"
+	jwt "github.com/golang-jwt/jwt/v4"
 	storecache "github.com/shellhub-io/shellhub/api/cache"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
 	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/api/store/mocks"
 	"github.com/shellhub-io/shellhub/pkg/errors"
@@ -51,6 +53,8 @@ func TestAuthDevice(t *testing.T) {
 	clockMock.On("Now").Return(now).Twice()
 	namespace := &models.Namespace{Name: "group1", Owner: "hash1", TenantID: "tenant"}
 
+	mock.On("DeviceTransferGet", ctx, models.UID(device.UID)).
+		Return(nil, store.ErrNoDocuments).Once()
 	mock.On("DeviceCreate", ctx, *device, "").
 		Return(nil).Once()
 	mock.On("DeviceSetOnline", ctx, models.UID(device.UID), true).
@@ -73,12 +77,74 @@ func TestAuthDevice(t *testing.T) {
 	assert.Equal(t, device.UID, authRes.UID)
 	assert.Equal(t, device.Name, authRes.Name)
 	assert.Equal(t, namespace.Name, authRes.Namespace)
+	assert.Equal(t, namespace.TenantID, authRes.TenantID)
 	assert.NotEmpty(t, authRes.Token)
 	assert.Equal(t, device.RemoteAddr, "0.0.0.0")
 
 	mock.AssertExpectations(t)
 }
 
+func TestAuthDeviceTransferred(t *testing.T) {
+	mock := &mocks.Store{}
+
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	authReq := &models.DeviceAuthRequest{
+		DeviceAuth: &models.DeviceAuth{
+			TenantID: "tenant",
+			Identity: &models.DeviceIdentity{
+				MAC: "mac",
+			},
+		},
+	}
+
+	oldUID := deviceUID(authReq.DeviceAuth)
+	newUID := deviceUID(&models.DeviceAuth{TenantID: "tenant2", Identity: authReq.Identity})
+
+	device := &models.Device{
+		UID:        newUID,
+		Name:       "name",
+		Identity:   authReq.Identity,
+		TenantID:   "tenant2",
+		LastSeen:   now,
+		RemoteAddr: "0.0.0.0",
+	}
+
+	namespace := &models.Namespace{Name: "group2", Owner: "hash1", TenantID: "tenant2"}
+
+	clockMock.On("Now").Return(now).Once()
+
+	mock.On("DeviceTransferGet", ctx, models.UID(oldUID)).
+		Return(&models.DeviceTransfer{UID: oldUID, NewUID: newUID, From: "tenant", TenantID: "tenant2"}, nil).Once()
+	mock.On("DeviceTransferGet", ctx, models.UID(newUID)).
+		Return(nil, store.ErrNoDocuments).Once()
+	mock.On("NamespaceGet", ctx, namespace.TenantID).
+		Return(namespace, nil).Once()
+	mock.On("DeviceCreate", ctx, models.Device{
+		UID:        newUID,
+		Identity:   authReq.Identity,
+		TenantID:   "tenant2",
+		LastSeen:   now,
+		RemoteAddr: "0.0.0.0",
+	}, "").Return(nil).Once()
+	mock.On("DeviceSetOnline", ctx, models.UID(newUID), true).
+		Return(nil).Once()
+	mock.On("DeviceGetByUID", ctx, models.UID(newUID), "tenant2").
+		Return(device, nil).Once()
+
+	authRes, err := s.AuthDevice(ctx, authReq, "0.0.0.0")
+	assert.NoError(t, err)
+
+	assert.Equal(t, newUID, authRes.UID)
+	assert.Equal(t, device.Name, authRes.Name)
+	assert.Equal(t, namespace.Name, authRes.Namespace)
+	assert.Equal(t, "tenant2", authRes.TenantID)
+
+	mock.AssertExpectations(t)
+}
+
 func TestAuthUser(t *testing.T) {
 	mock := &mocks.Store{}
 
@@ -138,9 +204,9 @@ func TestAuthUser(t *testing.T) {
 	mock.On("UserGetByUsername", ctx, authReq.Username).Return(userConfirmed, nil).Once()
 	mock.On("NamespaceGetFirst", ctx, userConfirmed.ID).Return(namespace, nil).Once()
 	mock.On("UserUpdateData", ctx, userConfirmed.ID, *userConfirmed).Return(nil).Once()
-	clockMock.On("Now").Return(now).Twice()
+	clockMock.On("Now").Return(now).Times(4)
 
-	authRes, err := s.AuthUser(ctx, *authReq)
+	authRes, err := s.AuthUser(ctx, *authReq, "127.0.0.1")
 	assert.NoError(t, err)
 
 	Err := errors.New("error", "", 0)
@@ -162,6 +228,7 @@ func TestAuthUser(t *testing.T) {
 			requiredMocks: func() {
 				mock.On("UserGetByUsername", ctx, authReq.Username).Return(nil, Err).Once()
 				mock.On("UserGetByEmail", ctx, authReq.Username).Return(nil, Err).Once()
+				clockMock.On("Now").Return(now).Twice()
 			},
 			expected: Expected{nil, NewErrUserNotFound(authReq.Username, Err)},
 		},
@@ -171,6 +238,7 @@ func TestAuthUser(t *testing.T) {
 			requiredMocks: func() {
 				mock.On("UserGetByUsername", ctx, authReq.Username).Return(userWithWrongPassword, nil).Once()
 				mock.On("NamespaceGetFirst", ctx, userWithWrongPassword.ID).Return(namespace, nil).Once()
+				clockMock.On("Now").Return(now).Times(3)
 			},
 			expected: Expected{nil, NewErrAuthUnathorized(nil)},
 		},
@@ -179,6 +247,7 @@ func TestAuthUser(t *testing.T) {
 			args:        *authReq,
 			requiredMocks: func() {
 				mock.On("UserGetByUsername", ctx, authReq.Username).Return(userNotActivatedAccount, nil).Once()
+				clockMock.On("Now").Return(now).Twice()
 			},
 			expected: Expected{nil, NewErrUserNotConfirmed(nil)},
 		},
@@ -189,7 +258,7 @@ func TestAuthUser(t *testing.T) {
 				mock.On("UserGetByUsername", ctx, authReq.Username).Return(userConfirmed, nil).Once()
 				mock.On("NamespaceGetFirst", ctx, userConfirmed.ID).Return(namespace, nil).Once()
 				mock.On("UserUpdateData", ctx, userConfirmed.ID, *userConfirmed).Return(nil).Once()
-				clockMock.On("Now").Return(now).Twice()
+				clockMock.On("Now").Return(now).Times(4)
 			},
 			expected: Expected{authRes, nil},
 		},
@@ -199,7 +268,7 @@ func TestAuthUser(t *testing.T) {
 		t.Run(tc.description, func(t *testing.T) {
 			tc.requiredMocks()
 
-			authRes, err := s.AuthUser(ctx, tc.args)
+			authRes, err := s.AuthUser(ctx, tc.args, "127.0.0.1")
 			assert.Equal(t, tc.expected, Expected{authRes, err})
 		})
 	}
@@ -293,3 +362,117 @@ func TestAuthUserInfo(t *testing.T) {
 
 	mock.AssertExpectations(t)
 }
+
+func TestAuthUserToken(t *testing.T) {
+	mock := &mocks.Store{}
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	s := NewService(store.Store(mock), privateKey, &privateKey.PublicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	user := &models.User{ID: "id", UserData: models.UserData{Username: "user", Name: "user", Email: "email@email.com"}}
+
+	namespaces := []models.Namespace{
+		{Name: "alpha", TenantID: "tenant-1", Members: []models.Member{{ID: "owner", Role: guard.RoleOwner}, {ID: "id", Role: guard.RoleObserver}}},
+		{Name: "beta", TenantID: "tenant-2", Members: []models.Member{{ID: "id", Role: guard.RoleOwner}}},
+	}
+
+	Err := errors.New("error", "", 0)
+
+	t.Run("fails when the user is not found", func(t *testing.T) {
+		mock.On("UserGetByID", ctx, "id", false).Return(nil, 0, Err).Once()
+
+		_, err := s.AuthUserToken(ctx, "id")
+		assert.Equal(t, NewErrUserNotFound("id", Err), err)
+	})
+
+	t.Run("succeeds listing the memberships", func(t *testing.T) {
+		mock.On("UserGetByID", ctx, "id", false).Return(user, 0, nil).Once()
+		mock.On("NamespaceListByMember", ctx, "id").Return(namespaces, nil).Once()
+		clockMock.On("Now").Return(now).Once()
+
+		res, err := s.AuthUserToken(ctx, "id")
+		assert.NoError(t, err)
+		assert.Equal(t, "", res.Tenant)
+		assert.Equal(t, []models.Membership{
+			{TenantID: "tenant-1", Name: "alpha", Role: guard.RoleObserver},
+			{TenantID: "tenant-2", Name: "beta", Role: guard.RoleOwner},
+		}, res.Namespaces)
+
+		claims := new(models.UserAuthClaims)
+		parser := &jwt.Parser{SkipClaimsValidation: true}
+		_, err = parser.ParseWithClaims(res.Token, claims, func(*jwt.Token) (interface{}, error) {
+			return &privateKey.PublicKey, nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "id", claims.ID)
+		assert.Equal(t, "", claims.Tenant)
+		assert.Equal(t, "", claims.Role)
+		assert.True(t, claims.Scoped)
+	})
+
+	mock.AssertExpectations(t)
+}
+
+func TestAuthTenant(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	namespace := &models.Namespace{
+		TenantID: "tenant",
+		Members:  []models.Member{{ID: "owner", Role: guard.RoleOwner}, {ID: "member", Role: guard.RoleOperator}},
+	}
+
+	Err := errors.New("error", "", 0)
+
+	cases := []struct {
+		description   string
+		id            string
+		requiredMocks func()
+		role          string
+		expected      error
+	}{
+		{
+			description: "fails when the namespace is not found",
+			id:          "member",
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(nil, Err).Once()
+			},
+			expected: NewErrNamespaceNotMember("tenant", Err),
+		},
+		{
+			description: "fails when the user is not a member",
+			id:          "other",
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+			},
+			expected: NewErrNamespaceNotMember("tenant", nil),
+		},
+		{
+			description: "succeeds with the member's role",
+			id:          "member",
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+			},
+			role:     guard.RoleOperator,
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			role, err := s.AuthTenant(ctx, tc.id, "tenant")
+			assert.Equal(t, tc.expected, err)
+			assert.Equal(t, tc.role, role)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
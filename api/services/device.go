@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"net"
 	"strings"
+	"time"
 
 	"github.com/shellhub-io/shellhub/api/store"
 	req "github.com/shellhub-io/shellhub/pkg/api/internalclient"
@@ -17,10 +18,13 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-const StatusAccepted = "accepted"
+const (
+	StatusAccepted       = "accepted"
+	StatusDecommissioned = "decommissioned"
+)
 
 type DeviceService interface {
-	ListDevices(ctx context.Context, pagination paginator.Query, filter string, status string, sort string, order string) ([]models.Device, int, error)
+	ListDevices(ctx context.Context, pagination paginator.Query, filter string, status string, sort string, order string, tenantID, userID string) ([]models.Device, int, error)
 	GetDevice(ctx context.Context, uid models.UID) (*models.Device, error)
 	DeleteDevice(ctx context.Context, uid models.UID, tenant string) error
 	RenameDevice(ctx context.Context, uid models.UID, name, tenant string) error
@@ -30,8 +34,15 @@ type DeviceService interface {
 	HandleReportUsage(ns *models.Namespace, ui models.UID, inc bool, device *models.Device) error
 	SetDevicePosition(ctx context.Context, uid models.UID, ip string) error
 	DeviceHeartbeat(ctx context.Context, uid models.UID) error
+	ListDeviceHistory(ctx context.Context, uid models.UID, pagination paginator.Query, from, to time.Time) ([]models.DeviceConnectionEvent, int, error)
+	GetDeviceAvailability(ctx context.Context, uid models.UID, from, to time.Time) (*models.DeviceAvailability, error)
+	TransferDevice(ctx context.Context, uid models.UID, from, to, userID string, sessions bool) (*models.Device, error)
 }
 
+// DeviceAvailabilityPeriod is the default period used to list the device's history and to compute its availability
+// when no period is informed.
+const DeviceAvailabilityPeriod = 7 * 24 * time.Hour
+
 func (s *service) HandleReportUsage(ns *models.Namespace, uid models.UID, inc bool, device *models.Device) error {
 	if !hp.HasBillingInstance(ns) {
 		return nil
@@ -54,7 +65,9 @@ func (s *service) HandleReportUsage(ns *models.Namespace, uid models.UID, inc bo
 	return HandleStatusResponse(status)
 }
 
-func (s *service) ListDevices(ctx context.Context, pagination paginator.Query, filterB64 string, status string, sort string, order string) ([]models.Device, int, error) {
+// ListDevices lists the devices matching the filter. When the user is a member of the namespace restricted to a scope,
+// only the devices in the scope are listed.
+func (s *service) ListDevices(ctx context.Context, pagination paginator.Query, filterB64 string, status string, sort string, order string, tenantID, userID string) ([]models.Device, int, error) {
 	raw, err := base64.StdEncoding.DecodeString(filterB64)
 	if err != nil {
 		return nil, 0, err
@@ -65,27 +78,39 @@ func (s *service) ListDevices(ctx context.Context, pagination paginator.Query, f
 		return nil, 0, err
 	}
 
-	return s.store.DeviceList(ctx, pagination, filter, status, sort, order)
+	uids, err := s.memberDevices(ctx, tenantID, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return s.store.DeviceList(ctx, pagination, filter, status, sort, order, uids)
 }
 
 func (s *service) GetDevice(ctx context.Context, uid models.UID) (*models.Device, error) {
 	return s.store.DeviceGet(ctx, uid)
 }
 
-// DeleteDevice deletes a device from a namespace.
+// DeleteDevice decommissions a device from a namespace.
+//
+// A decommissioned device keeps its name, info, sessions and history for audit, but it is not listed by default and
+// does not count to the namespace's device limit. It is purged by a worker after the retention window.
 //
 // It receives a context, used to "control" the request flow and, the device UID from models.Device and the tenant ID
 // from models.Namespace.
 //
-// It can return an error if the device is not found, NewErrDeviceNotFound(uid, err), if the namespace is not found,
-// NewErrNamespaceNotFound(tenant, err), if the usage cannot be reported, ErrReport or if the store function that
-// delete the device fails.
+// It can return an error if the device is not found or is already decommissioned, NewErrDeviceNotFound(uid, err), if
+// the namespace is not found, NewErrNamespaceNotFound(tenant, err), if the usage cannot be reported, ErrReport or if
+// the store function that decommission the device fails.
 func (s *service) DeleteDevice(ctx context.Context, uid models.UID, tenant string) error {
 	device, err := s.store.DeviceGetByUID(ctx, uid, tenant)
 	if err != nil {
 		return NewErrDeviceNotFound(uid, err)
 	}
 
+	if device.Status == StatusDecommissioned {
+		return NewErrDeviceNotFound(uid, nil)
+	}
+
 	ns, err := s.store.NamespaceGet(ctx, tenant)
 	if err != nil {
 		return NewErrNamespaceNotFound(tenant, err)
@@ -95,7 +120,102 @@ func (s *service) DeleteDevice(ctx context.Context, uid models.UID, tenant strin
 		return err
 	}
 
-	return s.store.DeviceDelete(ctx, uid)
+	if err := s.store.DeviceDecommission(ctx, uid); err != nil {
+		return err
+	}
+
+	s.audit(ctx, tenant, models.AuditDeviceRemove, string(uid), device, nil)
+
+	return nil
+}
+
+// TransferDevice transfers a device from a namespace to another one, optionally with its sessions and records.
+//
+// The user must be the owner of both namespaces. As the device's UID is derived from its tenant, the device is moved
+// to a new UID and the agent learns the new tenant on its next authentication.
+//
+// It can return an error if the device is not found, NewErrDeviceNotFound(uid, err), if a namespace is not found,
+// NewErrNamespaceNotFound(tenant, err), if the user is not the owner of both namespaces, NewErrNamespaceNotOwner, if
+// the target namespace has reached its device limit, NewErrDeviceLimit, or if it has a device with the same name,
+// NewErrDeviceDuplicated.
+func (s *service) TransferDevice(ctx context.Context, uid models.UID, from, to, userID string, sessions bool) (*models.Device, error) {
+	if from == to {
+		return nil, NewErrDeviceTransferInvalid(to, nil)
+	}
+
+	device, err := s.store.DeviceGetByUID(ctx, uid, from)
+	if err != nil || device.Status == StatusDecommissioned {
+		return nil, NewErrDeviceNotFound(uid, err)
+	}
+
+	source, err := s.store.NamespaceGet(ctx, from)
+	if err != nil {
+		return nil, NewErrNamespaceNotFound(from, err)
+	}
+
+	target, err := s.store.NamespaceGet(ctx, to)
+	if err != nil {
+		return nil, NewErrNamespaceNotFound(to, err)
+	}
+
+	if source.Owner != userID {
+		return nil, NewErrNamespaceNotOwner(from, nil)
+	}
+
+	if target.Owner != userID {
+		return nil, NewErrNamespaceNotOwner(to, nil)
+	}
+
+	if device.Status == StatusAccepted && target.MaxDevices > 0 && target.MaxDevices <= target.DevicesCount {
+		return nil, NewErrDeviceLimit(target.MaxDevices, nil)
+	}
+
+	if _, err := s.store.DeviceGetByName(ctx, device.Name, to); err == nil {
+		return nil, NewErrDeviceDuplicated(device.Name, nil)
+	} else if err != store.ErrNoDocuments {
+		return nil, err
+	}
+
+	transfer := &models.DeviceTransfer{
+		UID: device.UID,
+		NewUID: deviceUID(&models.DeviceAuth{
+			Identity:  device.Identity,
+			PublicKey: device.PublicKey,
+			TenantID:  to,
+		}),
+		From:      from,
+		TenantID:  to,
+		CreatedAt: clock.Now(),
+	}
+
+	if err := s.store.DeviceTransfer(ctx, transfer, sessions); err != nil {
+		return nil, err
+	}
+
+	moved, err := s.store.DeviceGetByUID(ctx, models.UID(transfer.NewUID), to)
+	if err != nil {
+		return nil, err
+	}
+
+	// The usage is only reported once the device was transferred, so a failed transfer is never billed. As the device
+	// is already on the target namespace, a failed report is logged, not returned.
+	if device.Status == StatusAccepted {
+		if err := s.HandleReportUsage(source, uid, false, device); err != nil {
+			logrus.WithError(err).WithFields(logrus.Fields{"uid": uid, "tenant_id": from}).
+				Error("failed to report the usage of the device transferred from the namespace")
+		}
+
+		if err := s.HandleReportUsage(target, models.UID(transfer.NewUID), true, device); err != nil {
+			logrus.WithError(err).WithFields(logrus.Fields{"uid": transfer.NewUID, "tenant_id": to}).
+				Error("failed to report the usage of the device transferred to the namespace")
+		}
+	}
+
+	// The transfer is recorded on both namespaces, as the device leaves one and joins the other.
+	s.audit(ctx, from, models.AuditDeviceTransfer, string(uid), device, moved)
+	s.audit(ctx, to, models.AuditDeviceTransfer, transfer.NewUID, device, moved)
+
+	return moved, nil
 }
 
 func (s *service) RenameDevice(ctx context.Context, uid models.UID, name, tenant string) error {
@@ -134,7 +254,14 @@ func (s *service) RenameDevice(ctx context.Context, uid models.UID, name, tenant
 		return NewErrDeviceDuplicated(otherDevice.Name, err)
 	}
 
-	return s.store.DeviceRename(ctx, uid, name)
+	if err := s.store.DeviceRename(ctx, uid, name); err != nil {
+		return err
+	}
+
+	s.audit(ctx, device.TenantID, models.AuditDeviceRename, string(uid),
+		map[string]string{"name": device.Name}, map[string]string{"name": updatedDevice.Name})
+
+	return nil
 }
 
 // LookupDevice looks for a device in a namespace.
@@ -151,7 +278,20 @@ func (s *service) LookupDevice(ctx context.Context, namespace, name string) (*mo
 }
 
 func (s *service) UpdateDeviceStatus(ctx context.Context, uid models.UID, online bool) error {
-	return s.store.DeviceSetOnline(ctx, uid, online)
+	if err := s.store.DeviceSetOnline(ctx, uid, online); err != nil {
+		return err
+	}
+
+	s.recordDeviceConnection(ctx, uid, online)
+
+	// The device is looked up only when its event can be delivered.
+	if !online && s.webhooks != nil {
+		if device, err := s.store.DeviceGet(ctx, uid); err == nil {
+			s.dispatchWebhookEvent(ctx, device.TenantID, models.WebhookEventDeviceOffline, device)
+		}
+	}
+
+	return nil
 }
 
 func (s *service) UpdatePendingStatus(ctx context.Context, uid models.UID, status, tenant string) error {
@@ -170,12 +310,28 @@ func (s *service) UpdatePendingStatus(ctx context.Context, uid models.UID, statu
 		return NewErrDeviceNotFound(uid, err)
 	}
 
+	if device.Status == StatusDecommissioned {
+		return NewErrDeviceNotFound(uid, nil)
+	}
+
 	if device.Status == StatusAccepted {
 		return NewErrDeviceStatusAccepted(nil)
 	}
 
 	if status != StatusAccepted {
-		return s.store.DeviceUpdateStatus(ctx, uid, status)
+		if err := s.store.DeviceUpdateStatus(ctx, uid, status); err != nil {
+			return err
+		}
+
+		action := models.AuditDeviceReject
+		if status == "pending" {
+			action = models.AuditDevicePending
+		}
+
+		s.audit(ctx, device.TenantID, action, string(uid),
+			map[string]string{"status": device.Status}, map[string]string{"status": status})
+
+		return nil
 	}
 
 	sameMacDev, err := s.store.DeviceGetByMac(ctx, device.Identity.MAC, device.TenantID, "accepted")
@@ -209,7 +365,17 @@ func (s *service) UpdatePendingStatus(ctx context.Context, uid models.UID, statu
 		}
 	}
 
-	return s.store.DeviceUpdateStatus(ctx, uid, status)
+	if err := s.store.DeviceUpdateStatus(ctx, uid, status); err != nil {
+		return err
+	}
+
+	s.audit(ctx, device.TenantID, models.AuditDeviceAccept, string(uid),
+		map[string]string{"status": device.Status}, map[string]string{"status": status})
+
+	device.Status = status
+	s.dispatchWebhookEvent(ctx, device.TenantID, models.WebhookEventDeviceAccepted, device)
+
+	return nil
 }
 
 // SetDevicePosition sets the position to a device from its IP.
@@ -251,5 +417,141 @@ func (s *service) SetDevicePosition(ctx context.Context, uid models.UID, ip stri
 }
 
 func (s *service) DeviceHeartbeat(ctx context.Context, uid models.UID) error {
-	return s.store.DeviceSetOnline(ctx, uid, true)
+	if err := s.store.DeviceSetOnline(ctx, uid, true); err != nil {
+		return err
+	}
+
+	s.recordDeviceConnection(ctx, uid, true)
+
+	return nil
+}
+
+// recordDeviceConnection stores a connection event when the device's connection state differs from the last one
+// recorded. The device is recorded online by the tunnel's keep alive, once the tunnel is established, and offline when
+// the tunnel is closed. As failures must not disconnect the device, they are only logged.
+func (s *service) recordDeviceConnection(ctx context.Context, uid models.UID, online bool) {
+	now := clock.Now()
+
+	last, err := s.store.DeviceHistoryGetLast(ctx, uid, now)
+	if err != nil && err != store.ErrNoDocuments {
+		logrus.WithError(err).WithField("uid", uid).Error("failed to get the last device connection event")
+
+		return
+	}
+
+	if last != nil && last.Online == online {
+		return
+	}
+
+	device, err := s.store.DeviceGet(ctx, uid)
+	if err != nil {
+		logrus.WithError(err).WithField("uid", uid).Error("failed to get the device to record its connection event")
+
+		return
+	}
+
+	event := &models.DeviceConnectionEvent{
+		UID:       string(uid),
+		TenantID:  device.TenantID,
+		Online:    online,
+		Timestamp: now,
+	}
+
+	if err := s.store.DeviceHistoryCreate(ctx, event); err != nil {
+		logrus.WithError(err).WithField("uid", uid).Error("failed to record the device connection event")
+	}
+}
+
+// devicePeriod fills an empty period with the default one and validates it.
+func devicePeriod(from, to time.Time) (time.Time, time.Time, error) {
+	if to.IsZero() {
+		to = clock.Now()
+	}
+
+	if from.IsZero() {
+		from = to.Add(-DeviceAvailabilityPeriod)
+	}
+
+	if !from.Before(to) {
+		return from, to, NewErrDevicePeriodInvalid(from, to, nil)
+	}
+
+	return from, to, nil
+}
+
+func (s *service) ListDeviceHistory(ctx context.Context, uid models.UID, pagination paginator.Query, from, to time.Time) ([]models.DeviceConnectionEvent, int, error) {
+	if _, err := s.store.DeviceGet(ctx, uid); err != nil {
+		return nil, 0, NewErrDeviceNotFound(uid, err)
+	}
+
+	from, to, err := devicePeriod(from, to)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return s.store.DeviceHistoryList(ctx, uid, pagination, from, to)
+}
+
+func (s *service) GetDeviceAvailability(ctx context.Context, uid models.UID, from, to time.Time) (*models.DeviceAvailability, error) {
+	if _, err := s.store.DeviceGet(ctx, uid); err != nil {
+		return nil, NewErrDeviceNotFound(uid, err)
+	}
+
+	from, to, err := devicePeriod(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	events, _, err := s.store.DeviceHistoryList(ctx, uid, paginator.Query{Page: -1, PerPage: -1}, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	online := false
+	last, err := s.store.DeviceHistoryGetLast(ctx, uid, from)
+	switch {
+	case err == nil:
+		online = last.Online
+	case err != store.ErrNoDocuments:
+		return nil, err
+	}
+
+	return computeDeviceAvailability(uid, from, to, online, events), nil
+}
+
+// computeDeviceAvailability computes the device's availability in a period from the connection events inside it,
+// sorted from the newest to the oldest, and the connection state at the period's start.
+func computeDeviceAvailability(uid models.UID, from, to time.Time, online bool, events []models.DeviceConnectionEvent) *models.DeviceAvailability {
+	availability := &models.DeviceAvailability{
+		UID:  string(uid),
+		From: from,
+		To:   to,
+	}
+
+	var onlineDuration time.Duration
+
+	since := from
+	for i := len(events) - 1; i >= 0; i-- {
+		event := events[i]
+		if event.Online == online {
+			continue
+		}
+
+		if online {
+			onlineDuration += event.Timestamp.Sub(since)
+			availability.Disconnections++
+		}
+
+		online = event.Online
+		since = event.Timestamp
+	}
+
+	if online {
+		onlineDuration += to.Sub(since)
+	}
+
+	availability.OnlineSeconds = int64(onlineDuration.Seconds())
+	availability.Uptime = float64(onlineDuration) / float64(to.Sub(from)) * 100
+
+	return availability
 }
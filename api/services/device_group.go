@@ -0,0 +1,204 @@
+package services
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/validator"
+)
+
+type DeviceGroupService interface {
+	ListDeviceGroups(ctx context.Context, pagination paginator.Query) ([]models.DeviceGroup, int, error)
+	GetDeviceGroup(ctx context.Context, id, tenant string) (*models.DeviceGroup, error)
+	CreateDeviceGroup(ctx context.Context, group *models.DeviceGroup, tenant string) error
+	UpdateDeviceGroup(ctx context.Context, id, tenant string, group *models.DeviceGroupUpdate) (*models.DeviceGroup, error)
+	DeleteDeviceGroup(ctx context.Context, id, tenant string) error
+	ListDeviceGroupMembers(ctx context.Context, id, tenant string) ([]models.UID, error)
+	ApplyDeviceGroupOperation(ctx context.Context, id, tenant, operation, value string) (*models.DeviceGroupBulkResult, error)
+}
+
+// Operations that can be applied to each device of a device group.
+const (
+	DeviceGroupOperationAccept = "accept"
+	DeviceGroupOperationReject = "reject"
+	DeviceGroupOperationRemove = "remove"
+	DeviceGroupOperationTag    = "tag"
+	DeviceGroupOperationUntag  = "untag"
+)
+
+func (s *service) ListDeviceGroups(ctx context.Context, pagination paginator.Query) ([]models.DeviceGroup, int, error) {
+	return s.store.DeviceGroupList(ctx, pagination)
+}
+
+func (s *service) GetDeviceGroup(ctx context.Context, id, tenant string) (*models.DeviceGroup, error) {
+	group, err := s.store.DeviceGroupGet(ctx, tenant, id)
+	if err != nil {
+		return nil, NewErrDeviceGroupNotFound(id, err)
+	}
+
+	return group, nil
+}
+
+// validateDeviceGroup checks the group's fields and if its static devices belong to the namespace.
+func (s *service) validateDeviceGroup(ctx context.Context, group *models.DeviceGroupFields, tenant string) error {
+	if err := group.Validate(); err != nil {
+		data, _ := validator.GetInvalidFieldsValues(err)
+
+		return NewErrDeviceGroupInvalid(data, nil)
+	}
+
+	for _, uid := range group.Devices {
+		if _, err := s.store.DeviceGetByUID(ctx, models.UID(uid), tenant); err != nil {
+			return NewErrDeviceNotFound(models.UID(uid), err)
+		}
+	}
+
+	return nil
+}
+
+func (s *service) CreateDeviceGroup(ctx context.Context, group *models.DeviceGroup, tenant string) error {
+	if err := s.validateDeviceGroup(ctx, &group.DeviceGroupFields, tenant); err != nil {
+		return err
+	}
+
+	group.TenantID = tenant
+	group.CreatedAt = clock.Now()
+
+	if err := s.store.DeviceGroupCreate(ctx, group); err != nil {
+		if err == store.ErrDuplicate {
+			return NewErrDeviceGroupDuplicated(group.Name, err)
+		}
+
+		return err
+	}
+
+	s.audit(ctx, tenant, models.AuditDeviceGroupCreate, group.ID, nil, group)
+
+	return nil
+}
+
+func (s *service) UpdateDeviceGroup(ctx context.Context, id, tenant string, group *models.DeviceGroupUpdate) (*models.DeviceGroup, error) {
+	if err := s.validateDeviceGroup(ctx, &group.DeviceGroupFields, tenant); err != nil {
+		return nil, err
+	}
+
+	before := s.auditGet(func() (interface{}, error) {
+		return s.store.DeviceGroupGet(ctx, tenant, id)
+	})
+
+	updated, err := s.store.DeviceGroupUpdate(ctx, tenant, id, group)
+	switch err {
+	case nil:
+		s.audit(ctx, tenant, models.AuditDeviceGroupUpdate, id, before, updated)
+
+		return updated, nil
+	case store.ErrDuplicate:
+		return nil, NewErrDeviceGroupDuplicated(group.Name, err)
+	case store.ErrNoDocuments, store.ErrInvalidHex:
+		return nil, NewErrDeviceGroupNotFound(id, err)
+	default:
+		return nil, err
+	}
+}
+
+func (s *service) DeleteDeviceGroup(ctx context.Context, id, tenant string) error {
+	before := s.auditGet(func() (interface{}, error) {
+		return s.store.DeviceGroupGet(ctx, tenant, id)
+	})
+
+	if err := s.store.DeviceGroupDelete(ctx, tenant, id); err != nil {
+		return NewErrDeviceGroupNotFound(id, err)
+	}
+
+	s.audit(ctx, tenant, models.AuditDeviceGroupRemove, id, before, nil)
+
+	return nil
+}
+
+func (s *service) ListDeviceGroupMembers(ctx context.Context, id, tenant string) ([]models.UID, error) {
+	group, err := s.store.DeviceGroupGet(ctx, tenant, id)
+	if err != nil {
+		return nil, NewErrDeviceGroupNotFound(id, err)
+	}
+
+	return s.store.DeviceGroupMembers(ctx, group)
+}
+
+// ApplyDeviceGroupOperation applies an operation to each device of a device group.
+//
+// The operation is applied to all members, even when it fails for some of them. Value is the tag's name when the
+// operation is DeviceGroupOperationTag or DeviceGroupOperationUntag.
+func (s *service) ApplyDeviceGroupOperation(ctx context.Context, id, tenant, operation, value string) (*models.DeviceGroupBulkResult, error) {
+	operations := map[string]func(uid models.UID) error{
+		DeviceGroupOperationAccept: func(uid models.UID) error {
+			return s.UpdatePendingStatus(ctx, uid, "accepted", tenant)
+		},
+		DeviceGroupOperationReject: func(uid models.UID) error {
+			return s.UpdatePendingStatus(ctx, uid, "rejected", tenant)
+		},
+		DeviceGroupOperationRemove: func(uid models.UID) error {
+			return s.DeleteDevice(ctx, uid, tenant)
+		},
+		DeviceGroupOperationTag: func(uid models.UID) error {
+			return s.CreateDeviceTag(ctx, uid, value)
+		},
+		DeviceGroupOperationUntag: func(uid models.UID) error {
+			return s.RemoveDeviceTag(ctx, uid, value)
+		},
+	}
+
+	fn, ok := operations[operation]
+	if !ok {
+		return nil, NewErrDeviceGroupOperation(operation, nil)
+	}
+
+	uids, err := s.ListDeviceGroupMembers(ctx, id, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.DeviceGroupBulkResult{
+		Succeeded: make([]string, 0),
+		Failed:    make(map[string]string),
+	}
+
+	for _, uid := range uids {
+		if err := fn(uid); err != nil {
+			result.Failed[string(uid)] = err.Error()
+
+			continue
+		}
+
+		result.Succeeded = append(result.Succeeded, string(uid))
+	}
+
+	return result, nil
+}
+
+// isDeviceGroupMember checks if a device belongs to a device group of the namespace.
+func (s *service) isDeviceGroupMember(ctx context.Context, id, tenant string, uid models.UID) (bool, error) {
+	group, err := s.store.DeviceGroupGet(ctx, tenant, id)
+	if err != nil {
+		if err == store.ErrNoDocuments {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	uids, err := s.store.DeviceGroupMembers(ctx, group)
+	if err != nil {
+		return false, err
+	}
+
+	for _, member := range uids {
+		if member == uid {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	storecache "github.com/shellhub-io/shellhub/api/cache"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateDeviceGroup(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	cases := []struct {
+		description   string
+		group         *models.DeviceGroup
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "Fails when the group has neither devices nor filter",
+			group:       &models.DeviceGroup{DeviceGroupFields: models.DeviceGroupFields{Name: "group"}},
+			requiredMocks: func() {
+			},
+			expected: NewErrDeviceGroupInvalid(map[string]interface{}{"Devices": []string(nil), "Filter": []models.Filter(nil)}, nil),
+		},
+		{
+			description: "Fails when a device does not belong to the namespace",
+			group:       &models.DeviceGroup{DeviceGroupFields: models.DeviceGroupFields{Name: "group", Devices: []string{"uid"}}},
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(nil, Err).Once()
+			},
+			expected: NewErrDeviceNotFound(models.UID("uid"), Err),
+		},
+		{
+			description: "Fails when the group name is duplicated",
+			group:       &models.DeviceGroup{DeviceGroupFields: models.DeviceGroupFields{Name: "group", Devices: []string{"uid"}}},
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(&models.Device{UID: "uid"}, nil).Once()
+				mock.On("DeviceGroupCreate", ctx, &models.DeviceGroup{TenantID: "tenant", CreatedAt: now, DeviceGroupFields: models.DeviceGroupFields{Name: "group", Devices: []string{"uid"}}}).Return(store.ErrDuplicate).Once()
+			},
+			expected: NewErrDeviceGroupDuplicated("group", store.ErrDuplicate),
+		},
+		{
+			description: "Succeeds",
+			group:       &models.DeviceGroup{DeviceGroupFields: models.DeviceGroupFields{Name: "group", Filter: []models.Filter{{Type: "property", Params: &models.PropertyParams{Name: "online", Operator: "bool", Value: true}}}}},
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				mock.On("DeviceGroupCreate", ctx, &models.DeviceGroup{TenantID: "tenant", CreatedAt: now, DeviceGroupFields: models.DeviceGroupFields{Name: "group", Filter: []models.Filter{{Type: "property", Params: &models.PropertyParams{Name: "online", Operator: "bool", Value: true}}}}}).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+			err := s.CreateDeviceGroup(ctx, tc.group, "tenant")
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestUpdateDeviceGroup(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	update := &models.DeviceGroupUpdate{DeviceGroupFields: models.DeviceGroupFields{Name: "group", Devices: []string{"uid"}}}
+	group := &models.DeviceGroup{ID: "id", TenantID: "tenant", DeviceGroupFields: update.DeviceGroupFields}
+
+	type Expected struct {
+		group *models.DeviceGroup
+		err   error
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "Fails when the group is not found",
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(&models.Device{UID: "uid"}, nil).Once()
+				mock.On("DeviceGroupUpdate", ctx, "tenant", "id", update).Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{nil, NewErrDeviceGroupNotFound("id", store.ErrNoDocuments)},
+		},
+		{
+			description: "Succeeds",
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(&models.Device{UID: "uid"}, nil).Once()
+				mock.On("DeviceGroupUpdate", ctx, "tenant", "id", update).Return(group, nil).Once()
+			},
+			expected: Expected{group, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+			group, err := s.UpdateDeviceGroup(ctx, "id", "tenant", update)
+			assert.Equal(t, tc.expected, Expected{group, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestApplyDeviceGroupOperation(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	group := &models.DeviceGroup{ID: "id", TenantID: "tenant", DeviceGroupFields: models.DeviceGroupFields{Name: "group", Devices: []string{"uid1", "uid2"}}}
+
+	type Expected struct {
+		result *models.DeviceGroupBulkResult
+		err    error
+	}
+
+	cases := []struct {
+		description   string
+		operation     string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "Fails when the operation is invalid",
+			operation:   "invalid",
+			requiredMocks: func() {
+			},
+			expected: Expected{nil, NewErrDeviceGroupOperation("invalid", nil)},
+		},
+		{
+			description: "Fails when the group is not found",
+			operation:   DeviceGroupOperationTag,
+			requiredMocks: func() {
+				mock.On("DeviceGroupGet", ctx, "tenant", "id").Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{nil, NewErrDeviceGroupNotFound("id", store.ErrNoDocuments)},
+		},
+		{
+			description: "Succeeds applying the operation on each member",
+			operation:   DeviceGroupOperationTag,
+			requiredMocks: func() {
+				mock.On("DeviceGroupGet", ctx, "tenant", "id").Return(group, nil).Once()
+				mock.On("DeviceGroupMembers", ctx, group).Return([]models.UID{"uid1", "uid2"}, nil).Once()
+				mock.On("DeviceGet", ctx, models.UID("uid1")).Return(&models.Device{UID: "uid1"}, nil).Once()
+				mock.On("DeviceCreateTag", ctx, models.UID("uid1"), "sitex").Return(nil).Once()
+				mock.On("DeviceGet", ctx, models.UID("uid2")).Return(&models.Device{UID: "uid2", Tags: []string{"sitex"}}, nil).Once()
+			},
+			expected: Expected{&models.DeviceGroupBulkResult{
+				Succeeded: []string{"uid1"},
+				Failed:    map[string]string{"uid2": NewErrTagDuplicated("sitex", nil).Error()},
+			}, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+			result, err := s.ApplyDeviceGroupOperation(ctx, "id", "tenant", tc.operation, "sitex")
+			assert.Equal(t, tc.expected, Expected{result, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
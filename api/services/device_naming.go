@@ -0,0 +1,205 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/validator"
+)
+
+const (
+	// DeviceNameMaxLength is the max length of a device's name, as it must be a single RFC 1123 label.
+	DeviceNameMaxLength = 63
+
+	// deviceNameSequence is rendered in place of the {seq} placeholder to find where the number goes on the name.
+	deviceNameSequence = "zzseqzz"
+)
+
+var (
+	deviceNamePlaceholder = regexp.MustCompile(`{[a-z0-9]*}`)
+	deviceNameInvalid     = regexp.MustCompile(`[^a-z0-9-]+`)
+)
+
+// renderDeviceName replaces the placeholders of a device naming template with the device's data, returning a name
+// with only lower case letters, digits and hyphens.
+func renderDeviceName(template, hostname string, device *models.Device, seq string) string {
+	var mac, os, arch string
+	if device.Identity != nil {
+		mac = strings.ReplaceAll(device.Identity.MAC, ":", "")
+	}
+
+	if device.Info != nil {
+		os = device.Info.ID
+		arch = device.Info.Arch
+	}
+
+	mac4 := mac
+	if len(mac4) > 4 {
+		mac4 = mac4[len(mac4)-4:]
+	}
+
+	name := strings.NewReplacer(
+		"{hostname}", hostname,
+		"{mac}", mac,
+		"{mac4}", mac4,
+		"{os}", os,
+		"{arch}", arch,
+		"{seq}", seq,
+	).Replace(template)
+
+	return formatDeviceName(name, "")
+}
+
+// formatDeviceName lowers the name, replaces its invalid characters by hyphens and truncates it so the name with the
+// suffix fits in a RFC 1123 label.
+func formatDeviceName(name, suffix string) string {
+	name = deviceNameInvalid.ReplaceAllString(strings.ToLower(name), "-")
+	name = strings.Trim(name, "-")
+
+	if limit := DeviceNameMaxLength - len(suffix); len(name) > limit {
+		name = strings.TrimRight(name[:limit], "-")
+	}
+
+	return name + suffix
+}
+
+// validateDeviceNaming checks if the template has only known placeholders and results in valid device's names.
+func validateDeviceNaming(naming *models.DeviceNaming) error {
+	if data, err := validator.ValidateStructFields(naming); err != nil {
+		return NewErrDeviceNamingInvalid(data, err)
+	}
+
+	known := strings.NewReplacer("{hostname}", "", "{mac}", "", "{mac4}", "", "{os}", "", "{arch}", "", "{seq}", "").
+		Replace(naming.Template)
+	if placeholder := deviceNamePlaceholder.FindString(known); placeholder != "" {
+		return NewErrDeviceNamingInvalid(map[string]interface{}{"Template": naming.Template}, nil)
+	}
+
+	sample := &models.Device{
+		Identity: &models.DeviceIdentity{MAC: "00:00:00:00:00:00"},
+		Info:     &models.DeviceInfo{ID: "linux", Arch: "amd64"},
+	}
+
+	if !validator.ValidateFieldDeviceName(renderDeviceName(naming.Template, "device", sample, "1")) {
+		return NewErrDeviceNamingInvalid(map[string]interface{}{"Template": naming.Template}, nil)
+	}
+
+	return nil
+}
+
+// deviceNameAvailable checks if a name is not used by any device on the namespace.
+func (s *service) deviceNameAvailable(ctx context.Context, name, tenant string) (bool, error) {
+	if _, err := s.store.DeviceGetByName(ctx, name, tenant); err != nil {
+		if err == store.ErrNoDocuments {
+			return true, nil
+		}
+
+		return false, err
+	}
+
+	return false, nil
+}
+
+// deviceNameSequencePattern returns the regular expression matching the names made of the parts joined by a number,
+// capturing the number.
+func deviceNameSequencePattern(parts []string) string {
+	quoted := make([]string, len(parts))
+	for i, part := range parts {
+		quoted[i] = regexp.QuoteMeta(part)
+	}
+
+	return "^" + strings.Join(quoted, "([0-9]+)") + "$"
+}
+
+// generateDeviceName generates the name of a device registering on a namespace from its naming template, applying
+// the namespace's collision policy when the name is already in use.
+//
+// It can return an error if the generated name is invalid, NewErrDeviceInvalid, or if the collision policy rejects the
+// name, NewErrDeviceDuplicated.
+func (s *service) generateDeviceName(ctx context.Context, naming *models.DeviceNaming, hostname string, device *models.Device) (string, error) {
+	if hostname == "" && device.Identity != nil {
+		hostname = device.Identity.MAC
+	}
+
+	// A template with a sequence is resolved by the next number of the namespace's sequence for the names it renders,
+	// which is got atomically after the highest number already used, so concurrent registrations get distinct names.
+	if strings.Contains(naming.Template, "{seq}") {
+		rendered := renderDeviceName(naming.Template, hostname, device, deviceNameSequence)
+		if !strings.Contains(rendered, deviceNameSequence) {
+			return "", NewErrDeviceInvalid(map[string]interface{}{"Name": rendered}, nil)
+		}
+
+		parts := strings.Split(rendered, deviceNameSequence)
+
+		seq, err := s.store.DeviceNameNextSequence(ctx, device.TenantID, deviceNameSequencePattern(parts), 1)
+		if err != nil {
+			return "", err
+		}
+
+		name := strings.Join(parts, strconv.Itoa(seq))
+		if !validator.ValidateFieldDeviceName(name) {
+			return "", NewErrDeviceInvalid(map[string]interface{}{"Name": name}, nil)
+		}
+
+		return name, nil
+	}
+
+	name := renderDeviceName(naming.Template, hostname, device, "")
+	if !validator.ValidateFieldDeviceName(name) {
+		return "", NewErrDeviceInvalid(map[string]interface{}{"Name": name}, nil)
+	}
+
+	if naming.Collision == models.DeviceNamingCollisionAllow {
+		return name, nil
+	}
+
+	available, err := s.deviceNameAvailable(ctx, name, device.TenantID)
+	if err != nil {
+		return "", err
+	}
+
+	if available {
+		return name, nil
+	}
+
+	switch naming.Collision {
+	case models.DeviceNamingCollisionReject:
+		return "", NewErrDeviceDuplicated(name, nil)
+	case models.DeviceNamingCollisionUID:
+		return formatDeviceName(name, "-"+device.UID[:8]), nil
+	default:
+		seq, err := s.store.DeviceNameNextSequence(ctx, device.TenantID, deviceNameSequencePattern([]string{name + "-", ""}), 2)
+		if err != nil {
+			return "", err
+		}
+
+		return formatDeviceName(name, fmt.Sprintf("-%d", seq)), nil
+	}
+}
+
+// EditDeviceNaming sets how the devices are named when they register on a namespace.
+//
+// A nil naming removes the namespace's naming settings, keeping the device's hostname as its name. It can return an
+// error if the namespace is not found, NewErrNamespaceNotFound, or if the naming is invalid, NewErrDeviceNamingInvalid.
+func (s *service) EditDeviceNaming(ctx context.Context, tenantID string, naming *models.DeviceNaming) error {
+	if naming != nil {
+		if err := validateDeviceNaming(naming); err != nil {
+			return err
+		}
+	}
+
+	before := s.auditSettings(ctx, tenantID)
+
+	if err := s.store.NamespaceSetDeviceNaming(ctx, tenantID, naming); err != nil {
+		return NewErrNamespaceNotFound(tenantID, err)
+	}
+
+	s.audit(ctx, tenantID, models.AuditNamespaceDeviceNaming, tenantID, before, s.auditSettings(ctx, tenantID))
+
+	return nil
+}
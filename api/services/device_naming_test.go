@@ -0,0 +1,250 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	storecache "github.com/shellhub-io/shellhub/api/cache"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderDeviceName(t *testing.T) {
+	device := &models.Device{
+		UID:      "a1b2c3d4e5f6",
+		Identity: &models.DeviceIdentity{MAC: "00:11:22:33:aa:bb"},
+		Info:     &models.DeviceInfo{ID: "ubuntu", Arch: "amd64"},
+	}
+
+	cases := []struct {
+		description string
+		template    string
+		hostname    string
+		seq         string
+		expected    string
+	}{
+		{
+			description: "renders the hostname with the last MAC characters",
+			template:    "{hostname}-{mac4}",
+			hostname:    "raspberrypi",
+			expected:    "raspberrypi-aabb",
+		},
+		{
+			description: "renders the operating system with a sequence",
+			template:    "{os}-{arch}-{seq}",
+			seq:         "3",
+			expected:    "ubuntu-amd64-3",
+		},
+		{
+			description: "replaces invalid characters",
+			template:    "Edge_{hostname}.",
+			hostname:    "my.host",
+			expected:    "edge-my-host",
+		},
+		{
+			description: "truncates long names",
+			template:    "{hostname}",
+			hostname:    "abcdefghijabcdefghijabcdefghijabcdefghijabcdefghijabcdefghijabcdefghij",
+			expected:    "abcdefghijabcdefghijabcdefghijabcdefghijabcdefghijabcdefghijabc",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			name := renderDeviceName(tc.template, tc.hostname, device, tc.seq)
+			assert.Equal(t, tc.expected, name)
+			assert.True(t, validator.ValidateFieldDeviceName(name))
+		})
+	}
+}
+
+func TestGenerateDeviceName(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	device := &models.Device{
+		UID:      "a1b2c3d4e5f6",
+		TenantID: "tenant",
+		Identity: &models.DeviceIdentity{MAC: "00:11:22:33:aa:bb"},
+		Info:     &models.DeviceInfo{ID: "ubuntu", Arch: "amd64"},
+	}
+	Err := errors.New("error", "", 0)
+
+	type Expected struct {
+		name string
+		err  error
+	}
+
+	cases := []struct {
+		description   string
+		naming        *models.DeviceNaming
+		hostname      string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "uses the generated name when it is available",
+			naming:      &models.DeviceNaming{Template: "{hostname}-{mac4}"},
+			hostname:    "pi",
+			requiredMocks: func() {
+				mock.On("DeviceGetByName", ctx, "pi-aabb", "tenant").
+					Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{"pi-aabb", nil},
+		},
+		{
+			description: "uses the MAC address when the hostname is empty",
+			naming:      &models.DeviceNaming{Template: "{hostname}", Collision: models.DeviceNamingCollisionAllow},
+			requiredMocks: func() {
+			},
+			expected: Expected{"00-11-22-33-aa-bb", nil},
+		},
+		{
+			description: "uses the next number of the sequence",
+			naming:      &models.DeviceNaming{Template: "{os}-{seq}"},
+			requiredMocks: func() {
+				mock.On("DeviceNameNextSequence", ctx, "tenant", "^ubuntu-([0-9]+)$", 1).Return(2, nil).Once()
+			},
+			expected: Expected{"ubuntu-2", nil},
+		},
+		{
+			description: "fails when the sequence cannot be got",
+			naming:      &models.DeviceNaming{Template: "{seq}.{arch}"},
+			requiredMocks: func() {
+				mock.On("DeviceNameNextSequence", ctx, "tenant", "^([0-9]+)-amd64$", 1).Return(0, Err).Once()
+			},
+			expected: Expected{"", Err},
+		},
+		{
+			description: "appends a suffix on collision",
+			naming:      &models.DeviceNaming{Template: "{hostname}", Collision: models.DeviceNamingCollisionSuffix},
+			hostname:    "pi",
+			requiredMocks: func() {
+				mock.On("DeviceGetByName", ctx, "pi", "tenant").
+					Return(&models.Device{Name: "pi"}, nil).Once()
+				mock.On("DeviceNameNextSequence", ctx, "tenant", "^pi-([0-9]+)$", 2).Return(3, nil).Once()
+			},
+			expected: Expected{"pi-3", nil},
+		},
+		{
+			description: "appends the UID on collision",
+			naming:      &models.DeviceNaming{Template: "{hostname}", Collision: models.DeviceNamingCollisionUID},
+			hostname:    "pi",
+			requiredMocks: func() {
+				mock.On("DeviceGetByName", ctx, "pi", "tenant").
+					Return(&models.Device{Name: "pi"}, nil).Once()
+			},
+			expected: Expected{"pi-a1b2c3d4", nil},
+		},
+		{
+			description: "rejects on collision",
+			naming:      &models.DeviceNaming{Template: "{hostname}", Collision: models.DeviceNamingCollisionReject},
+			hostname:    "pi",
+			requiredMocks: func() {
+				mock.On("DeviceGetByName", ctx, "pi", "tenant").
+					Return(&models.Device{Name: "pi"}, nil).Once()
+			},
+			expected: Expected{"", NewErrDeviceDuplicated("pi", nil)},
+		},
+		{
+			description: "fails when the store fails",
+			naming:      &models.DeviceNaming{Template: "{hostname}"},
+			hostname:    "pi",
+			requiredMocks: func() {
+				mock.On("DeviceGetByName", ctx, "pi", "tenant").
+					Return(nil, Err).Once()
+			},
+			expected: Expected{"", Err},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			name, err := s.(*service).generateDeviceName(ctx, tc.naming, tc.hostname, device)
+			assert.Equal(t, tc.expected, Expected{name, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestEditDeviceNaming(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	cases := []struct {
+		description   string
+		tenant        string
+		naming        *models.DeviceNaming
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description:   "fails when the collision policy is invalid",
+			tenant:        "tenant",
+			naming:        &models.DeviceNaming{Template: "{hostname}", Collision: "invalid"},
+			requiredMocks: func() {},
+			expected:      NewErrDeviceNamingInvalid(map[string]interface{}{"Collision": "invalid"}, validator.ErrInvalidFields),
+		},
+		{
+			description:   "fails when the template has an unknown placeholder",
+			tenant:        "tenant",
+			naming:        &models.DeviceNaming{Template: "{hostname}-{serial}"},
+			requiredMocks: func() {},
+			expected:      NewErrDeviceNamingInvalid(map[string]interface{}{"Template": "{hostname}-{serial}"}, nil),
+		},
+		{
+			description: "fails when the namespace is not found",
+			tenant:      "tenant",
+			naming:      &models.DeviceNaming{Template: "{hostname}-{mac4}"},
+			requiredMocks: func() {
+				mock.On("NamespaceSetDeviceNaming", ctx, "tenant", &models.DeviceNaming{Template: "{hostname}-{mac4}"}).
+					Return(Err).Once()
+			},
+			expected: NewErrNamespaceNotFound("tenant", Err),
+		},
+		{
+			description: "succeeds to set the naming",
+			tenant:      "tenant",
+			naming:      &models.DeviceNaming{Template: "{os}-{seq}", Collision: models.DeviceNamingCollisionReject},
+			requiredMocks: func() {
+				mock.On("NamespaceSetDeviceNaming", ctx, "tenant", &models.DeviceNaming{Template: "{os}-{seq}", Collision: models.DeviceNamingCollisionReject}).
+					Return(nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "succeeds to remove the naming",
+			tenant:      "tenant",
+			naming:      nil,
+			requiredMocks: func() {
+				mock.On("NamespaceSetDeviceNaming", ctx, "tenant", (*models.DeviceNaming)(nil)).
+					Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			err := s.EditDeviceNaming(ctx, tc.tenant, tc.naming)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -34,7 +34,14 @@ func (s *service) CreateDeviceTag(ctx context.Context, uid models.UID, name stri
 		return NewErrTagDuplicated(name, nil)
 	}
 
-	return s.store.DeviceCreateTag(ctx, uid, name)
+	if err := s.store.DeviceCreateTag(ctx, uid, name); err != nil {
+		return err
+	}
+
+	s.audit(ctx, device.TenantID, models.AuditDeviceTags, string(uid),
+		map[string][]string{"tags": device.Tags}, map[string][]string{"tags": append(device.Tags, name)})
+
+	return nil
 }
 
 func (s *service) RemoveDeviceTag(ctx context.Context, uid models.UID, name string) error {
@@ -47,7 +54,21 @@ func (s *service) RemoveDeviceTag(ctx context.Context, uid models.UID, name stri
 		return NewErrTagNotFound(name, nil)
 	}
 
-	return s.store.DeviceRemoveTag(ctx, uid, name)
+	if err := s.store.DeviceRemoveTag(ctx, uid, name); err != nil {
+		return err
+	}
+
+	tags := make([]string, 0, len(device.Tags))
+	for _, tag := range device.Tags {
+		if tag != name {
+			tags = append(tags, tag)
+		}
+	}
+
+	s.audit(ctx, device.TenantID, models.AuditDeviceTags, string(uid),
+		map[string][]string{"tags": device.Tags}, map[string][]string{"tags": tags})
+
+	return nil
 }
 
 func (s *service) UpdateDeviceTag(ctx context.Context, uid models.UID, tags []string) error {
@@ -81,5 +102,12 @@ func (s *service) UpdateDeviceTag(ctx context.Context, uid models.UID, tags []st
 		return NewErrDeviceNotFound(uid, err)
 	}
 
-	return s.store.DeviceUpdateTag(ctx, uid, tagSet)
+	if err := s.store.DeviceUpdateTag(ctx, uid, tagSet); err != nil {
+		return err
+	}
+
+	s.audit(ctx, device.TenantID, models.AuditDeviceTags, string(uid),
+		map[string][]string{"tags": device.Tags}, map[string][]string{"tags": tagSet})
+
+	return nil
 }
@@ -66,6 +66,7 @@ func TestListDevices(t *testing.T) {
 		requiredMocks                  func()
 		expected                       Expected
 		filterB64, status, sort, order string
+		userID                         string
 	}{
 		{
 			name:       "ListDevices fails when the store device list fails",
@@ -75,7 +76,7 @@ func TestListDevices(t *testing.T) {
 			sort:       sort,
 			order:      order[0],
 			requiredMocks: func() {
-				mock.On("DeviceList", ctx, query, filters, status[0], sort, order[0]).
+				mock.On("DeviceList", ctx, query, filters, status[0], sort, order[0], []models.UID(nil)).
 					Return(nil, 0, Err).Once()
 			},
 			expected: Expected{
@@ -92,7 +93,7 @@ func TestListDevices(t *testing.T) {
 			sort:       sort,
 			order:      order[0],
 			requiredMocks: func() {
-				mock.On("DeviceList", ctx, query, filters, status[0], sort, order[0]).
+				mock.On("DeviceList", ctx, query, filters, status[0], sort, order[0], []models.UID(nil)).
 					Return(devices, len(devices), nil).Once()
 			},
 			expected: Expected{
@@ -101,12 +102,42 @@ func TestListDevices(t *testing.T) {
 				nil,
 			},
 		},
+		{
+			name:       "ListDevices succeeds listing only the devices in the member's scope",
+			pagination: query,
+			filterB64:  encodedFilter,
+			status:     status[0],
+			sort:       sort,
+			order:      order[0],
+			userID:     "member",
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(&models.Namespace{
+					TenantID: "tenant",
+					Members: []models.Member{
+						{ID: "member", Role: guard.RoleObserver, Scope: &models.MemberScope{Tags: []string{"project"}}},
+					},
+				}, nil).Once()
+				mock.On("DeviceGroupMembers", ctx, &models.DeviceGroup{
+					TenantID: "tenant",
+					DeviceGroupFields: models.DeviceGroupFields{Filter: []models.Filter{
+						{Type: "property", Params: &models.PropertyParams{Name: "tags", Operator: "eq", Value: "project"}},
+					}},
+				}).Return([]models.UID{"uid"}, nil).Once()
+				mock.On("DeviceList", ctx, query, filters, status[0], sort, order[0], []models.UID{"uid"}).
+					Return(devices[:1], 1, nil).Once()
+			},
+			expected: Expected{
+				devices[:1],
+				1,
+				nil,
+			},
+		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(*testing.T) {
 			tc.requiredMocks()
-			returnedDevices, count, err := s.ListDevices(ctx, tc.pagination, tc.filterB64, tc.status, tc.sort, tc.order)
+			returnedDevices, count, err := s.ListDevices(ctx, tc.pagination, tc.filterB64, tc.status, tc.sort, tc.order, "tenant", tc.userID)
 			assert.Equal(t, tc.expected, Expected{returnedDevices, count, err})
 		})
 	}
@@ -203,15 +234,26 @@ func TestDeleteDevice(t *testing.T) {
 			expected: NewErrDeviceNotFound(models.UID("_uid"), Err),
 		},
 		{
-			name:   "DeleteDevice fails when the store device delete fails",
+			name:   "DeleteDevice fails when the device is already decommissioned",
 			uid:    models.UID(device.UID),
 			tenant: namespace.TenantID,
 			requiredMocks: func() {
 				mock.On("DeviceGetByUID", ctx, models.UID(device.UID), namespace.TenantID).
-					Return(nil, nil).Once()
+					Return(&models.Device{UID: "uid", TenantID: "tenant", Status: "decommissioned"}, nil).Once()
+			},
+			id:       user.ID,
+			expected: NewErrDeviceNotFound(models.UID(device.UID), nil),
+		},
+		{
+			name:   "DeleteDevice fails when the store device decommission fails",
+			uid:    models.UID(device.UID),
+			tenant: namespace.TenantID,
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID(device.UID), namespace.TenantID).
+					Return(device, nil).Once()
 				mock.On("NamespaceGet", ctx, namespace.TenantID).
 					Return(namespace, nil).Once()
-				mock.On("DeviceDelete", ctx, models.UID(device.UID)).
+				mock.On("DeviceDecommission", ctx, models.UID(device.UID)).
 					Return(Err).Once()
 			},
 			id:       user.ID,
@@ -223,10 +265,10 @@ func TestDeleteDevice(t *testing.T) {
 			tenant: namespace.TenantID,
 			requiredMocks: func() {
 				mock.On("DeviceGetByUID", ctx, models.UID(device.UID), namespace.TenantID).
-					Return(nil, nil).Once()
+					Return(device, nil).Once()
 				mock.On("NamespaceGet", ctx, namespace.TenantID).
 					Return(&models.Namespace{TenantID: namespace.TenantID}, nil).Once()
-				mock.On("DeviceDelete", ctx, models.UID(device.UID)).
+				mock.On("DeviceDecommission", ctx, models.UID(device.UID)).
 					Return(nil).Once()
 			},
 			id:       user.ID,
@@ -282,7 +324,7 @@ func TestDeleteDevice(t *testing.T) {
 					Namespace: namespaceBilling,
 					Timestamp: now.Unix(),
 				}).Return(200, nil).Once()
-				mock.On("DeviceDelete", ctx, models.UID(device.UID)).
+				mock.On("DeviceDecommission", ctx, models.UID(device.UID)).
 					Return(nil).Once()
 			},
 			id:       user.ID,
@@ -533,6 +575,21 @@ func TestUpdateDeviceStatus(t *testing.T) {
 			},
 			expected: Err,
 		},
+		{
+			name:   "UpdateDeviceStatus records the disconnection",
+			uid:    models.UID("uid"),
+			online: false,
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				mock.On("DeviceSetOnline", ctx, models.UID("uid"), false).Return(nil).Once()
+				mock.On("DeviceHistoryGetLast", ctx, models.UID("uid"), now).
+					Return(&models.DeviceConnectionEvent{UID: "uid", TenantID: "tenant", Online: true, Timestamp: now}, nil).Once()
+				mock.On("DeviceGet", ctx, models.UID("uid")).Return(&models.Device{UID: "uid", TenantID: "tenant"}, nil).Once()
+				mock.On("DeviceHistoryCreate", ctx, &models.DeviceConnectionEvent{UID: "uid", TenantID: "tenant", Online: false, Timestamp: now}).
+					Return(nil).Once()
+			},
+			expected: nil,
+		},
 	}
 
 	for _, tc := range cases {
@@ -788,9 +845,336 @@ func TestDeviceHeartbeat(t *testing.T) {
 	clockMock.On("Now").Return(now).Once()
 
 	mock.On("DeviceSetOnline", ctx, uid, true).Return(nil).Once()
+	mock.On("DeviceHistoryGetLast", ctx, uid, now).Return(nil, store.ErrNoDocuments).Once()
+	mock.On("DeviceGet", ctx, uid).Return(&models.Device{UID: "uid", TenantID: "tenant"}, nil).Once()
+	mock.On("DeviceHistoryCreate", ctx, &models.DeviceConnectionEvent{UID: "uid", TenantID: "tenant", Online: true, Timestamp: now}).Return(nil).Once()
 
 	err := s.DeviceHeartbeat(ctx, uid)
 	assert.NoError(t, err)
 
+	clockMock.On("Now").Return(now).Once()
+
+	mock.On("DeviceSetOnline", ctx, uid, true).Return(nil).Once()
+	mock.On("DeviceHistoryGetLast", ctx, uid, now).Return(&models.DeviceConnectionEvent{UID: "uid", TenantID: "tenant", Online: true, Timestamp: now}, nil).Once()
+
+	err = s.DeviceHeartbeat(ctx, uid)
+	assert.NoError(t, err)
+
+	clockMock.On("Now").Return(now).Once()
+
+	mock.On("DeviceSetOnline", ctx, uid, true).Return(nil).Once()
+	mock.On("DeviceHistoryGetLast", ctx, uid, now).Return(&models.DeviceConnectionEvent{UID: "uid", TenantID: "tenant", Online: false, Timestamp: now}, nil).Once()
+	mock.On("DeviceGet", ctx, uid).Return(&models.Device{UID: "uid", TenantID: "tenant"}, nil).Once()
+	mock.On("DeviceHistoryCreate", ctx, &models.DeviceConnectionEvent{UID: "uid", TenantID: "tenant", Online: true, Timestamp: now}).Return(nil).Once()
+
+	err = s.DeviceHeartbeat(ctx, uid)
+	assert.NoError(t, err)
+
+	mock.AssertExpectations(t)
+}
+
+func TestListDeviceHistory(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+	uid := models.UID("uid")
+	query := paginator.Query{Page: 1, PerPage: 10}
+	from := now.Add(-time.Hour)
+
+	events := []models.DeviceConnectionEvent{
+		{UID: "uid", TenantID: "tenant", Online: true, Timestamp: now.Add(-time.Minute)},
+	}
+
+	Err := errors.New("error", "", 0)
+
+	type Expected struct {
+		events []models.DeviceConnectionEvent
+		count  int
+		err    error
+	}
+
+	cases := []struct {
+		description   string
+		from          time.Time
+		to            time.Time
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "Fails when the device is not found",
+			from:        from,
+			to:          now,
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, uid).Return(nil, Err).Once()
+			},
+			expected: Expected{nil, 0, NewErrDeviceNotFound(uid, Err)},
+		},
+		{
+			description: "Fails when the period is invalid",
+			from:        now,
+			to:          from,
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, uid).Return(&models.Device{UID: "uid"}, nil).Once()
+			},
+			expected: Expected{nil, 0, NewErrDevicePeriodInvalid(now, from, nil)},
+		},
+		{
+			description: "Succeeds using the default period",
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				mock.On("DeviceGet", ctx, uid).Return(&models.Device{UID: "uid"}, nil).Once()
+				mock.On("DeviceHistoryList", ctx, uid, query, now.Add(-DeviceAvailabilityPeriod), now).Return(events, len(events), nil).Once()
+			},
+			expected: Expected{events, len(events), nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+			events, count, err := s.ListDeviceHistory(ctx, uid, query, tc.from, tc.to)
+			assert.Equal(t, tc.expected, Expected{events, count, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestGetDeviceAvailability(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+	uid := models.UID("uid")
+	query := paginator.Query{Page: -1, PerPage: -1}
+	from := now.Add(-10 * time.Hour)
+
+	Err := errors.New("error", "", 0)
+
+	type Expected struct {
+		availability *models.DeviceAvailability
+		err          error
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "Fails when the device is not found",
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, uid).Return(nil, Err).Once()
+			},
+			expected: Expected{nil, NewErrDeviceNotFound(uid, Err)},
+		},
+		{
+			description: "Succeeds when the device was offline at the period start",
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, uid).Return(&models.Device{UID: "uid"}, nil).Once()
+				mock.On("DeviceHistoryList", ctx, uid, query, from, now).Return([]models.DeviceConnectionEvent{
+					{UID: "uid", Online: false, Timestamp: now.Add(-2 * time.Hour)},
+					{UID: "uid", Online: true, Timestamp: now.Add(-5 * time.Hour)},
+				}, 2, nil).Once()
+				mock.On("DeviceHistoryGetLast", ctx, uid, from).Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{&models.DeviceAvailability{
+				UID:            "uid",
+				From:           from,
+				To:             now,
+				Uptime:         30,
+				OnlineSeconds:  3 * 60 * 60,
+				Disconnections: 1,
+			}, nil},
+		},
+		{
+			description: "Succeeds when the device was online at the period start",
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, uid).Return(&models.Device{UID: "uid"}, nil).Once()
+				mock.On("DeviceHistoryList", ctx, uid, query, from, now).Return([]models.DeviceConnectionEvent{
+					{UID: "uid", Online: true, Timestamp: now.Add(-4 * time.Hour)},
+					{UID: "uid", Online: false, Timestamp: now.Add(-5 * time.Hour)},
+				}, 2, nil).Once()
+				mock.On("DeviceHistoryGetLast", ctx, uid, from).Return(&models.DeviceConnectionEvent{UID: "uid", Online: true}, nil).Once()
+			},
+			expected: Expected{&models.DeviceAvailability{
+				UID:            "uid",
+				From:           from,
+				To:             now,
+				Uptime:         90,
+				OnlineSeconds:  9 * 60 * 60,
+				Disconnections: 1,
+			}, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+			availability, err := s.GetDeviceAvailability(ctx, uid, from, now)
+			assert.Equal(t, tc.expected, Expected{availability, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestTransferDevice(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	identity := &models.DeviceIdentity{MAC: "00:00:00:00:00:00"}
+	device := &models.Device{UID: deviceUID(&models.DeviceAuth{Identity: identity, TenantID: "tenant"}), Name: "name", TenantID: "tenant", Identity: identity, Status: StatusAccepted}
+	transferred := &models.Device{UID: deviceUID(&models.DeviceAuth{Identity: identity, TenantID: "tenant2"}), Name: "name", TenantID: "tenant2", Identity: identity, Status: StatusAccepted}
+	source := &models.Namespace{Name: "group1", Owner: "id", TenantID: "tenant"}
+	target := &models.Namespace{Name: "group2", Owner: "id", TenantID: "tenant2", MaxDevices: -1}
+	full := &models.Namespace{Name: "group2", Owner: "id", TenantID: "tenant2", MaxDevices: 1, DevicesCount: 1}
+	other := &models.Namespace{Name: "group2", Owner: "id2", TenantID: "tenant2"}
+	Err := errors.New("error", "", 0)
+
+	type Expected struct {
+		device *models.Device
+		err    error
+	}
+
+	cases := []struct {
+		description   string
+		uid           models.UID
+		from, to      string
+		user          string
+		sessions      bool
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description:   "fails when the target namespace is the source one",
+			uid:           models.UID(device.UID),
+			from:          "tenant",
+			to:            "tenant",
+			user:          "id",
+			requiredMocks: func() {},
+			expected:      Expected{nil, NewErrDeviceTransferInvalid("tenant", nil)},
+		},
+		{
+			description: "fails when the device is not found",
+			uid:         models.UID(device.UID),
+			from:        "tenant",
+			to:          "tenant2",
+			user:        "id",
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID(device.UID), "tenant").
+					Return(nil, Err).Once()
+			},
+			expected: Expected{nil, NewErrDeviceNotFound(models.UID(device.UID), Err)},
+		},
+		{
+			description: "fails when the target namespace is not found",
+			uid:         models.UID(device.UID),
+			from:        "tenant",
+			to:          "tenant2",
+			user:        "id",
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID(device.UID), "tenant").
+					Return(device, nil).Once()
+				mock.On("NamespaceGet", ctx, "tenant").
+					Return(source, nil).Once()
+				mock.On("NamespaceGet", ctx, "tenant2").
+					Return(nil, Err).Once()
+			},
+			expected: Expected{nil, NewErrNamespaceNotFound("tenant2", Err)},
+		},
+		{
+			description: "fails when the user is not the owner of the target namespace",
+			uid:         models.UID(device.UID),
+			from:        "tenant",
+			to:          "tenant2",
+			user:        "id",
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID(device.UID), "tenant").
+					Return(device, nil).Once()
+				mock.On("NamespaceGet", ctx, "tenant").
+					Return(source, nil).Once()
+				mock.On("NamespaceGet", ctx, "tenant2").
+					Return(other, nil).Once()
+			},
+			expected: Expected{nil, NewErrNamespaceNotOwner("tenant2", nil)},
+		},
+		{
+			description: "fails when the target namespace has reached the device limit",
+			uid:         models.UID(device.UID),
+			from:        "tenant",
+			to:          "tenant2",
+			user:        "id",
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID(device.UID), "tenant").
+					Return(device, nil).Once()
+				mock.On("NamespaceGet", ctx, "tenant").
+					Return(source, nil).Once()
+				mock.On("NamespaceGet", ctx, "tenant2").
+					Return(full, nil).Once()
+			},
+			expected: Expected{nil, NewErrDeviceLimit(1, nil)},
+		},
+		{
+			description: "fails when the target namespace has a device with the same name",
+			uid:         models.UID(device.UID),
+			from:        "tenant",
+			to:          "tenant2",
+			user:        "id",
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID(device.UID), "tenant").
+					Return(device, nil).Once()
+				mock.On("NamespaceGet", ctx, "tenant").
+					Return(source, nil).Once()
+				mock.On("NamespaceGet", ctx, "tenant2").
+					Return(target, nil).Once()
+				mock.On("DeviceGetByName", ctx, "name", "tenant2").
+					Return(transferred, nil).Once()
+			},
+			expected: Expected{nil, NewErrDeviceDuplicated("name", nil)},
+		},
+		{
+			description: "succeeds to transfer the device with its sessions",
+			uid:         models.UID(device.UID),
+			from:        "tenant",
+			to:          "tenant2",
+			user:        "id",
+			sessions:    true,
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID(device.UID), "tenant").
+					Return(device, nil).Once()
+				mock.On("NamespaceGet", ctx, "tenant").
+					Return(source, nil).Once()
+				mock.On("NamespaceGet", ctx, "tenant2").
+					Return(target, nil).Once()
+				mock.On("DeviceGetByName", ctx, "name", "tenant2").
+					Return(nil, store.ErrNoDocuments).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("DeviceTransfer", ctx, &models.DeviceTransfer{
+					UID:       device.UID,
+					NewUID:    transferred.UID,
+					From:      "tenant",
+					TenantID:  "tenant2",
+					CreatedAt: now,
+				}, true).Return(nil).Once()
+				mock.On("DeviceGetByUID", ctx, models.UID(transferred.UID), "tenant2").
+					Return(transferred, nil).Once()
+			},
+			expected: Expected{transferred, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			device, err := s.TransferDevice(ctx, tc.uid, tc.from, tc.to, tc.user, tc.sessions)
+			assert.Equal(t, tc.expected, Expected{device, err})
+		})
+	}
+
 	mock.AssertExpectations(t)
 }
@@ -1,6 +1,8 @@
 package services
 
 import (
+	"time"
+
 	"github.com/shellhub-io/shellhub/pkg/errors"
 	"github.com/shellhub-io/shellhub/pkg/models"
 )
@@ -27,6 +29,8 @@ const (
 	// ErrCodeStore is the error code for when the store function fails. The store function is responsible for execute
 	// the main service action.
 	ErrCodeStore
+	// ErrCodeTooManyRequests is the error code for when the access to a resource is refused after too many attempts.
+	ErrCodeTooManyRequests
 )
 
 // ErrDataNotFound structure should be used to add errors.Data to an error when the resource is not found.
@@ -93,6 +97,42 @@ var (
 	ErrDeviceStatusAccepted      = errors.New("device status accepted", ErrLayer, ErrCodeInvalid)
 	ErrDeviceCreate              = errors.New("device create", ErrLayer, ErrCodeStore)
 	ErrDeviceSetOnline           = errors.New("device set online", ErrLayer, ErrCodeStore)
+	ErrDevicePeriodInvalid       = errors.New("device period invalid", ErrLayer, ErrCodeInvalid)
+	ErrDeviceGroupNotFound       = errors.New("device group not found", ErrLayer, ErrCodeNotFound)
+	ErrDeviceGroupInvalid        = errors.New("device group invalid", ErrLayer, ErrCodeInvalid)
+	ErrDeviceGroupDuplicated     = errors.New("device group duplicated", ErrLayer, ErrCodeDuplicated)
+	ErrDeviceGroupOperation      = errors.New("device group operation invalid", ErrLayer, ErrCodeInvalid)
+	ErrDeviceTransferInvalid     = errors.New("device transfer invalid", ErrLayer, ErrCodeInvalid)
+	ErrDeviceNamingInvalid       = errors.New("device naming invalid", ErrLayer, ErrCodeInvalid)
+	ErrFirewallRuleNotFound      = errors.New("firewall rule not found", ErrLayer, ErrCodeNotFound)
+	ErrFirewallRuleInvalid       = errors.New("firewall rule invalid", ErrLayer, ErrCodeInvalid)
+	ErrIPSetNotFound             = errors.New("ip set not found", ErrLayer, ErrCodeNotFound)
+	ErrIPSetInvalid              = errors.New("ip set invalid", ErrLayer, ErrCodeInvalid)
+	ErrIPSetDuplicated           = errors.New("ip set duplicated", ErrLayer, ErrCodeDuplicated)
+	ErrWebhookNotFound           = errors.New("webhook not found", ErrLayer, ErrCodeNotFound)
+	ErrWebhookInvalid            = errors.New("webhook invalid", ErrLayer, ErrCodeInvalid)
+	ErrWebhookAddressDenied      = errors.New("webhook address not allowed", ErrLayer, ErrCodeInvalid)
+	ErrRoleNotFound              = errors.New("role not found", ErrLayer, ErrCodeNotFound)
+	ErrRoleInvalid               = errors.New("role invalid", ErrLayer, ErrCodeInvalid)
+	ErrRoleDuplicated            = errors.New("role duplicated", ErrLayer, ErrCodeDuplicated)
+	ErrRoleInUse                 = errors.New("role assigned to members", ErrLayer, ErrCodeInvalid)
+	ErrSessionApprovalNotFound   = errors.New("session approval not found", ErrLayer, ErrCodeNotFound)
+	ErrSessionApprovalInvalid    = errors.New("session approval invalid", ErrLayer, ErrCodeInvalid)
+	ErrSessionApprovalDecided    = errors.New("session approval already decided or expired", ErrLayer, ErrCodeInvalid)
+	ErrMemberScopeInvalid        = errors.New("member scope invalid", ErrLayer, ErrCodeInvalid)
+	ErrInvitationNotFound        = errors.New("invitation not found", ErrLayer, ErrCodeNotFound)
+	ErrInvitationInvalid         = errors.New("invitation invalid", ErrLayer, ErrCodeInvalid)
+	ErrInvitationDuplicated      = errors.New("invitation duplicated", ErrLayer, ErrCodeDuplicated)
+	ErrInvitationClosed          = errors.New("invitation already accepted, declined or expired", ErrLayer, ErrCodeInvalid)
+	ErrInvitationEmail           = errors.New("invitation sent to another email", ErrLayer, ErrCodeForbidden)
+	ErrPolicyInvalid             = errors.New("policy invalid", ErrLayer, ErrCodeInvalid)
+	ErrGeoAccessInvalid          = errors.New("geo access invalid", ErrLayer, ErrCodeInvalid)
+	ErrGeoAccessDenied           = errors.New("connections from this country are not allowed", ErrLayer, ErrCodeForbidden)
+	ErrSessionTypeDenied         = errors.New("session type not allowed", ErrLayer, ErrCodeForbidden)
+	ErrSSHAttemptInvalid         = errors.New("ssh attempt invalid", ErrLayer, ErrCodeInvalid)
+	ErrSSHAttemptBlocked         = errors.New("too many failed ssh attempts", ErrLayer, ErrCodeTooManyRequests)
+	ErrNamespaceNotOwner         = errors.New("user is not the namespace owner", ErrLayer, ErrCodeForbidden)
+	ErrNamespaceNotMember        = errors.New("user is not a namespace member", ErrLayer, ErrCodeForbidden)
 	ErrMaxDeviceCountReached     = errors.New("maximum number of accepted devices reached", ErrLayer, ErrCodeLimit)
 	ErrDuplicatedDeviceName      = errors.New("device name duplicated", ErrLayer, ErrCodeDuplicated)
 	ErrPublicKeyDuplicated       = errors.New("public key duplicated", ErrLayer, ErrCodeDuplicated)
@@ -106,6 +146,7 @@ var (
 	ErrSessionNotFound           = errors.New("session not found", ErrLayer, ErrCodeNotFound)
 	ErrAuthInvalid               = errors.New("auth invalid", ErrLayer, ErrCodeInvalid)
 	ErrAuthUnathorized           = errors.New("auth unauthorized", ErrLayer, ErrCodeUnauthorized)
+	ErrAuthLocked                = errors.New("too many failed logins", ErrLayer, ErrCodeTooManyRequests)
 )
 
 // NewErrNotFound returns an error with the ErrDataNotFound and wrap an error.
@@ -360,7 +401,189 @@ func NewErrDeviceSetOnline(id models.UID, err error) error {
 	return NewErrStore(ErrDeviceSetOnline, id, err)
 }
 
+// NewErrDevicePeriodInvalid returns an error to be used when the period used to query a device's history is invalid.
+func NewErrDevicePeriodInvalid(from, to time.Time, next error) error {
+	return NewErrInvalid(ErrDevicePeriodInvalid, map[string]interface{}{"from": from, "to": to}, next)
+}
+
+// NewErrDeviceTransferInvalid returns an error to be used when a device cannot be transferred to a namespace.
+func NewErrDeviceTransferInvalid(tenant string, next error) error {
+	return NewErrInvalid(ErrDeviceTransferInvalid, map[string]interface{}{"tenant": tenant}, next)
+}
+
+// NewErrDeviceNamingInvalid returns an error to be used when the device naming settings are invalid.
+func NewErrDeviceNamingInvalid(data map[string]interface{}, next error) error {
+	return NewErrInvalid(ErrDeviceNamingInvalid, data, next)
+}
+
+// NewErrFirewallRuleNotFound returns an error to be used when the firewall rule is not found.
+func NewErrFirewallRuleNotFound(id string, next error) error {
+	return NewErrNotFound(ErrFirewallRuleNotFound, id, next)
+}
+
+// NewErrFirewallRuleInvalid returns an error to be used when the firewall rule is invalid.
+func NewErrFirewallRuleInvalid(data map[string]interface{}, next error) error {
+	return NewErrInvalid(ErrFirewallRuleInvalid, data, next)
+}
+
+// NewErrPolicyInvalid returns an error to be used when the policy document is invalid.
+func NewErrPolicyInvalid(data map[string]interface{}, next error) error {
+	return NewErrInvalid(ErrPolicyInvalid, data, next)
+}
+
+// NewErrGeoAccessInvalid returns an error to be used when the geo access settings are invalid.
+func NewErrGeoAccessInvalid(data map[string]interface{}, next error) error {
+	return NewErrInvalid(ErrGeoAccessInvalid, data, next)
+}
+
+// NewErrGeoAccessDenied returns an error to be used when a connection is denied by the client's country.
+func NewErrGeoAccessDenied(country string, next error) error {
+	return NewErrForbidden(errors.WithData(ErrGeoAccessDenied, ErrDataInvalid{Data: map[string]interface{}{"country": country}}), next)
+}
+
+// NewErrSessionTypeDenied returns an error to be used when a session type, or a forwarding, is disabled on the
+// namespace.
+func NewErrSessionTypeDenied(kind string, next error) error {
+	return NewErrForbidden(errors.WithData(ErrSessionTypeDenied, ErrDataInvalid{Data: map[string]interface{}{"type": kind}}), next)
+}
+
+// NewErrSSHAttemptInvalid returns an error to be used when the result of an SSH attempt is invalid.
+func NewErrSSHAttemptInvalid(data map[string]interface{}, next error) error {
+	return NewErrInvalid(ErrSSHAttemptInvalid, data, next)
+}
+
+// NewErrSSHAttemptBlocked returns an error to be used when the SSH attempts are refused until a time.
+func NewErrSSHAttemptBlocked(until time.Time, next error) error {
+	return errors.Wrap(errors.WithData(ErrSSHAttemptBlocked, ErrDataInvalid{Data: map[string]interface{}{"blocked_until": until}}), next)
+}
+
+// NewErrIPSetNotFound returns an error to be used when the IP set is not found.
+func NewErrIPSetNotFound(id string, next error) error {
+	return NewErrNotFound(ErrIPSetNotFound, id, next)
+}
+
+// NewErrIPSetInvalid returns an error to be used when the IP set data is invalid.
+func NewErrIPSetInvalid(data map[string]interface{}, next error) error {
+	return NewErrInvalid(ErrIPSetInvalid, data, next)
+}
+
+// NewErrIPSetDuplicated returns an error to be used when an IP set with the same name exists in the namespace.
+func NewErrIPSetDuplicated(name string, next error) error {
+	return NewErrDuplicated(ErrIPSetDuplicated, []string{name}, next)
+}
+
+// NewErrWebhookNotFound returns an error to be used when the webhook is not found.
+func NewErrWebhookNotFound(id string, next error) error {
+	return NewErrNotFound(ErrWebhookNotFound, id, next)
+}
+
+// NewErrWebhookInvalid returns an error to be used when the webhook data is invalid.
+func NewErrWebhookInvalid(data map[string]interface{}, next error) error {
+	return NewErrInvalid(ErrWebhookInvalid, data, next)
+}
+
+// NewErrRoleNotFound returns an error to be used when the custom role is not found.
+func NewErrRoleNotFound(id string, next error) error {
+	return NewErrNotFound(ErrRoleNotFound, id, next)
+}
+
+// NewErrRoleInvalid returns an error to be used when the custom role data is invalid.
+func NewErrRoleInvalid(data map[string]interface{}, next error) error {
+	return NewErrInvalid(ErrRoleInvalid, data, next)
+}
+
+// NewErrRoleDuplicated returns an error to be used when a custom role with the same name exists in the namespace.
+func NewErrRoleDuplicated(name string, next error) error {
+	return NewErrDuplicated(ErrRoleDuplicated, []string{name}, next)
+}
+
+// NewErrRoleInUse returns an error to be used when a custom role assigned to members is deleted or renamed.
+func NewErrRoleInUse(name string, next error) error {
+	return NewErrInvalid(ErrRoleInUse, map[string]interface{}{"name": name}, next)
+}
+
+// NewErrSessionApprovalNotFound returns an error to be used when the session approval is not found.
+func NewErrSessionApprovalNotFound(uid models.UID, next error) error {
+	return NewErrNotFound(ErrSessionApprovalNotFound, string(uid), next)
+}
+
+// NewErrSessionApprovalInvalid returns an error to be used when the session approval data is invalid.
+func NewErrSessionApprovalInvalid(data map[string]interface{}, next error) error {
+	return NewErrInvalid(ErrSessionApprovalInvalid, data, next)
+}
+
+// NewErrSessionApprovalDecided returns an error to be used when the session approval is not pending anymore.
+func NewErrSessionApprovalDecided(uid models.UID, next error) error {
+	return NewErrInvalid(ErrSessionApprovalDecided, map[string]interface{}{"uid": string(uid)}, next)
+}
+
+// NewErrMemberScopeInvalid returns an error to be used when the devices a member is restricted to are invalid.
+func NewErrMemberScopeInvalid(data map[string]interface{}, next error) error {
+	return NewErrInvalid(ErrMemberScopeInvalid, data, next)
+}
+
+// NewErrInvitationNotFound returns an error to be used when the invitation is not found.
+func NewErrInvitationNotFound(id string, next error) error {
+	return NewErrNotFound(ErrInvitationNotFound, id, next)
+}
+
+// NewErrInvitationInvalid returns an error to be used when the invitation data is invalid.
+func NewErrInvitationInvalid(data map[string]interface{}, next error) error {
+	return NewErrInvalid(ErrInvitationInvalid, data, next)
+}
+
+// NewErrInvitationDuplicated returns an error to be used when the email has a pending invitation to the namespace.
+func NewErrInvitationDuplicated(email string, next error) error {
+	return NewErrDuplicated(ErrInvitationDuplicated, []string{email}, next)
+}
+
+// NewErrInvitationClosed returns an error to be used when the invitation is not pending anymore.
+func NewErrInvitationClosed(id string, next error) error {
+	return NewErrInvalid(ErrInvitationClosed, map[string]interface{}{"id": id}, next)
+}
+
+// NewErrInvitationEmail returns an error to be used when the invitation is answered by a user with another email.
+func NewErrInvitationEmail(email string, next error) error {
+	return NewErrForbidden(errors.WithData(ErrInvitationEmail, ErrDataInvalid{Data: map[string]interface{}{"email": email}}), next)
+}
+
+// NewErrNamespaceNotOwner returns an error to be used when the user is not the owner of the namespace.
+func NewErrNamespaceNotOwner(tenant string, next error) error {
+	return NewErrForbidden(errors.WithData(ErrNamespaceNotOwner, ErrDataNotFound{ID: tenant}), next)
+}
+
+// NewErrNamespaceNotMember returns an error to be used when the user is not a member of the namespace, or when the
+// namespace is not found, so its existence is not revealed.
+func NewErrNamespaceNotMember(tenant string, next error) error {
+	return NewErrForbidden(errors.WithData(ErrNamespaceNotMember, ErrDataNotFound{ID: tenant}), next)
+}
+
+// NewErrDeviceGroupNotFound returns an error when the device group is not found.
+func NewErrDeviceGroupNotFound(id string, next error) error {
+	return NewErrNotFound(ErrDeviceGroupNotFound, id, next)
+}
+
+// NewErrDeviceGroupInvalid returns an error to be used when the device group data is invalid.
+func NewErrDeviceGroupInvalid(data map[string]interface{}, next error) error {
+	return NewErrInvalid(ErrDeviceGroupInvalid, data, next)
+}
+
+// NewErrDeviceGroupDuplicated returns an error to be used when a device group with the same name exists in the namespace.
+func NewErrDeviceGroupDuplicated(name string, next error) error {
+	return NewErrDuplicated(ErrDeviceGroupDuplicated, []string{name}, next)
+}
+
+// NewErrDeviceGroupOperation returns an error to be used when the operation to apply on a device group is invalid.
+func NewErrDeviceGroupOperation(operation string, next error) error {
+	return NewErrInvalid(ErrDeviceGroupOperation, map[string]interface{}{"operation": operation}, next)
+}
+
 // NewErrAuthUnathorized returns a error to be used when the auth is unauthorized.
 func NewErrAuthUnathorized(err error) error {
 	return NewErrUnathorized(ErrAuthUnathorized, err)
 }
+
+// NewErrAuthLocked returns an error to be used when the logins are locked out until a time.
+func NewErrAuthLocked(until time.Time, next error) error {
+	return errors.Wrap(errors.WithData(ErrAuthLocked, ErrDataInvalid{Data: map[string]interface{}{"locked_until": until}}), next)
+}
@@ -0,0 +1,36 @@
+package services
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/pkg/exporter"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/sirupsen/logrus"
+)
+
+// WithExporter configures the exporter streaming the sessions, the authentication failures and the actions recorded on
+// the audit log to external systems. Without it, the events are not exported.
+func WithExporter(e exporter.Exporter) Option {
+	return func(s *service) {
+		s.exporter = e
+	}
+}
+
+// export sends an event of a namespace to the exporter. A failure to export the event is only logged.
+func (s *service) export(ctx context.Context, event, tenant string, data interface{}) {
+	if s.exporter == nil {
+		return
+	}
+
+	if err := s.exporter.Export(ctx, &exporter.Event{
+		Type:     event,
+		TenantID: tenant,
+		Time:     clock.Now(),
+		Data:     data,
+	}); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"tenant_id": tenant,
+			"type":      event,
+		}).Error("failed to export the event")
+	}
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	storecache "github.com/shellhub-io/shellhub/api/cache"
+	"github.com/shellhub-io/shellhub/api/pkg/exporter"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	mocklib "github.com/stretchr/testify/mock"
+)
+
+// fakeExporter keeps the exported events.
+type fakeExporter struct {
+	events []*exporter.Event
+}
+
+func (e *fakeExporter) Export(_ context.Context, event *exporter.Event) error {
+	e.events = append(e.events, event)
+
+	return nil
+}
+
+func TestExportSession(t *testing.T) {
+	mock := &mocks.Store{}
+	fake := &fakeExporter{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil, WithExporter(fake))
+
+	ctx := context.TODO()
+
+	session := &models.Session{UID: "uid", TenantID: "tenant", DeviceUID: "device"}
+
+	mock.On("SessionCreate", ctx, *session).Return(session, nil).Once()
+	clockMock.On("Now").Return(now).Once()
+
+	_, err := s.CreateSession(ctx, *session)
+	assert.NoError(t, err)
+
+	mock.On("SessionDeleteActives", ctx, models.UID("uid")).Return(nil).Once()
+	mock.On("SessionGet", ctx, models.UID("uid")).Return(session, nil).Once()
+	clockMock.On("Now").Return(now).Once()
+
+	assert.NoError(t, s.DeactivateSession(ctx, "uid"))
+
+	assert.Equal(t, []*exporter.Event{
+		{Type: exporter.EventSessionStarted, TenantID: "tenant", Time: now, Data: session},
+		{Type: exporter.EventSessionFinished, TenantID: "tenant", Time: now, Data: session},
+	}, fake.events)
+
+	mock.AssertExpectations(t)
+}
+
+func TestExportAuthFailure(t *testing.T) {
+	mock := &mocks.Store{}
+	fake := &fakeExporter{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil, WithExporter(fake))
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		description   string
+		attempt       *models.SSHAttempt
+		requiredMocks func()
+		expected      []*exporter.Event
+	}{
+		{
+			description:   "does not export a successful attempt",
+			attempt:       &models.SSHAttempt{IPAddress: "10.0.0.1", Device: "uid", Success: true},
+			requiredMocks: func() {},
+			expected:      nil,
+		},
+		{
+			description: "exports a failed attempt bound to the device's namespace",
+			attempt:     &models.SSHAttempt{IPAddress: "10.0.0.1", Device: "uid"},
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("uid")).Return(&models.Device{UID: "uid", TenantID: "tenant"}, nil).Once()
+			},
+			expected: []*exporter.Event{
+				{
+					Type:     exporter.EventAuthFailed,
+					TenantID: "tenant",
+					Time:     now,
+					Data:     &exporter.AuthFailure{Method: exporter.AuthMethodSSH, IPAddress: "10.0.0.1", Device: "uid"},
+				},
+			},
+		},
+		{
+			description: "exports a failed attempt to a device not found",
+			attempt:     &models.SSHAttempt{IPAddress: "10.0.0.1", Device: "uid"},
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("uid")).Return(nil, errors.New("error", "", 0)).Once()
+			},
+			expected: []*exporter.Event{
+				{
+					Type: exporter.EventAuthFailed,
+					Time: now,
+					Data: &exporter.AuthFailure{Method: exporter.AuthMethodSSH, IPAddress: "10.0.0.1", Device: "uid"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			fake.events = nil
+
+			if !tc.attempt.Success {
+				clockMock.On("Now").Return(now).Once()
+			}
+
+			clockMock.On("Now").Return(now).Once()
+
+			assert.NoError(t, s.RecordSSHAttempt(ctx, tc.attempt))
+			assert.Equal(t, tc.expected, fake.events)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestExportAudit(t *testing.T) {
+	mock := &mocks.Store{}
+	fake := &fakeExporter{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil, WithExporter(fake))
+
+	ctx := context.TODO()
+
+	rule := &models.FirewallRule{ID: "id", TenantID: "tenant"}
+
+	// The action is exported without being recorded, as the audit log is disabled.
+	mock.On("FirewallRuleGet", ctx, "id").Return(rule, nil).Once()
+	mock.On("FirewallRuleDelete", ctx, "id").Return(nil).Once()
+	clockMock.On("Now").Return(now).Twice()
+
+	assert.NoError(t, s.DeleteFirewallRule(ctx, "id", "tenant"))
+
+	mock.AssertNotCalled(t, "AuditCreate", mocklib.Anything, mocklib.Anything)
+
+	assert.Len(t, fake.events, 1)
+	assert.Equal(t, exporter.EventAudit, fake.events[0].Type)
+	assert.Equal(t, "tenant", fake.events[0].TenantID)
+
+	log, ok := fake.events[0].Data.(*models.AuditLog)
+	assert.True(t, ok)
+	assert.Equal(t, models.AuditFirewallRemove, log.Action)
+
+	mock.AssertExpectations(t)
+}
@@ -0,0 +1,355 @@
+package services
+
+import (
+	"context"
+	"net"
+	"regexp"
+	"strings"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/validator"
+)
+
+const (
+	FirewallActionAllow = "allow"
+	FirewallActionDeny  = "deny"
+)
+
+type FirewallService interface {
+	ListFirewallRules(ctx context.Context, pagination paginator.Query) ([]models.FirewallRule, int, error)
+	GetFirewallRule(ctx context.Context, id, tenant string) (*models.FirewallRule, error)
+	CreateFirewallRule(ctx context.Context, tenant string, fields models.FirewallRuleFields) (*models.FirewallRule, error)
+	UpdateFirewallRule(ctx context.Context, id, tenant string, rule models.FirewallRuleUpdate) (*models.FirewallRule, error)
+	DeleteFirewallRule(ctx context.Context, id, tenant string) error
+	EvaluateFirewall(ctx context.Context, evaluation *models.FirewallEvaluation) (*models.FirewallDecision, error)
+	SimulateFirewall(ctx context.Context, tenant string, simulation *models.FirewallSimulation) (*models.FirewallDecision, error)
+}
+
+func (s *service) ListFirewallRules(ctx context.Context, pagination paginator.Query) ([]models.FirewallRule, int, error) {
+	return s.store.FirewallRuleList(ctx, pagination)
+}
+
+// GetFirewallRule gets a firewall rule of a namespace.
+//
+// It returns NewErrFirewallRuleNotFound when the rule does not exist or belongs to another namespace.
+func (s *service) GetFirewallRule(ctx context.Context, id, tenant string) (*models.FirewallRule, error) {
+	rule, err := s.store.FirewallRuleGet(ctx, id)
+	if err != nil || rule.TenantID != tenant {
+		return nil, NewErrFirewallRuleNotFound(id, err)
+	}
+
+	return rule, nil
+}
+
+// validateFirewallRule checks if the rule's fields are valid and if the tags and the group of its filter, and the IP sets
+// of its source IP, exist on the namespace.
+func (s *service) validateFirewallRule(ctx context.Context, tenant string, fields *models.FirewallRuleFields) error {
+	if err := fields.Validate(); err != nil {
+		data, _ := validator.GetInvalidFieldsValues(err)
+
+		return NewErrFirewallRuleInvalid(data, nil)
+	}
+
+	if len(fields.Filter.Tags) > 0 {
+		tags, _, err := s.store.TagsGet(ctx, tenant)
+		if err != nil {
+			return NewErrTagEmpty(tenant, err)
+		}
+
+		for _, tag := range fields.Filter.Tags {
+			if !contains(tags, tag) {
+				return NewErrTagNotFound(tag, nil)
+			}
+		}
+	}
+
+	if fields.Filter.Group != "" {
+		if _, err := s.store.DeviceGroupGet(ctx, tenant, fields.Filter.Group); err != nil {
+			return NewErrDeviceGroupNotFound(fields.Filter.Group, err)
+		}
+	}
+
+	entries, _ := models.ParseFirewallSourceIP(fields.SourceIP)
+	for _, entry := range entries {
+		if entry.Set == "" {
+			continue
+		}
+
+		if _, err := s.store.IPSetGetByName(ctx, tenant, entry.Set); err != nil {
+			return NewErrIPSetNotFound(entry.Set, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *service) CreateFirewallRule(ctx context.Context, tenant string, fields models.FirewallRuleFields) (*models.FirewallRule, error) {
+	if err := s.validateFirewallRule(ctx, tenant, &fields); err != nil {
+		return nil, err
+	}
+
+	rule := &models.FirewallRule{
+		TenantID:           tenant,
+		FirewallRuleFields: fields,
+	}
+
+	if err := s.store.FirewallRuleCreate(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, tenant, models.AuditFirewallCreate, rule.ID, nil, rule)
+
+	return rule, nil
+}
+
+func (s *service) UpdateFirewallRule(ctx context.Context, id, tenant string, rule models.FirewallRuleUpdate) (*models.FirewallRule, error) {
+	before, err := s.GetFirewallRule(ctx, id, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validateFirewallRule(ctx, tenant, &rule.FirewallRuleFields); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.store.FirewallRuleUpdate(ctx, id, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, tenant, models.AuditFirewallUpdate, id, before, updated)
+
+	return updated, nil
+}
+
+func (s *service) DeleteFirewallRule(ctx context.Context, id, tenant string) error {
+	before, err := s.GetFirewallRule(ctx, id, tenant)
+	if err != nil {
+		return err
+	}
+
+	if err := s.store.FirewallRuleDelete(ctx, id); err != nil {
+		return err
+	}
+
+	s.audit(ctx, tenant, models.AuditFirewallRemove, id, before, nil)
+
+	return nil
+}
+
+// EvaluateFirewall evaluates a connection to a device against the active firewall rules of the device's namespace.
+//
+// The rules are evaluated by priority and the action of the first rule matching the connection's source IP, username
+// and device decides if the connection is allowed. A rule with a schedule is evaluated only when the schedule is active
+// at the current time. When no rule matches, the connection is allowed. The decision contains the matched rule and the
+// trace of every rule evaluated.
+func (s *service) EvaluateFirewall(ctx context.Context, evaluation *models.FirewallEvaluation) (*models.FirewallDecision, error) {
+	device, err := s.store.DeviceLookup(ctx, evaluation.Namespace, evaluation.Device)
+	if err != nil {
+		return nil, NewErrDeviceLookUpStore(evaluation.Namespace, evaluation.Device, err)
+	}
+
+	rules, err := s.store.FirewallRuleListActive(ctx, device.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The country is resolved only when a rule requires it.
+	var country string
+	for i := range rules {
+		if len(rules[i].Countries) > 0 {
+			country = s.resolveCountry(evaluation.IPAddress)
+
+			break
+		}
+	}
+
+	decision := &models.FirewallDecision{Allowed: true, Trace: []models.FirewallTraceStep{}}
+
+	now := clock.Now()
+	for i := range rules {
+		step := models.FirewallTraceStep{RuleID: rules[i].ID, Priority: rules[i].Priority, Action: rules[i].Action}
+
+		if rules[i].Schedule != nil && !rules[i].Schedule.Active(now) {
+			step.Result = models.FirewallTraceScheduleInactive
+		} else if step.Result, err = s.evaluateFirewallRule(ctx, &rules[i], evaluation, device, country); err != nil {
+			return nil, err
+		}
+
+		decision.Trace = append(decision.Trace, step)
+
+		if step.Result == models.FirewallTraceMatched {
+			decision.Allowed = rules[i].Action == FirewallActionAllow
+			decision.Rule = &rules[i]
+
+			break
+		}
+	}
+
+	return decision, nil
+}
+
+// SimulateFirewall evaluates a hypothetical connection to a device of a namespace against its firewall rules, as
+// EvaluateFirewall does for a real one.
+func (s *service) SimulateFirewall(ctx context.Context, tenant string, simulation *models.FirewallSimulation) (*models.FirewallDecision, error) {
+	namespace, err := s.store.NamespaceGet(ctx, tenant)
+	if err != nil {
+		return nil, NewErrNamespaceNotFound(tenant, err)
+	}
+
+	return s.EvaluateFirewall(ctx, &models.FirewallEvaluation{
+		Namespace: namespace.Name,
+		Device:    simulation.Device,
+		Username:  simulation.Username,
+		IPAddress: simulation.IPAddress,
+		Type:      simulation.Type,
+		Command:   simulation.Command,
+	})
+}
+
+// evaluateFirewallRule evaluates a firewall rule against a connection to a device, from a country, returning
+// FirewallTraceMatched when the rule matches or the FirewallTrace constant of the first condition not met.
+func (s *service) evaluateFirewallRule(ctx context.Context, rule *models.FirewallRule, evaluation *models.FirewallEvaluation, device *models.Device, country string) (string, error) {
+	if ok, err := s.evaluateFirewallSourceIP(ctx, rule, evaluation.IPAddress); err != nil || !ok {
+		return models.FirewallTraceSourceIPMismatch, err
+	}
+
+	if len(rule.Countries) > 0 && !contains(rule.Countries, country) {
+		return models.FirewallTraceCountryMismatch, nil
+	}
+
+	if ok, err := regexp.MatchString(rule.Username, evaluation.Username); err != nil || !ok {
+		return models.FirewallTraceUsernameMismatch, err
+	}
+
+	ok, err := s.evaluateFirewallFilter(ctx, rule, device)
+	if err != nil || !ok {
+		return models.FirewallTraceFilterMismatch, err
+	}
+
+	if len(rule.SessionTypes) > 0 && !contains(rule.SessionTypes, evaluation.Type) {
+		return models.FirewallTraceSessionTypeMismatch, nil
+	}
+
+	if len(rule.Commands) > 0 && !evaluateFirewallCommand(rule.Commands, evaluation) {
+		return models.FirewallTraceCommandMismatch, nil
+	}
+
+	return models.FirewallTraceMatched, nil
+}
+
+// evaluateFirewallCommand checks if the connection is an exec session whose command line matches one of the patterns.
+//
+// A pattern matches the whole command line, where "*" matches any sequence of characters. Consecutive spaces are
+// ignored on both the pattern and the command line.
+func evaluateFirewallCommand(patterns []string, evaluation *models.FirewallEvaluation) bool {
+	if evaluation.Type != models.SessionTypeExec {
+		return false
+	}
+
+	command := strings.Join(strings.Fields(evaluation.Command), " ")
+	for _, pattern := range patterns {
+		expr := strings.ReplaceAll(regexp.QuoteMeta(strings.Join(strings.Fields(pattern), " ")), `\*`, ".*")
+		if regexp.MustCompile("^" + expr + "$").MatchString(command) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// evaluateFirewallFilter checks if a device matches the filter of a firewall rule.
+func (s *service) evaluateFirewallFilter(ctx context.Context, rule *models.FirewallRule, device *models.Device) (bool, error) {
+	switch {
+	case rule.Filter.Hostname != "":
+		return regexp.MatchString(rule.Filter.Hostname, device.Name)
+	case len(rule.Filter.Tags) > 0:
+		for _, tag := range device.Tags {
+			if contains(rule.Filter.Tags, tag) {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	case rule.Filter.Group != "":
+		return s.isDeviceGroupMember(ctx, rule.Filter.Group, rule.TenantID, models.UID(device.UID))
+	}
+
+	return true, nil
+}
+
+// evaluateFirewallSourceIP checks if an IP address matches the source IP of a firewall rule.
+//
+// The address matches when it is in any entry not excluded and in none of the excluded ones. An IP set not found on
+// the namespace matches no address.
+func (s *service) evaluateFirewallSourceIP(ctx context.Context, rule *models.FirewallRule, address string) (bool, error) {
+	if rule.SourceIPRegexp != "" {
+		if ok, err := regexp.MatchString(rule.SourceIPRegexp, address); err != nil || !ok {
+			return false, err
+		}
+	}
+
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false, nil
+	}
+
+	entries, err := models.ParseFirewallSourceIP(rule.SourceIP)
+	if err != nil {
+		return false, err
+	}
+
+	included := false
+	for _, entry := range entries {
+		if included && !entry.Exclude {
+			continue
+		}
+
+		ok, err := s.evaluateFirewallSourceIPEntry(ctx, rule.TenantID, entry, ip)
+		if err != nil {
+			return false, err
+		}
+
+		if ok && entry.Exclude {
+			return false, nil
+		}
+
+		included = included || ok
+	}
+
+	return included, nil
+}
+
+func (s *service) evaluateFirewallSourceIPEntry(ctx context.Context, tenant string, entry models.FirewallSourceIP, ip net.IP) (bool, error) {
+	switch {
+	case entry.Any:
+		return true, nil
+	case entry.Network != nil:
+		return entry.Network.Contains(ip), nil
+	}
+
+	set, err := s.store.IPSetGetByName(ctx, tenant, entry.Set)
+	if err != nil {
+		if err == store.ErrNoDocuments {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	for _, prefix := range set.Prefixes {
+		network, err := models.ParseIPNetwork(prefix)
+		if err != nil {
+			continue
+		}
+
+		if network.Contains(ip) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,537 @@
+package services
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	storecache "github.com/shellhub-io/shellhub/api/cache"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetFirewallRule(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	rule := &models.FirewallRule{ID: "id", TenantID: "tenant"}
+	Err := errors.New("error", "", 0)
+
+	type Expected struct {
+		rule *models.FirewallRule
+		err  error
+	}
+
+	cases := []struct {
+		description   string
+		id, tenant    string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the rule is not found",
+			id:          "id",
+			tenant:      "tenant",
+			requiredMocks: func() {
+				mock.On("FirewallRuleGet", ctx, "id").Return(nil, Err).Once()
+			},
+			expected: Expected{nil, NewErrFirewallRuleNotFound("id", Err)},
+		},
+		{
+			description: "fails when the rule belongs to another namespace",
+			id:          "id",
+			tenant:      "other",
+			requiredMocks: func() {
+				mock.On("FirewallRuleGet", ctx, "id").Return(rule, nil).Once()
+			},
+			expected: Expected{nil, NewErrFirewallRuleNotFound("id", nil)},
+		},
+		{
+			description: "succeeds",
+			id:          "id",
+			tenant:      "tenant",
+			requiredMocks: func() {
+				mock.On("FirewallRuleGet", ctx, "id").Return(rule, nil).Once()
+			},
+			expected: Expected{rule, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			rule, err := s.GetFirewallRule(ctx, tc.id, tc.tenant)
+			assert.Equal(t, tc.expected, Expected{rule, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestCreateFirewallRule(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	fields := models.FirewallRuleFields{
+		Priority: 1,
+		Action:   FirewallActionDeny,
+		Active:   true,
+		SourceIP: "*",
+		Username: ".*",
+		Filter:   models.FirewallFilter{Tags: []string{"production"}},
+	}
+
+	type Expected struct {
+		rule *models.FirewallRule
+		err  error
+	}
+
+	cases := []struct {
+		description   string
+		fields        models.FirewallRuleFields
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description:   "fails when the rule is invalid",
+			fields:        models.FirewallRuleFields{Action: "drop", SourceIP: "*", Username: ".*", Filter: models.FirewallFilter{Hostname: ".*"}},
+			requiredMocks: func() {},
+			expected:      Expected{nil, NewErrFirewallRuleInvalid(map[string]interface{}{"Action": "drop"}, nil)},
+		},
+		{
+			description:   "fails when the source IP is invalid",
+			fields:        models.FirewallRuleFields{Action: FirewallActionDeny, SourceIP: "10.0.0.0/33", Username: ".*", Filter: models.FirewallFilter{Hostname: ".*"}},
+			requiredMocks: func() {},
+			expected:      Expected{nil, NewErrFirewallRuleInvalid(map[string]interface{}{"SourceIP": "10.0.0.0/33"}, nil)},
+		},
+		{
+			description: "fails when the schedule is invalid",
+			fields: models.FirewallRuleFields{
+				Action:   FirewallActionDeny,
+				SourceIP: "*",
+				Username: ".*",
+				Filter:   models.FirewallFilter{Hostname: ".*"},
+				Schedule: &models.FirewallSchedule{Timezone: "Mars/Olympus"},
+			},
+			requiredMocks: func() {},
+			expected:      Expected{nil, NewErrFirewallRuleInvalid(map[string]interface{}{"Timezone": "Mars/Olympus"}, nil)},
+		},
+		{
+			description: "fails when an IP set does not exist",
+			fields:      models.FirewallRuleFields{Action: FirewallActionDeny, SourceIP: "@office, !10.0.0.1", Username: ".*", Filter: models.FirewallFilter{Hostname: ".*"}},
+			requiredMocks: func() {
+				mock.On("IPSetGetByName", ctx, "tenant", "office").Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{nil, NewErrIPSetNotFound("office", store.ErrNoDocuments)},
+		},
+		{
+			description: "fails when a tag does not exist",
+			fields:      fields,
+			requiredMocks: func() {
+				mock.On("TagsGet", ctx, "tenant").Return([]string{"staging"}, 1, nil).Once()
+			},
+			expected: Expected{nil, NewErrTagNotFound("production", nil)},
+		},
+		{
+			description: "succeeds",
+			fields:      fields,
+			requiredMocks: func() {
+				mock.On("TagsGet", ctx, "tenant").Return([]string{"production"}, 1, nil).Once()
+				mock.On("FirewallRuleCreate", ctx, &models.FirewallRule{TenantID: "tenant", FirewallRuleFields: fields}).
+					Return(nil).Once()
+			},
+			expected: Expected{&models.FirewallRule{TenantID: "tenant", FirewallRuleFields: fields}, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			rule, err := s.CreateFirewallRule(ctx, "tenant", tc.fields)
+			assert.Equal(t, tc.expected, Expected{rule, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestDeleteFirewallRule(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	mock.On("FirewallRuleGet", ctx, "id").Return(&models.FirewallRule{ID: "id", TenantID: "other"}, nil).Once()
+	assert.Equal(t, NewErrFirewallRuleNotFound("id", nil), s.DeleteFirewallRule(ctx, "id", "tenant"))
+
+	mock.On("FirewallRuleGet", ctx, "id").Return(&models.FirewallRule{ID: "id", TenantID: "tenant"}, nil).Once()
+	mock.On("FirewallRuleDelete", ctx, "id").Return(nil).Once()
+	assert.NoError(t, s.DeleteFirewallRule(ctx, "id", "tenant"))
+
+	mock.AssertExpectations(t)
+}
+
+func TestEvaluateFirewall(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	device := &models.Device{UID: "uid", Name: "device", TenantID: "tenant", Tags: []string{"production"}}
+	evaluation := &models.FirewallEvaluation{
+		Namespace: "namespace",
+		Device:    "device",
+		Username:  "root",
+		IPAddress: "10.0.0.1",
+		Type:      models.SessionTypeExec,
+		Command:   "systemctl status nginx",
+	}
+	rule := func(priority int, action, source, username string, filter models.FirewallFilter) models.FirewallRule {
+		return models.FirewallRule{
+			ID:       strconv.Itoa(priority),
+			TenantID: "tenant",
+			FirewallRuleFields: models.FirewallRuleFields{
+				Priority: priority,
+				Action:   action,
+				Active:   true,
+				SourceIP: source,
+				Username: username,
+				Filter:   filter,
+			},
+		}
+	}
+	Err := errors.New("error", "", 0)
+
+	type Expected struct {
+		allowed bool
+		rule    string
+		trace   []string
+		err     error
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the device is not found",
+			requiredMocks: func() {
+				mock.On("DeviceLookup", ctx, "namespace", "device").Return(nil, Err).Once()
+			},
+			expected: Expected{false, "", nil, NewErrDeviceLookUpStore("namespace", "device", Err)},
+		},
+		{
+			description: "allows when no rule matches",
+			requiredMocks: func() {
+				mock.On("DeviceLookup", ctx, "namespace", "device").Return(device, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("FirewallRuleListActive", ctx, "tenant").Return([]models.FirewallRule{
+					rule(1, FirewallActionDeny, "192.168.0.0/16", ".*", models.FirewallFilter{Hostname: ".*"}),
+					rule(2, FirewallActionDeny, "*", "^admin$", models.FirewallFilter{Hostname: ".*"}),
+					rule(3, FirewallActionDeny, "*", ".*", models.FirewallFilter{Tags: []string{"staging"}}),
+					rule(4, FirewallActionDeny, "10.0.0.0/8, !10.0.0.0/24", ".*", models.FirewallFilter{Hostname: ".*"}),
+				}, nil).Once()
+			},
+			expected: Expected{true, "", []string{models.FirewallTraceSourceIPMismatch, models.FirewallTraceUsernameMismatch, models.FirewallTraceFilterMismatch, models.FirewallTraceSourceIPMismatch}, nil},
+		},
+		{
+			description: "denies when the first matching rule denies",
+			requiredMocks: func() {
+				mock.On("DeviceLookup", ctx, "namespace", "device").Return(device, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("FirewallRuleListActive", ctx, "tenant").Return([]models.FirewallRule{
+					rule(1, FirewallActionDeny, "10.0.0.0/8", "^root$", models.FirewallFilter{Tags: []string{"production"}}),
+					rule(2, FirewallActionAllow, "*", ".*", models.FirewallFilter{Hostname: ".*"}),
+				}, nil).Once()
+			},
+			expected: Expected{false, "1", []string{models.FirewallTraceMatched}, nil},
+		},
+		{
+			description: "allows when the first matching rule allows",
+			requiredMocks: func() {
+				mock.On("DeviceLookup", ctx, "namespace", "device").Return(device, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("FirewallRuleListActive", ctx, "tenant").Return([]models.FirewallRule{
+					rule(1, FirewallActionAllow, "10.0.0.1", ".*", models.FirewallFilter{Hostname: "^device$"}),
+					rule(2, FirewallActionDeny, "*", ".*", models.FirewallFilter{Hostname: ".*"}),
+				}, nil).Once()
+			},
+			expected: Expected{true, "1", []string{models.FirewallTraceMatched}, nil},
+		},
+		{
+			description: "denies when the address is in the rule's IP set",
+			requiredMocks: func() {
+				mock.On("DeviceLookup", ctx, "namespace", "device").Return(device, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("FirewallRuleListActive", ctx, "tenant").Return([]models.FirewallRule{
+					rule(1, FirewallActionDeny, "@unknown", ".*", models.FirewallFilter{Hostname: ".*"}),
+					rule(2, FirewallActionDeny, "2001:db8::/32, @office", ".*", models.FirewallFilter{Hostname: ".*"}),
+				}, nil).Once()
+				mock.On("IPSetGetByName", ctx, "tenant", "unknown").Return(nil, store.ErrNoDocuments).Once()
+				mock.On("IPSetGetByName", ctx, "tenant", "office").Return(&models.IPSet{
+					TenantID:    "tenant",
+					IPSetFields: models.IPSetFields{Name: "office", Prefixes: []string{"172.16.0.0/12", "10.0.0.1"}},
+				}, nil).Once()
+			},
+			expected: Expected{false, "2", []string{models.FirewallTraceSourceIPMismatch, models.FirewallTraceMatched}, nil},
+		},
+		{
+			description: "keeps matching the source IP regular expression of older rules",
+			requiredMocks: func() {
+				legacy := rule(1, FirewallActionDeny, "*", ".*", models.FirewallFilter{Hostname: ".*"})
+				legacy.SourceIPRegexp = "^192\\.168\\."
+
+				mock.On("DeviceLookup", ctx, "namespace", "device").Return(device, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("FirewallRuleListActive", ctx, "tenant").Return([]models.FirewallRule{legacy}, nil).Once()
+			},
+			expected: Expected{true, "", []string{models.FirewallTraceSourceIPMismatch}, nil},
+		},
+		{
+			description: "skips the rules whose schedule is not active",
+			requiredMocks: func() {
+				location, _ := time.LoadLocation("America/Sao_Paulo")
+				local := now.In(location)
+				end := now.Add(-time.Hour)
+
+				weekend := rule(1, FirewallActionDeny, "*", ".*", models.FirewallFilter{Hostname: ".*"})
+				weekend.Schedule = &models.FirewallSchedule{Weekdays: []string{strings.ToLower(now.Add(24 * time.Hour).Weekday().String())}}
+
+				expired := rule(2, FirewallActionDeny, "*", ".*", models.FirewallFilter{Hostname: ".*"})
+				expired.Schedule = &models.FirewallSchedule{End: &end}
+
+				later := rule(3, FirewallActionDeny, "*", ".*", models.FirewallFilter{Hostname: ".*"})
+				later.Schedule = &models.FirewallSchedule{
+					Timezone: "America/Sao_Paulo",
+					Ranges: []models.FirewallTimeRange{{
+						From: local.Add(time.Hour).Format("15:04"),
+						To:   local.Add(2 * time.Hour).Format("15:04"),
+					}},
+				}
+
+				maintenance := rule(4, FirewallActionAllow, "*", ".*", models.FirewallFilter{Hostname: ".*"})
+				maintenance.Schedule = &models.FirewallSchedule{
+					Weekdays: []string{strings.ToLower(local.Weekday().String())},
+					Timezone: "America/Sao_Paulo",
+					Ranges: []models.FirewallTimeRange{{
+						From: local.Add(-time.Hour).Format("15:04"),
+						To:   local.Add(time.Hour).Format("15:04"),
+					}},
+				}
+
+				mock.On("DeviceLookup", ctx, "namespace", "device").Return(device, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("FirewallRuleListActive", ctx, "tenant").Return([]models.FirewallRule{
+					weekend,
+					expired,
+					later,
+					maintenance,
+					rule(5, FirewallActionDeny, "*", ".*", models.FirewallFilter{Hostname: ".*"}),
+				}, nil).Once()
+			},
+			expected: Expected{true, "4", []string{models.FirewallTraceScheduleInactive, models.FirewallTraceScheduleInactive, models.FirewallTraceScheduleInactive, models.FirewallTraceMatched}, nil},
+		},
+		{
+			description: "matches the session type and the command of the rules",
+			requiredMocks: func() {
+				term := rule(1, FirewallActionDeny, "*", ".*", models.FirewallFilter{Hostname: ".*"})
+				term.SessionTypes = []string{models.SessionTypeTerm, models.SessionTypeSCP}
+
+				restart := rule(2, FirewallActionAllow, "*", ".*", models.FirewallFilter{Hostname: ".*"})
+				restart.Commands = []string{"systemctl restart *"}
+
+				status := rule(3, FirewallActionAllow, "*", ".*", models.FirewallFilter{Hostname: ".*"})
+				status.SessionTypes = []string{models.SessionTypeExec}
+				status.Commands = []string{"uptime", "systemctl  status *"}
+
+				mock.On("DeviceLookup", ctx, "namespace", "device").Return(device, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("FirewallRuleListActive", ctx, "tenant").Return([]models.FirewallRule{
+					term,
+					restart,
+					status,
+					rule(4, FirewallActionDeny, "*", ".*", models.FirewallFilter{Hostname: ".*"}),
+				}, nil).Once()
+			},
+			expected: Expected{true, "3", []string{models.FirewallTraceSessionTypeMismatch, models.FirewallTraceCommandMismatch, models.FirewallTraceMatched}, nil},
+		},
+		{
+			description: "matches the country of the rules",
+			requiredMocks: func() {
+				abroad := rule(1, FirewallActionDeny, "*", ".*", models.FirewallFilter{Hostname: ".*"})
+				abroad.Countries = []string{"US"}
+
+				mock.On("DeviceLookup", ctx, "namespace", "device").Return(device, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("FirewallRuleListActive", ctx, "tenant").Return([]models.FirewallRule{
+					abroad,
+					rule(2, FirewallActionAllow, "*", ".*", models.FirewallFilter{Hostname: ".*"}),
+				}, nil).Once()
+			},
+			expected: Expected{true, "2", []string{models.FirewallTraceCountryMismatch, models.FirewallTraceMatched}, nil},
+		},
+		{
+			description: "denies when the device is a member of the rule's group",
+			requiredMocks: func() {
+				group := &models.DeviceGroup{ID: "group", TenantID: "tenant"}
+
+				mock.On("DeviceLookup", ctx, "namespace", "device").Return(device, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("FirewallRuleListActive", ctx, "tenant").Return([]models.FirewallRule{
+					rule(1, FirewallActionDeny, "*", ".*", models.FirewallFilter{Group: "group"}),
+				}, nil).Once()
+				mock.On("DeviceGroupGet", ctx, "tenant", "group").Return(group, nil).Once()
+				mock.On("DeviceGroupMembers", ctx, group).Return([]models.UID{"uid"}, nil).Once()
+			},
+			expected: Expected{false, "1", []string{models.FirewallTraceMatched}, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			decision, err := s.EvaluateFirewall(ctx, evaluation)
+
+			result := Expected{err: err}
+			if decision != nil {
+				result.allowed = decision.Allowed
+				if decision.Rule != nil {
+					result.rule = decision.Rule.ID
+				}
+
+				for _, step := range decision.Trace {
+					result.trace = append(result.trace, step.Result)
+				}
+			}
+
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestSimulateFirewall(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	namespace := &models.Namespace{Name: "namespace", TenantID: "tenant"}
+	device := &models.Device{UID: "uid", Name: "device", TenantID: "tenant"}
+	simulation := &models.FirewallSimulation{Device: "device", Username: "root", IPAddress: "10.0.0.1"}
+	rule := models.FirewallRule{
+		ID:       "id",
+		TenantID: "tenant",
+		FirewallRuleFields: models.FirewallRuleFields{
+			Action:   FirewallActionDeny,
+			Active:   true,
+			SourceIP: "10.0.0.0/8",
+			Username: "^root$",
+			Filter:   models.FirewallFilter{Hostname: ".*"},
+		},
+	}
+	Err := errors.New("error", "", 0)
+
+	type Expected struct {
+		decision *models.FirewallDecision
+		err      error
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the namespace is not found",
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(nil, Err).Once()
+			},
+			expected: Expected{nil, NewErrNamespaceNotFound("tenant", Err)},
+		},
+		{
+			description: "succeeds",
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				mock.On("DeviceLookup", ctx, "namespace", "device").Return(device, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("FirewallRuleListActive", ctx, "tenant").Return([]models.FirewallRule{rule}, nil).Once()
+			},
+			expected: Expected{
+				&models.FirewallDecision{
+					Allowed: false,
+					Rule:    &rule,
+					Trace: []models.FirewallTraceStep{
+						{RuleID: "id", Action: FirewallActionDeny, Result: models.FirewallTraceMatched},
+					},
+				},
+				nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			decision, err := s.SimulateFirewall(ctx, "tenant", simulation)
+			assert.Equal(t, tc.expected, Expected{decision, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestEvaluateFirewallCommand(t *testing.T) {
+	cases := []struct {
+		description string
+		patterns    []string
+		evaluation  *models.FirewallEvaluation
+		expected    bool
+	}{
+		{
+			description: "does not match a session other than exec",
+			patterns:    []string{"*"},
+			evaluation:  &models.FirewallEvaluation{Type: models.SessionTypeTerm, Command: "bash"},
+			expected:    false,
+		},
+		{
+			description: "does not match a partial command line",
+			patterns:    []string{"systemctl status"},
+			evaluation:  &models.FirewallEvaluation{Type: models.SessionTypeExec, Command: "systemctl status; rm -rf /"},
+			expected:    false,
+		},
+		{
+			description: "does not handle the pattern as a regular expression",
+			patterns:    []string{"ls ."},
+			evaluation:  &models.FirewallEvaluation{Type: models.SessionTypeExec, Command: "ls a"},
+			expected:    false,
+		},
+		{
+			description: "matches a wildcard",
+			patterns:    []string{"uptime", "systemctl status *"},
+			evaluation:  &models.FirewallEvaluation{Type: models.SessionTypeExec, Command: "systemctl   status sshd.service"},
+			expected:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, evaluateFirewallCommand(tc.patterns, tc.evaluation))
+		})
+	}
+}
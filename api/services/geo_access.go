@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"net"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/validator"
+	"github.com/sirupsen/logrus"
+)
+
+type GeoAccessService interface {
+	EditGeoAccess(ctx context.Context, tenantID string, geo *models.GeoAccess) error
+	EvaluateGeoAccess(ctx context.Context, device *models.Device, ip string) error
+}
+
+// EditGeoAccess sets the countries from which the SSH connections to a namespace's devices are allowed or denied.
+//
+// A nil geo access removes the namespace's restrictions. It can return an error if the namespace is not found,
+// NewErrNamespaceNotFound, or if the settings are invalid, NewErrGeoAccessInvalid.
+func (s *service) EditGeoAccess(ctx context.Context, tenantID string, geo *models.GeoAccess) error {
+	if geo != nil {
+		if data, err := validator.ValidateStructFields(geo); err != nil {
+			return NewErrGeoAccessInvalid(data, err)
+		}
+	}
+
+	before := s.auditSettings(ctx, tenantID)
+
+	if err := s.store.NamespaceSetGeoAccess(ctx, tenantID, geo); err != nil {
+		return NewErrNamespaceNotFound(tenantID, err)
+	}
+
+	s.audit(ctx, tenantID, models.AuditNamespaceGeoAccess, tenantID, before, s.auditSettings(ctx, tenantID))
+
+	return nil
+}
+
+// EvaluateGeoAccess checks if a connection from an IP address to a device is allowed by the geo access settings of the
+// device's namespace.
+//
+// It returns NewErrGeoAccessDenied when the country resolved from the IP address is not allowed.
+func (s *service) EvaluateGeoAccess(ctx context.Context, device *models.Device, ip string) error {
+	namespace, err := s.store.NamespaceGet(ctx, device.TenantID)
+	if err != nil {
+		return NewErrNamespaceNotFound(device.TenantID, err)
+	}
+
+	if namespace.Settings == nil || namespace.Settings.GeoAccess == nil {
+		return nil
+	}
+
+	country := s.resolveCountry(ip)
+	if !namespace.Settings.GeoAccess.Allows(country) {
+		return NewErrGeoAccessDenied(country, nil)
+	}
+
+	return nil
+}
+
+// resolveCountry resolves the ISO 3166-1 alpha-2 code of the country of an IP address, returning an empty string when
+// the country cannot be resolved.
+func (s *service) resolveCountry(ip string) string {
+	address := net.ParseIP(ip)
+	if s.locator == nil || address == nil {
+		return ""
+	}
+
+	country, err := s.locator.GetCountry(address)
+	if err != nil {
+		logrus.WithError(err).WithField("ip", ip).Error("Failed to get the country of the IP address")
+
+		return ""
+	}
+
+	return country
+}
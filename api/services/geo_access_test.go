@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	storecache "github.com/shellhub-io/shellhub/api/cache"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	mocksGeoIp "github.com/shellhub-io/shellhub/pkg/geoip/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEditGeoAccess(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	geo := &models.GeoAccess{Mode: models.GeoAccessDeny, Countries: []string{"KP"}}
+	Err := errors.New("error", "", 0)
+
+	cases := []struct {
+		description   string
+		geo           *models.GeoAccess
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description:   "fails when the mode is invalid",
+			geo:           &models.GeoAccess{Mode: "block", Countries: []string{"KP"}},
+			requiredMocks: func() {},
+			expected:      NewErrGeoAccessInvalid(map[string]interface{}{"Mode": "block"}, validator.ErrInvalidFields),
+		},
+		{
+			description: "fails when the namespace is not found",
+			geo:         geo,
+			requiredMocks: func() {
+				mock.On("NamespaceSetGeoAccess", ctx, "tenant", geo).Return(Err).Once()
+			},
+			expected: NewErrNamespaceNotFound("tenant", Err),
+		},
+		{
+			description: "succeeds",
+			geo:         geo,
+			requiredMocks: func() {
+				mock.On("NamespaceSetGeoAccess", ctx, "tenant", geo).Return(nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "removes the restrictions",
+			geo:         nil,
+			requiredMocks: func() {
+				mock.On("NamespaceSetGeoAccess", ctx, "tenant", (*models.GeoAccess)(nil)).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			err := s.EditGeoAccess(ctx, "tenant", tc.geo)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestEvaluateGeoAccess(t *testing.T) {
+	locator := &mocksGeoIp.Locator{}
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, locator)
+
+	ctx := context.TODO()
+
+	device := &models.Device{UID: "uid", TenantID: "tenant"}
+	namespace := func(geo *models.GeoAccess) *models.Namespace {
+		return &models.Namespace{TenantID: "tenant", Settings: &models.NamespaceSettings{GeoAccess: geo}}
+	}
+	Err := errors.New("error", "", 0)
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the namespace is not found",
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(nil, Err).Once()
+			},
+			expected: NewErrNamespaceNotFound("tenant", Err),
+		},
+		{
+			description: "allows when the namespace has no restrictions",
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace(nil), nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "denies when the country is not in the allow list",
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").
+					Return(namespace(&models.GeoAccess{Mode: models.GeoAccessAllow, Countries: []string{"BR", "PT"}}), nil).Once()
+				locator.On("GetCountry", net.ParseIP("8.8.8.8")).Return("US", nil).Once()
+			},
+			expected: NewErrGeoAccessDenied("US", nil),
+		},
+		{
+			description: "denies when the country is in the deny list",
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").
+					Return(namespace(&models.GeoAccess{Mode: models.GeoAccessDeny, Countries: []string{"US"}}), nil).Once()
+				locator.On("GetCountry", net.ParseIP("8.8.8.8")).Return("US", nil).Once()
+			},
+			expected: NewErrGeoAccessDenied("US", nil),
+		},
+		{
+			description: "allows when the country is in the allow list",
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").
+					Return(namespace(&models.GeoAccess{Mode: models.GeoAccessAllow, Countries: []string{"US"}}), nil).Once()
+				locator.On("GetCountry", net.ParseIP("8.8.8.8")).Return("US", nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			err := s.EvaluateGeoAccess(ctx, device, "8.8.8.8")
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+	locator.AssertExpectations(t)
+}
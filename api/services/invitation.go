@@ -0,0 +1,229 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/pkg/mailer"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/validator"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// InvitationExpiration is how long an invitation can be accepted after it is sent.
+	InvitationExpiration = 7 * 24 * time.Hour
+	// InvitationSendTimeout is how long the email of an invitation can take to be sent.
+	InvitationSendTimeout = 30 * time.Second
+)
+
+// WithMailer configures the mailer sending the invitations and the URL of the page where they are answered, which
+// receives the invitation's token as the "token" query parameter. Without it, the invitations, with their tokens, are
+// only logged.
+func WithMailer(m mailer.Mailer, invitationURL string) Option {
+	return func(s *service) {
+		s.mailer = m
+		s.invitationURL = invitationURL
+	}
+}
+
+type InvitationService interface {
+	ListInvitations(ctx context.Context, pagination paginator.Query) ([]models.Invitation, int, error)
+	CreateInvitation(ctx context.Context, tenant, userID string, invitation *models.InvitationFields) (*models.Invitation, error)
+	DeleteInvitation(ctx context.Context, id, tenant string) error
+	AcceptInvitation(ctx context.Context, userID, token string) (*models.Namespace, error)
+	DeclineInvitation(ctx context.Context, userID, token string) error
+}
+
+func (s *service) ListInvitations(ctx context.Context, pagination paginator.Query) ([]models.Invitation, int, error) {
+	return s.store.InvitationList(ctx, pagination)
+}
+
+// CreateInvitation invites an email to join a namespace with a role, sending the invitation's token to the email.
+//
+// The invited user does not need to have an account, being able to accept the invitation after signing up with the
+// email. It can return an error if the namespace is not found, NewErrNamespaceNotFound, if the user is not a member,
+// NewErrNamespaceMemberNotFound, if the user cannot assign the role, guard.ErrForbidden, if the email is already a
+// member or has a pending invitation, NewErrNamespaceMemberDuplicated and NewErrInvitationDuplicated, or if the email
+// cannot be sent.
+func (s *service) CreateInvitation(ctx context.Context, tenant, userID string, fields *models.InvitationFields) (*models.Invitation, error) {
+	fields.Email = strings.ToLower(fields.Email)
+	if err := fields.Validate(); err != nil {
+		data, _ := validator.GetInvalidFieldsValues(err)
+
+		return nil, NewErrInvitationInvalid(data, nil)
+	}
+
+	namespace, err := s.store.NamespaceGet(ctx, tenant)
+	if err != nil {
+		return nil, NewErrNamespaceNotFound(tenant, err)
+	}
+
+	active, ok := guard.CheckMember(namespace, userID)
+	if !ok {
+		return nil, NewErrNamespaceMemberNotFound(userID, nil)
+	}
+
+	if !checkMemberRole(active.Role, fields.Role) {
+		return nil, guard.ErrForbidden
+	}
+
+	if err := s.validateMemberRole(ctx, tenant, fields.Role); err != nil {
+		return nil, err
+	}
+
+	if user, err := s.store.UserGetByEmail(ctx, fields.Email); err == nil {
+		if _, ok := guard.CheckMember(namespace, user.ID); ok {
+			return nil, NewErrNamespaceMemberDuplicated(user.ID, nil)
+		}
+	}
+
+	if pending, err := s.store.InvitationGetPending(ctx, tenant, fields.Email); err == nil && clock.Now().Before(pending.ExpiresAt) {
+		return nil, NewErrInvitationDuplicated(fields.Email, nil)
+	}
+
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return nil, err
+	}
+
+	invitation := &models.Invitation{
+		TenantID:         tenant,
+		Token:            hex.EncodeToString(token),
+		Status:           models.InvitationPending,
+		InvitedBy:        userID,
+		CreatedAt:        clock.Now(),
+		InvitationFields: *fields,
+	}
+	invitation.ExpiresAt = invitation.CreatedAt.Add(InvitationExpiration)
+
+	if err := s.store.InvitationCreate(ctx, invitation); err != nil {
+		return nil, err
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, InvitationSendTimeout)
+	defer cancel()
+
+	if err := s.mailer.Send(sendCtx, s.invitationMessage(namespace, invitation)); err != nil {
+		// An invitation whose email was not sent cannot be accepted, so it is removed to be sent again.
+		if err := s.store.InvitationDelete(ctx, tenant, invitation.ID); err != nil {
+			logrus.WithError(err).WithField("invitation", invitation.ID).Error("failed to delete the invitation not sent")
+		}
+
+		return nil, err
+	}
+
+	s.audit(ctx, tenant, models.AuditInvitationCreate, invitation.ID, nil, invitation)
+
+	return invitation, nil
+}
+
+// invitationMessage builds the email of an invitation to a namespace.
+func (s *service) invitationMessage(namespace *models.Namespace, invitation *models.Invitation) *mailer.Message {
+	link := invitation.Token
+	if s.invitationURL != "" {
+		link = fmt.Sprintf("%s?token=%s", s.invitationURL, url.QueryEscape(invitation.Token))
+	}
+
+	return &mailer.Message{
+		To:      []string{invitation.Email},
+		Subject: fmt.Sprintf("Invitation to join the namespace %s on ShellHub", namespace.Name),
+		Body: fmt.Sprintf("You were invited to join the namespace %s on ShellHub as %s.\n\n"+
+			"Sign in, or sign up with this email, and accept the invitation at:\n\n%s\n\n"+
+			"The invitation expires on %s.\n",
+			namespace.Name, invitation.Role, link, invitation.ExpiresAt.Format(time.RFC1123)),
+	}
+}
+
+func (s *service) DeleteInvitation(ctx context.Context, id, tenant string) error {
+	if err := s.store.InvitationDelete(ctx, tenant, id); err != nil {
+		return NewErrInvitationNotFound(id, err)
+	}
+
+	s.audit(ctx, tenant, models.AuditInvitationRemove, id, nil, nil)
+
+	return nil
+}
+
+// AcceptInvitation adds the user to the namespace of an invitation sent to its email, with the invitation's role.
+func (s *service) AcceptInvitation(ctx context.Context, userID, token string) (*models.Namespace, error) {
+	invitation, user, err := s.answerInvitation(ctx, userID, token, models.InvitationAccepted)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace, err := s.store.NamespaceAddMember(ctx, invitation.TenantID, user.ID, invitation.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	member := &models.Member{ID: user.ID, Username: user.Username, Role: invitation.Role}
+	s.dispatchWebhookEvent(ctx, invitation.TenantID, models.WebhookEventMemberAdded, member)
+	s.audit(ctx, invitation.TenantID, models.AuditMemberAdd, user.ID, nil, member)
+
+	return namespace, nil
+}
+
+// DeclineInvitation refuses an invitation sent to the user's email.
+func (s *service) DeclineInvitation(ctx context.Context, userID, token string) error {
+	_, _, err := s.answerInvitation(ctx, userID, token, models.InvitationDeclined)
+
+	return err
+}
+
+// answerInvitation sets the status of a pending invitation answered by the user it was sent to.
+//
+// It can return an error if the user is not found, NewErrUserNotFound, if the invitation is not found,
+// NewErrInvitationNotFound, if it was sent to another email, NewErrInvitationEmail, if it is not pending or is
+// expired, NewErrInvitationClosed, or if the user is already a member of the namespace,
+// NewErrNamespaceMemberDuplicated.
+func (s *service) answerInvitation(ctx context.Context, userID, token, status string) (*models.Invitation, *models.User, error) {
+	user, _, err := s.store.UserGetByID(ctx, userID, false)
+	if err != nil {
+		return nil, nil, NewErrUserNotFound(userID, err)
+	}
+
+	invitation, err := s.store.InvitationGetByToken(ctx, token)
+	if err != nil {
+		return nil, nil, NewErrInvitationNotFound(token, err)
+	}
+
+	if !strings.EqualFold(invitation.Email, user.Email) {
+		return nil, nil, NewErrInvitationEmail(user.Email, nil)
+	}
+
+	if invitation.Status != models.InvitationPending || !clock.Now().Before(invitation.ExpiresAt) {
+		return nil, nil, NewErrInvitationClosed(invitation.ID, nil)
+	}
+
+	if status == models.InvitationAccepted {
+		namespace, err := s.store.NamespaceGet(ctx, invitation.TenantID)
+		if err != nil {
+			return nil, nil, NewErrNamespaceNotFound(invitation.TenantID, err)
+		}
+
+		if _, ok := guard.CheckMember(namespace, user.ID); ok {
+			return nil, nil, NewErrNamespaceMemberDuplicated(user.ID, nil)
+		}
+	}
+
+	// The status is only set while the invitation is pending, so it cannot be answered twice.
+	answered, err := s.store.InvitationAnswer(ctx, invitation.ID, status)
+	switch {
+	case err == store.ErrNoDocuments:
+		return nil, nil, NewErrInvitationClosed(invitation.ID, err)
+	case err != nil:
+		return nil, nil, err
+	}
+
+	return answered, user, nil
+}
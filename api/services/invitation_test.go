@@ -0,0 +1,303 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	storecache "github.com/shellhub-io/shellhub/api/cache"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/pkg/mailer"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	mocklib "github.com/stretchr/testify/mock"
+)
+
+// fakeMailer keeps the sent messages and the deadlines of their contexts, failing with err when it is set.
+type fakeMailer struct {
+	messages  []*mailer.Message
+	deadlines []time.Time
+	err       error
+}
+
+func (m *fakeMailer) Send(ctx context.Context, message *mailer.Message) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	deadline, _ := ctx.Deadline()
+
+	m.messages = append(m.messages, message)
+	m.deadlines = append(m.deadlines, deadline)
+
+	return nil
+}
+
+func TestCreateInvitation(t *testing.T) {
+	mock := &mocks.Store{}
+	fake := &fakeMailer{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil,
+		WithMailer(fake, "http://localhost/invitation"))
+
+	ctx := context.TODO()
+
+	namespace := &models.Namespace{
+		Name:     "namespace",
+		TenantID: "tenant",
+		Owner:    "owner",
+		Members: []models.Member{
+			{ID: "owner", Role: guard.RoleOwner},
+			{ID: "admin", Role: guard.RoleAdministrator},
+			{ID: "member", Role: guard.RoleObserver},
+		},
+	}
+
+	Err := errors.New("error", "", 0)
+
+	cases := []struct {
+		description   string
+		userID        string
+		fields        *models.InvitationFields
+		mailerErr     error
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description:   "fails when the email is invalid",
+			userID:        "admin",
+			fields:        &models.InvitationFields{Email: "john", Role: guard.RoleObserver},
+			requiredMocks: func() {},
+			expected:      NewErrInvitationInvalid(map[string]interface{}{"Email": "john"}, nil),
+		},
+		{
+			description: "fails when the namespace is not found",
+			userID:      "admin",
+			fields:      &models.InvitationFields{Email: "john@example.com", Role: guard.RoleObserver},
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(nil, Err).Once()
+			},
+			expected: NewErrNamespaceNotFound("tenant", Err),
+		},
+		{
+			description: "fails when the user cannot assign the role",
+			userID:      "admin",
+			fields:      &models.InvitationFields{Email: "john@example.com", Role: guard.RoleAdministrator},
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+			},
+			expected: guard.ErrForbidden,
+		},
+		{
+			description: "fails when the email is already a member",
+			userID:      "admin",
+			fields:      &models.InvitationFields{Email: "Member@Example.com", Role: guard.RoleObserver},
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				mock.On("UserGetByEmail", ctx, "member@example.com").Return(&models.User{ID: "member"}, nil).Once()
+			},
+			expected: NewErrNamespaceMemberDuplicated("member", nil),
+		},
+		{
+			description: "fails when the email has a pending invitation",
+			userID:      "admin",
+			fields:      &models.InvitationFields{Email: "john@example.com", Role: guard.RoleObserver},
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				mock.On("UserGetByEmail", ctx, "john@example.com").Return(nil, store.ErrNoDocuments).Once()
+				mock.On("InvitationGetPending", ctx, "tenant", "john@example.com").
+					Return(&models.Invitation{ExpiresAt: now.Add(InvitationExpiration)}, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+			},
+			expected: NewErrInvitationDuplicated("john@example.com", nil),
+		},
+		{
+			description: "fails and removes the invitation when the email is not sent",
+			userID:      "admin",
+			fields:      &models.InvitationFields{Email: "john@example.com", Role: guard.RoleObserver},
+			mailerErr:   Err,
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				mock.On("UserGetByEmail", ctx, "john@example.com").Return(nil, store.ErrNoDocuments).Once()
+				mock.On("InvitationGetPending", ctx, "tenant", "john@example.com").Return(nil, store.ErrNoDocuments).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("InvitationCreate", ctx, mocklib.AnythingOfType("*models.Invitation")).Return(nil).Once()
+				mock.On("InvitationDelete", ctx, "tenant", "").Return(nil).Once()
+			},
+			expected: Err,
+		},
+		{
+			description: "succeeds when the previous invitation is expired",
+			userID:      "admin",
+			fields:      &models.InvitationFields{Email: "john@example.com", Role: guard.RoleObserver},
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				mock.On("UserGetByEmail", ctx, "john@example.com").Return(nil, store.ErrNoDocuments).Once()
+				mock.On("InvitationGetPending", ctx, "tenant", "john@example.com").
+					Return(&models.Invitation{ExpiresAt: now}, nil).Once()
+				clockMock.On("Now").Return(now).Twice()
+				mock.On("InvitationCreate", ctx, mocklib.AnythingOfType("*models.Invitation")).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			fake.messages = nil
+			fake.deadlines = nil
+			fake.err = tc.mailerErr
+
+			invitation, err := s.CreateInvitation(ctx, "tenant", tc.userID, tc.fields)
+			assert.Equal(t, tc.expected, err)
+
+			if err == nil {
+				assert.Equal(t, models.InvitationPending, invitation.Status)
+				assert.Equal(t, now.Add(InvitationExpiration), invitation.ExpiresAt)
+				assert.Len(t, invitation.Token, 64)
+
+				assert.Len(t, fake.messages, 1)
+				assert.Equal(t, []string{"john@example.com"}, fake.messages[0].To)
+				assert.Contains(t, fake.messages[0].Body, "http://localhost/invitation?token="+invitation.Token)
+				assert.False(t, fake.deadlines[0].IsZero())
+			}
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestAcceptInvitation(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	user := &models.User{ID: "user", UserData: models.UserData{Username: "john", Email: "john@example.com"}}
+
+	invitation := &models.Invitation{
+		ID:               "id",
+		TenantID:         "tenant",
+		Status:           models.InvitationPending,
+		ExpiresAt:        now.Add(InvitationExpiration),
+		InvitationFields: models.InvitationFields{Email: "John@Example.com", Role: guard.RoleOperator},
+	}
+
+	namespace := &models.Namespace{
+		TenantID: "tenant",
+		Members:  []models.Member{{ID: "owner", Role: guard.RoleOwner}},
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the invitation is not found",
+			requiredMocks: func() {
+				mock.On("UserGetByID", ctx, "user", false).Return(user, 0, nil).Once()
+				mock.On("InvitationGetByToken", ctx, "token").Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: NewErrInvitationNotFound("token", store.ErrNoDocuments),
+		},
+		{
+			description: "fails when the invitation was sent to another email",
+			requiredMocks: func() {
+				other := *invitation
+				other.Email = "jane@example.com"
+
+				mock.On("UserGetByID", ctx, "user", false).Return(user, 0, nil).Once()
+				mock.On("InvitationGetByToken", ctx, "token").Return(&other, nil).Once()
+			},
+			expected: NewErrInvitationEmail("john@example.com", nil),
+		},
+		{
+			description: "fails when the invitation was declined",
+			requiredMocks: func() {
+				declined := *invitation
+				declined.Status = models.InvitationDeclined
+
+				mock.On("UserGetByID", ctx, "user", false).Return(user, 0, nil).Once()
+				mock.On("InvitationGetByToken", ctx, "token").Return(&declined, nil).Once()
+			},
+			expected: NewErrInvitationClosed("id", nil),
+		},
+		{
+			description: "fails when the invitation is expired",
+			requiredMocks: func() {
+				expired := *invitation
+				expired.ExpiresAt = now
+
+				mock.On("UserGetByID", ctx, "user", false).Return(user, 0, nil).Once()
+				mock.On("InvitationGetByToken", ctx, "token").Return(&expired, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+			},
+			expected: NewErrInvitationClosed("id", nil),
+		},
+		{
+			description: "fails when the invitation is answered concurrently",
+			requiredMocks: func() {
+				mock.On("UserGetByID", ctx, "user", false).Return(user, 0, nil).Once()
+				mock.On("InvitationGetByToken", ctx, "token").Return(invitation, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				mock.On("InvitationAnswer", ctx, "id", models.InvitationAccepted).Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: NewErrInvitationClosed("id", store.ErrNoDocuments),
+		},
+		{
+			description: "succeeds",
+			requiredMocks: func() {
+				mock.On("UserGetByID", ctx, "user", false).Return(user, 0, nil).Once()
+				mock.On("InvitationGetByToken", ctx, "token").Return(invitation, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				mock.On("InvitationAnswer", ctx, "id", models.InvitationAccepted).Return(invitation, nil).Once()
+				mock.On("NamespaceAddMember", ctx, "tenant", "user", guard.RoleOperator).Return(namespace, nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			_, err := s.AcceptInvitation(ctx, "user", "token")
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestDeclineInvitation(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	user := &models.User{ID: "user", UserData: models.UserData{Email: "john@example.com"}}
+
+	invitation := &models.Invitation{
+		ID:               "id",
+		TenantID:         "tenant",
+		Status:           models.InvitationPending,
+		ExpiresAt:        now.Add(InvitationExpiration),
+		InvitationFields: models.InvitationFields{Email: "john@example.com", Role: guard.RoleOperator},
+	}
+
+	mock.On("UserGetByID", ctx, "user", false).Return(user, 0, nil).Once()
+	mock.On("InvitationGetByToken", ctx, "token").Return(invitation, nil).Once()
+	clockMock.On("Now").Return(now).Once()
+	mock.On("InvitationAnswer", ctx, "id", models.InvitationDeclined).Return(invitation, nil).Once()
+
+	assert.NoError(t, s.DeclineInvitation(ctx, "user", "token"))
+
+	mock.AssertExpectations(t)
+}
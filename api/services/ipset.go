@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/validator"
+)
+
+type IPSetService interface {
+	ListIPSets(ctx context.Context, pagination paginator.Query) ([]models.IPSet, int, error)
+	GetIPSet(ctx context.Context, id, tenant string) (*models.IPSet, error)
+	CreateIPSet(ctx context.Context, set *models.IPSet, tenant string) error
+	UpdateIPSet(ctx context.Context, id, tenant string, set *models.IPSetUpdate) (*models.IPSet, error)
+	DeleteIPSet(ctx context.Context, id, tenant string) error
+}
+
+func (s *service) ListIPSets(ctx context.Context, pagination paginator.Query) ([]models.IPSet, int, error) {
+	return s.store.IPSetList(ctx, pagination)
+}
+
+func (s *service) GetIPSet(ctx context.Context, id, tenant string) (*models.IPSet, error) {
+	set, err := s.store.IPSetGet(ctx, tenant, id)
+	if err != nil {
+		return nil, NewErrIPSetNotFound(id, err)
+	}
+
+	return set, nil
+}
+
+func (s *service) CreateIPSet(ctx context.Context, set *models.IPSet, tenant string) error {
+	if err := set.Validate(); err != nil {
+		data, _ := validator.GetInvalidFieldsValues(err)
+
+		return NewErrIPSetInvalid(data, nil)
+	}
+
+	set.TenantID = tenant
+	set.CreatedAt = clock.Now()
+
+	if err := s.store.IPSetCreate(ctx, set); err != nil {
+		if err == store.ErrDuplicate {
+			return NewErrIPSetDuplicated(set.Name, err)
+		}
+
+		return err
+	}
+
+	s.audit(ctx, tenant, models.AuditIPSetCreate, set.ID, nil, set)
+
+	return nil
+}
+
+// UpdateIPSet updates an IP set of a namespace.
+//
+// As the firewall rules reference an IP set by its name, renaming it makes these rules stop matching its addresses.
+func (s *service) UpdateIPSet(ctx context.Context, id, tenant string, set *models.IPSetUpdate) (*models.IPSet, error) {
+	if err := set.Validate(); err != nil {
+		data, _ := validator.GetInvalidFieldsValues(err)
+
+		return nil, NewErrIPSetInvalid(data, nil)
+	}
+
+	before := s.auditGet(func() (interface{}, error) {
+		return s.store.IPSetGet(ctx, tenant, id)
+	})
+
+	updated, err := s.store.IPSetUpdate(ctx, tenant, id, set)
+	switch err {
+	case nil:
+		s.audit(ctx, tenant, models.AuditIPSetUpdate, id, before, updated)
+
+		return updated, nil
+	case store.ErrDuplicate:
+		return nil, NewErrIPSetDuplicated(set.Name, err)
+	case store.ErrNoDocuments, store.ErrInvalidHex:
+		return nil, NewErrIPSetNotFound(id, err)
+	default:
+		return nil, err
+	}
+}
+
+func (s *service) DeleteIPSet(ctx context.Context, id, tenant string) error {
+	before := s.auditGet(func() (interface{}, error) {
+		return s.store.IPSetGet(ctx, tenant, id)
+	})
+
+	if err := s.store.IPSetDelete(ctx, tenant, id); err != nil {
+		return NewErrIPSetNotFound(id, err)
+	}
+
+	s.audit(ctx, tenant, models.AuditIPSetRemove, id, before, nil)
+
+	return nil
+}
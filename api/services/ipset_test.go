@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	storecache "github.com/shellhub-io/shellhub/api/cache"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateIPSet(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		description   string
+		set           *models.IPSet
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description:   "fails when a prefix is invalid",
+			set:           &models.IPSet{IPSetFields: models.IPSetFields{Name: "office", Prefixes: []string{"10.0.0.0/8", "invalid"}}},
+			requiredMocks: func() {},
+			expected:      NewErrIPSetInvalid(map[string]interface{}{"Prefixes[1]": "invalid"}, nil),
+		},
+		{
+			description: "fails when the name is duplicated",
+			set:         &models.IPSet{IPSetFields: models.IPSetFields{Name: "office", Prefixes: []string{"10.0.0.0/8"}}},
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				mock.On("IPSetCreate", ctx, &models.IPSet{
+					TenantID:    "tenant",
+					CreatedAt:   now,
+					IPSetFields: models.IPSetFields{Name: "office", Prefixes: []string{"10.0.0.0/8"}},
+				}).Return(store.ErrDuplicate).Once()
+			},
+			expected: NewErrIPSetDuplicated("office", store.ErrDuplicate),
+		},
+		{
+			description: "succeeds",
+			set:         &models.IPSet{IPSetFields: models.IPSetFields{Name: "office", Prefixes: []string{"10.0.0.0/8", "2001:db8::/32", "192.168.0.1"}}},
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				mock.On("IPSetCreate", ctx, &models.IPSet{
+					TenantID:    "tenant",
+					CreatedAt:   now,
+					IPSetFields: models.IPSetFields{Name: "office", Prefixes: []string{"10.0.0.0/8", "2001:db8::/32", "192.168.0.1"}},
+				}).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			err := s.CreateIPSet(ctx, tc.set, "tenant")
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestUpdateIPSet(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	update := &models.IPSetUpdate{IPSetFields: models.IPSetFields{Name: "office", Prefixes: []string{"10.0.0.0/8"}}}
+	set := &models.IPSet{ID: "id", TenantID: "tenant", IPSetFields: update.IPSetFields}
+
+	mock.On("IPSetUpdate", ctx, "tenant", "id", update).Return(nil, store.ErrNoDocuments).Once()
+	_, err := s.UpdateIPSet(ctx, "id", "tenant", update)
+	assert.Equal(t, NewErrIPSetNotFound("id", store.ErrNoDocuments), err)
+
+	mock.On("IPSetUpdate", ctx, "tenant", "id", update).Return(set, nil).Once()
+	updated, err := s.UpdateIPSet(ctx, "id", "tenant", update)
+	assert.NoError(t, err)
+	assert.Equal(t, set, updated)
+
+	mock.AssertExpectations(t)
+}
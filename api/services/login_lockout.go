@@ -0,0 +1,236 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// LoginLockoutUsernameKey and LoginLockoutIPAddressKey are the prefixes of the cache keys of the failed logins of
+	// a username and of a source IP address.
+	LoginLockoutUsernameKey  = "login_lockout/username/"
+	LoginLockoutIPAddressKey = "login_lockout/ip_address/"
+	// LoginLockoutFailuresKey is the suffix of the cache key of the failed logins counter of a username or of a
+	// source IP address.
+	LoginLockoutFailuresKey = "/failures"
+)
+
+// LoginLockout configures the lockout of the user logins after consecutive failures.
+//
+// The failed logins are stored in the cache, so they are shared by the API replicas, and are not counted when the cache
+// is disabled.
+type LoginLockout struct {
+	// UsernameFailures is the number of failed logins of a username before it is locked out. Zero disables it.
+	UsernameFailures int
+	// IPAddressFailures is the number of failed logins from a source IP address before it is locked out. Zero
+	// disables it.
+	IPAddressFailures int
+	// Duration is how long a lockout lasts and how long the failed logins are counted after the last one.
+	Duration time.Duration
+}
+
+// DefaultLoginLockout is the LoginLockout used when the service is not configured with WithLoginLockout.
+var DefaultLoginLockout = LoginLockout{
+	UsernameFailures:  5,
+	IPAddressFailures: 20,
+	Duration:          15 * time.Minute,
+}
+
+// LockoutNotifier notifies that the logins of a username or of a source IP address were locked out. The username is
+// empty when the source IP address was locked out.
+type LockoutNotifier func(ctx context.Context, username, ip string, lockout *models.LoginLockout)
+
+// WithLoginLockout configures the lockout of the user logins.
+func WithLoginLockout(lockout LoginLockout) Option {
+	return func(s *service) {
+		s.lockout = lockout
+	}
+}
+
+// WithLockoutNotifier configures the function called when a username or a source IP address is locked out.
+func WithLockoutNotifier(notifier LockoutNotifier) Option {
+	return func(s *service) {
+		s.notifyLockout = notifier
+	}
+}
+
+func logLockout(_ context.Context, username, ip string, lockout *models.LoginLockout) {
+	logrus.WithFields(logrus.Fields{
+		"username":     username,
+		"ip_address":   ip,
+		"locked_until": lockout.LockedUntil,
+	}).Warn("Logins locked out after too many failures")
+}
+
+type LoginLockoutService interface {
+	GetLoginLockouts(ctx context.Context, username, ip string) (*models.LoginLockouts, error)
+	UnlockLogin(ctx context.Context, username, ip string) error
+}
+
+// GetLoginLockouts gets the state of the failed logins of a username and of a source IP address. An empty username or
+// IP address is not returned.
+func (s *service) GetLoginLockouts(ctx context.Context, username, ip string) (*models.LoginLockouts, error) {
+	lockouts := new(models.LoginLockouts)
+
+	if username != "" {
+		user, err := s.store.UserGetByUsername(ctx, strings.ToLower(username))
+		if err != nil {
+			return nil, NewErrUserNotFound(username, err)
+		}
+
+		if lockouts.Username, err = s.getLoginLockout(ctx, LoginLockoutUsernameKey+user.Username); err != nil {
+			return nil, err
+		}
+	}
+
+	if ip != "" {
+		lockout, err := s.getLoginLockout(ctx, LoginLockoutIPAddressKey+ip)
+		if err != nil {
+			return nil, err
+		}
+
+		lockouts.IPAddress = lockout
+	}
+
+	return lockouts, nil
+}
+
+// UnlockLogin clears the failed logins and the lockout of a username and of a source IP address. An empty username or
+// IP address is not changed.
+func (s *service) UnlockLogin(ctx context.Context, username, ip string) error {
+	if username != "" {
+		user, err := s.store.UserGetByUsername(ctx, strings.ToLower(username))
+		if err != nil {
+			return NewErrUserNotFound(username, err)
+		}
+
+		if err := s.deleteLoginLockout(ctx, LoginLockoutUsernameKey+user.Username); err != nil {
+			return err
+		}
+	}
+
+	if ip != "" {
+		if err := s.deleteLoginLockout(ctx, LoginLockoutIPAddressKey+ip); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkLoginLockout returns NewErrAuthLocked when the logins of a username or of a source IP address are locked out.
+// An empty username or IP address is not checked.
+//
+// As the failed logins are stored in the cache, the logins are never locked out when the cache is disabled.
+func (s *service) checkLoginLockout(ctx context.Context, username, ip string) error {
+	now := clock.Now()
+
+	keys := make([]string, 0, 2)
+	if ip != "" {
+		keys = append(keys, LoginLockoutIPAddressKey+ip)
+	}
+
+	if username != "" {
+		keys = append(keys, LoginLockoutUsernameKey+username)
+	}
+
+	for _, key := range keys {
+		lockout := new(models.LoginLockout)
+		if err := s.cache.Get(ctx, key, lockout); err != nil {
+			return err
+		}
+
+		if lockout.Locked(now) {
+			return NewErrAuthLocked(lockout.LockedUntil, nil)
+		}
+	}
+
+	return nil
+}
+
+// recordLoginFailure counts a failed login of a username and of a source IP address, locking them out when their
+// failures reach the limit. An empty username or IP address is not counted.
+func (s *service) recordLoginFailure(ctx context.Context, username, ip string) {
+	now := clock.Now()
+
+	record := func(key string, max int) *models.LoginLockout {
+		if max <= 0 {
+			return nil
+		}
+
+		failures, err := s.cache.Incr(ctx, key+LoginLockoutFailuresKey, s.lockout.Duration)
+		if err != nil {
+			logrus.WithError(err).WithField("key", key).Error("Failed to count the failed login")
+
+			return nil
+		}
+
+		// The counter is only zero when the cache is disabled. As it is incremented atomically, only the failure
+		// reaching the limit locks out, even when failed logins are concurrent.
+		if failures != int64(max) {
+			return nil
+		}
+
+		lockout := &models.LoginLockout{LockedUntil: now.Add(s.lockout.Duration)}
+		if err := s.cache.Set(ctx, key, lockout, s.lockout.Duration); err != nil {
+			logrus.WithError(err).WithField("key", key).Error("Failed to lock out the logins")
+
+			return nil
+		}
+
+		if err := s.cache.Delete(ctx, key+LoginLockoutFailuresKey); err != nil {
+			logrus.WithError(err).WithField("key", key).Error("Failed to clear the failed logins")
+		}
+
+		return lockout
+	}
+
+	if ip != "" {
+		if lockout := record(LoginLockoutIPAddressKey+ip, s.lockout.IPAddressFailures); lockout != nil {
+			s.notifyLockout(ctx, "", ip, lockout)
+		}
+	}
+
+	if username != "" {
+		if lockout := record(LoginLockoutUsernameKey+username, s.lockout.UsernameFailures); lockout != nil {
+			s.notifyLockout(ctx, username, ip, lockout)
+		}
+	}
+}
+
+// clearLoginFailures clears the failed logins of a username after a successful login.
+func (s *service) clearLoginFailures(ctx context.Context, username string) {
+	if err := s.cache.Delete(ctx, LoginLockoutUsernameKey+username+LoginLockoutFailuresKey); err != nil {
+		logrus.WithError(err).WithField("username", username).Error("Failed to clear the failed logins")
+	}
+}
+
+func (s *service) getLoginLockout(ctx context.Context, key string) (*models.LoginLockout, error) {
+	lockout := new(models.LoginLockout)
+	if err := s.cache.Get(ctx, key, lockout); err != nil {
+		return nil, err
+	}
+
+	failures, err := s.getCacheCounter(ctx, key+LoginLockoutFailuresKey)
+	if err != nil {
+		return nil, err
+	}
+
+	lockout.Failures = failures
+
+	return lockout, nil
+}
+
+// deleteLoginLockout clears the failed logins and the lockout of a username or of a source IP address.
+func (s *service) deleteLoginLockout(ctx context.Context, key string) error {
+	if err := s.cache.Delete(ctx, key+LoginLockoutFailuresKey); err != nil {
+		return err
+	}
+
+	return s.cache.Delete(ctx, key)
+}
@@ -0,0 +1,288 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	cachemocks "github.com/shellhub-io/shellhub/api/cache/mocks"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	mocklib "github.com/stretchr/testify/mock"
+)
+
+// cachedLoginLockout returns a function to be returned by the Get of the cache mock that loads a login lockout.
+func cachedLoginLockout(lockout models.LoginLockout) func(context.Context, string, interface{}) error {
+	return func(_ context.Context, _ string, value interface{}) error {
+		*value.(*models.LoginLockout) = lockout
+
+		return nil
+	}
+}
+
+func TestAuthUserLockout(t *testing.T) {
+	mock := &mocks.Store{}
+	cache := &cachemocks.Cache{}
+
+	type Notification struct {
+		username string
+		ip       string
+		lockout  models.LoginLockout
+	}
+
+	var notifications []Notification
+	s := NewService(store.Store(mock), privateKey, publicKey, cache, clientMock, nil,
+		WithLoginLockout(LoginLockout{UsernameFailures: 3, IPAddressFailures: 10, Duration: time.Minute}),
+		WithLockoutNotifier(func(_ context.Context, username, ip string, lockout *models.LoginLockout) {
+			notifications = append(notifications, Notification{username, ip, *lockout})
+		}),
+	)
+
+	ctx := context.TODO()
+
+	password := sha256.Sum256([]byte("password"))
+	user := &models.User{
+		ID:           "id",
+		UserData:     models.UserData{Username: "user"},
+		UserPassword: models.UserPassword{Password: hex.EncodeToString(password[:])},
+		Confirmed:    true,
+	}
+	Err := errors.New("error", "", 0)
+
+	type Expected struct {
+		notifications []Notification
+		err           error
+	}
+
+	cases := []struct {
+		description   string
+		password      string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the source IP address is locked out",
+			password:    "password",
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				cache.On("Get", ctx, "login_lockout/ip_address/10.0.0.1", &models.LoginLockout{}).
+					Return(cachedLoginLockout(models.LoginLockout{LockedUntil: now.Add(time.Minute)})).Once()
+			},
+			expected: Expected{nil, NewErrAuthLocked(now.Add(time.Minute), nil)},
+		},
+		{
+			description: "fails when the username is locked out",
+			password:    "password",
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Twice()
+				cache.On("Get", ctx, "login_lockout/ip_address/10.0.0.1", &models.LoginLockout{}).Return(nil).Once()
+				mock.On("UserGetByUsername", ctx, "user").Return(user, nil).Once()
+				cache.On("Get", ctx, "login_lockout/username/user", &models.LoginLockout{}).
+					Return(cachedLoginLockout(models.LoginLockout{LockedUntil: now.Add(time.Second)})).Once()
+			},
+			expected: Expected{nil, NewErrAuthLocked(now.Add(time.Second), nil)},
+		},
+		{
+			description: "counts the failed login of an unknown user by source IP address",
+			password:    "password",
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Twice()
+				cache.On("Get", ctx, "login_lockout/ip_address/10.0.0.1", &models.LoginLockout{}).Return(nil).Once()
+				mock.On("UserGetByUsername", ctx, "user").Return(nil, Err).Once()
+				mock.On("UserGetByEmail", ctx, "user").Return(nil, Err).Once()
+				cache.On("Incr", ctx, "login_lockout/ip_address/10.0.0.1/failures", time.Minute).Return(int64(5), nil).Once()
+			},
+			expected: Expected{nil, NewErrUserNotFound("user", Err)},
+		},
+		{
+			description: "locks out the username when its failed logins reach the limit",
+			password:    "wrong",
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Times(3)
+				cache.On("Get", ctx, "login_lockout/ip_address/10.0.0.1", &models.LoginLockout{}).Return(nil).Once()
+				mock.On("UserGetByUsername", ctx, "user").Return(user, nil).Once()
+				cache.On("Get", ctx, "login_lockout/username/user", &models.LoginLockout{}).Return(nil).Once()
+				mock.On("NamespaceGetFirst", ctx, "id").Return(nil, Err).Once()
+				cache.On("Incr", ctx, "login_lockout/ip_address/10.0.0.1/failures", time.Minute).Return(int64(6), nil).Once()
+				cache.On("Incr", ctx, "login_lockout/username/user/failures", time.Minute).Return(int64(3), nil).Once()
+				cache.On("Set", ctx, "login_lockout/username/user", &models.LoginLockout{LockedUntil: now.Add(time.Minute)}, time.Minute).
+					Return(nil).Once()
+				cache.On("Delete", ctx, "login_lockout/username/user/failures").Return(nil).Once()
+			},
+			expected: Expected{
+				[]Notification{{"user", "10.0.0.1", models.LoginLockout{LockedUntil: now.Add(time.Minute)}}},
+				NewErrAuthUnathorized(nil),
+			},
+		},
+		{
+			description: "does not lock out the username again on the failed logins past the limit",
+			password:    "wrong",
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Times(3)
+				cache.On("Get", ctx, "login_lockout/ip_address/10.0.0.1", &models.LoginLockout{}).Return(nil).Once()
+				mock.On("UserGetByUsername", ctx, "user").Return(user, nil).Once()
+				cache.On("Get", ctx, "login_lockout/username/user", &models.LoginLockout{}).Return(nil).Once()
+				mock.On("NamespaceGetFirst", ctx, "id").Return(nil, Err).Once()
+				cache.On("Incr", ctx, "login_lockout/ip_address/10.0.0.1/failures", time.Minute).Return(int64(7), nil).Once()
+				cache.On("Incr", ctx, "login_lockout/username/user/failures", time.Minute).Return(int64(4), nil).Once()
+			},
+			expected: Expected{nil, NewErrAuthUnathorized(nil)},
+		},
+		{
+			description: "clears the failed logins of the username on a successful login",
+			password:    "password",
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Times(4)
+				cache.On("Get", ctx, "login_lockout/ip_address/10.0.0.1", &models.LoginLockout{}).Return(nil).Once()
+				mock.On("UserGetByUsername", ctx, "user").Return(user, nil).Once()
+				cache.On("Get", ctx, "login_lockout/username/user", &models.LoginLockout{}).Return(nil).Once()
+				mock.On("NamespaceGetFirst", ctx, "id").Return(nil, Err).Once()
+				updated := *user
+				updated.LastLogin = now
+				mock.On("UserUpdateData", ctx, "id", updated).Return(nil).Once()
+				cache.On("Delete", ctx, "login_lockout/username/user/failures").Return(nil).Once()
+			},
+			expected: Expected{nil, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			notifications = nil
+			tc.requiredMocks()
+
+			_, err := s.AuthUser(ctx, models.UserAuthRequest{Username: "user", Password: tc.password}, "10.0.0.1")
+			assert.Equal(t, tc.expected, Expected{notifications, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+	cache.AssertExpectations(t)
+}
+
+func TestGetLoginLockouts(t *testing.T) {
+	mock := &mocks.Store{}
+	cache := &cachemocks.Cache{}
+	s := NewService(store.Store(mock), privateKey, publicKey, cache, clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	type Expected struct {
+		lockouts *models.LoginLockouts
+		err      error
+	}
+
+	cases := []struct {
+		description   string
+		username      string
+		ip            string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the user is not found",
+			username:    "user",
+			requiredMocks: func() {
+				mock.On("UserGetByUsername", ctx, "user").Return(nil, Err).Once()
+			},
+			expected: Expected{nil, NewErrUserNotFound("user", Err)},
+		},
+		{
+			description: "succeeds",
+			username:    "User",
+			ip:          "10.0.0.1",
+			requiredMocks: func() {
+				mock.On("UserGetByUsername", ctx, "user").Return(&models.User{UserData: models.UserData{Username: "user"}}, nil).Once()
+				cache.On("Get", ctx, "login_lockout/username/user", &models.LoginLockout{}).
+					Return(cachedLoginLockout(models.LoginLockout{LockedUntil: now})).Once()
+				cache.On("Get", ctx, "login_lockout/username/user/failures", mocklib.Anything).
+					Return(cachedSSHLimitCounter("2")).Once()
+				cache.On("Get", ctx, "login_lockout/ip_address/10.0.0.1", &models.LoginLockout{}).Return(nil).Once()
+				cache.On("Get", ctx, "login_lockout/ip_address/10.0.0.1/failures", mocklib.Anything).Return(nil).Once()
+			},
+			expected: Expected{
+				&models.LoginLockouts{Username: &models.LoginLockout{Failures: 2, LockedUntil: now}, IPAddress: &models.LoginLockout{}},
+				nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			lockouts, err := s.GetLoginLockouts(ctx, tc.username, tc.ip)
+			assert.Equal(t, tc.expected, Expected{lockouts, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+	cache.AssertExpectations(t)
+}
+
+func TestUnlockLogin(t *testing.T) {
+	mock := &mocks.Store{}
+	cache := &cachemocks.Cache{}
+	s := NewService(store.Store(mock), privateKey, publicKey, cache, clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	cases := []struct {
+		description   string
+		username      string
+		ip            string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the user is not found",
+			username:    "user",
+			requiredMocks: func() {
+				mock.On("UserGetByUsername", ctx, "user").Return(nil, Err).Once()
+			},
+			expected: NewErrUserNotFound("user", Err),
+		},
+		{
+			description: "fails when the cache fails",
+			ip:          "10.0.0.1",
+			requiredMocks: func() {
+				cache.On("Delete", ctx, "login_lockout/ip_address/10.0.0.1/failures").Return(Err).Once()
+			},
+			expected: Err,
+		},
+		{
+			description: "succeeds",
+			username:    "user",
+			ip:          "10.0.0.1",
+			requiredMocks: func() {
+				mock.On("UserGetByUsername", ctx, "user").Return(&models.User{UserData: models.UserData{Username: "user"}}, nil).Once()
+				cache.On("Delete", ctx, "login_lockout/username/user/failures").Return(nil).Once()
+				cache.On("Delete", ctx, "login_lockout/username/user").Return(nil).Once()
+				cache.On("Delete", ctx, "login_lockout/ip_address/10.0.0.1/failures").Return(nil).Once()
+				cache.On("Delete", ctx, "login_lockout/ip_address/10.0.0.1").Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			err := s.UnlockLogin(ctx, tc.username, tc.ip)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+	cache.AssertExpectations(t)
+}
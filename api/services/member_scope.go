@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/validator"
+)
+
+type MemberScopeService interface {
+	EditMemberScope(ctx context.Context, tenantID, userID, memberID string, scope *models.MemberScope) error
+	EvaluateMemberScope(ctx context.Context, tenantID, userID string, uid models.UID) error
+	EvaluateKeyScope(ctx context.Context, tenantID, fingerprint string, uid models.UID) error
+}
+
+// EditMemberScope restricts a member of a namespace to the devices matched by the scope. A nil scope removes the
+// restriction.
+//
+// It can return an error if the namespace is not found, NewErrNamespaceNotFound, if the user or the member are not in
+// the namespace, NewErrNamespaceMemberNotFound, if the user cannot act over the member, guard.ErrForbidden, if the scope
+// is invalid, NewErrMemberScopeInvalid, or if its device group is not found, NewErrDeviceGroupNotFound.
+func (s *service) EditMemberScope(ctx context.Context, tenantID, userID, memberID string, scope *models.MemberScope) error {
+	namespace, err := s.store.NamespaceGet(ctx, tenantID)
+	if err != nil {
+		return NewErrNamespaceNotFound(tenantID, err)
+	}
+
+	active, ok := guard.CheckMember(namespace, userID)
+	if !ok {
+		return NewErrNamespaceMemberNotFound(userID, nil)
+	}
+
+	passive, ok := guard.CheckMember(namespace, memberID)
+	if !ok {
+		return NewErrNamespaceMemberNotFound(memberID, nil)
+	}
+
+	if active.ID == passive.ID || !checkMemberRole(active.Role, passive.Role) {
+		return guard.ErrForbidden
+	}
+
+	if scope != nil {
+		if data, err := validator.ValidateStructFields(scope); err != nil {
+			return NewErrMemberScopeInvalid(data, nil)
+		}
+
+		if scope.Group != "" {
+			if _, err := s.store.DeviceGroupGet(ctx, tenantID, scope.Group); err != nil {
+				return NewErrDeviceGroupNotFound(scope.Group, err)
+			}
+		}
+	}
+
+	if err := s.store.NamespaceSetMemberScope(ctx, tenantID, memberID, scope); err != nil {
+		return NewErrNamespaceMemberNotFound(memberID, err)
+	}
+
+	s.audit(ctx, tenantID, models.AuditMemberScope, memberID,
+		map[string]*models.MemberScope{"scope": passive.Scope}, map[string]*models.MemberScope{"scope": scope})
+
+	return nil
+}
+
+// EvaluateMemberScope checks if a member of a namespace can reach a device.
+//
+// It returns NewErrDeviceNotFound when the device is out of the member's scope, so the member cannot tell it apart
+// from a device that does not exist.
+func (s *service) EvaluateMemberScope(ctx context.Context, tenantID, userID string, uid models.UID) error {
+	uids, err := s.memberDevices(ctx, tenantID, userID)
+	if err != nil || uids == nil {
+		return err
+	}
+
+	for _, allowed := range uids {
+		if allowed == uid {
+			return nil
+		}
+	}
+
+	return NewErrDeviceNotFound(uid, nil)
+}
+
+// EvaluateKeyScope checks if the member who added a public key to a namespace can reach a device, so the sessions
+// authenticated by the key are bound to that member's scope. A key not added by a member, or not found, is not
+// restricted.
+//
+// It returns NewErrDeviceNotFound when the device is out of the member's scope.
+func (s *service) EvaluateKeyScope(ctx context.Context, tenantID, fingerprint string, uid models.UID) error {
+	key, err := s.store.PublicKeyGet(ctx, fingerprint, tenantID)
+	switch {
+	case err == store.ErrNoDocuments:
+		return nil
+	case err != nil:
+		return err
+	}
+
+	return s.EvaluateMemberScope(ctx, tenantID, key.CreatedBy, uid)
+}
+
+// memberDevices gets the UIDs of the devices a member of a namespace is restricted to. It returns nil when the member
+// is not restricted, as when the request is not made by a member or the member has no scope.
+func (s *service) memberDevices(ctx context.Context, tenantID, userID string) ([]models.UID, error) {
+	if tenantID == "" || userID == "" {
+		return nil, nil
+	}
+
+	namespace, err := s.store.NamespaceGet(ctx, tenantID)
+	if err != nil {
+		return nil, NewErrNamespaceNotFound(tenantID, err)
+	}
+
+	member, ok := guard.CheckMember(namespace, userID)
+	if !ok || member.Scope == nil {
+		return nil, nil
+	}
+
+	var group *models.DeviceGroup
+	if member.Scope.Group != "" {
+		group, err = s.store.DeviceGroupGet(ctx, tenantID, member.Scope.Group)
+		switch {
+		case err == store.ErrNoDocuments:
+			// A member restricted to a deleted group reaches no device.
+			return []models.UID{}, nil
+		case err != nil:
+			return nil, err
+		}
+	} else {
+		// The devices with any of the tags are matched by a filter on each tag.
+		filter := make([]models.Filter, len(member.Scope.Tags))
+		for i, tag := range member.Scope.Tags {
+			filter[i] = models.Filter{
+				Type:   "property",
+				Params: &models.PropertyParams{Name: "tags", Operator: "eq", Value: tag},
+			}
+		}
+
+		group = &models.DeviceGroup{
+			TenantID:          tenantID,
+			DeviceGroupFields: models.DeviceGroupFields{Filter: filter},
+		}
+	}
+
+	return s.store.DeviceGroupMembers(ctx, group)
+}
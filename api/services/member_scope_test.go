@@ -0,0 +1,324 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	storecache "github.com/shellhub-io/shellhub/api/cache"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEditMemberScope(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	namespace := &models.Namespace{
+		TenantID: "tenant",
+		Owner:    "owner",
+		Members: []models.Member{
+			{ID: "owner", Role: guard.RoleOwner},
+			{ID: "admin", Role: guard.RoleAdministrator},
+			{ID: "observer", Role: guard.RoleObserver},
+		},
+	}
+
+	Err := errors.New("error", "", 0)
+
+	cases := []struct {
+		description   string
+		userID        string
+		memberID      string
+		scope         *models.MemberScope
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the namespace is not found",
+			userID:      "admin",
+			memberID:    "observer",
+			scope:       &models.MemberScope{Tags: []string{"project"}},
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(nil, Err).Once()
+			},
+			expected: NewErrNamespaceNotFound("tenant", Err),
+		},
+		{
+			description: "fails when the member is not in the namespace",
+			userID:      "admin",
+			memberID:    "unknown",
+			scope:       &models.MemberScope{Tags: []string{"project"}},
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+			},
+			expected: NewErrNamespaceMemberNotFound("unknown", nil),
+		},
+		{
+			description: "fails when the user cannot act over the member",
+			userID:      "admin",
+			memberID:    "owner",
+			scope:       &models.MemberScope{Tags: []string{"project"}},
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+			},
+			expected: guard.ErrForbidden,
+		},
+		{
+			description: "fails when the scope has both tags and a group",
+			userID:      "admin",
+			memberID:    "observer",
+			scope:       &models.MemberScope{Tags: []string{"project"}, Group: "group"},
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+			},
+			expected: NewErrMemberScopeInvalid(map[string]interface{}{"Tags": []string{"project"}, "Group": "group"}, nil),
+		},
+		{
+			description: "fails when the group is not found",
+			userID:      "admin",
+			memberID:    "observer",
+			scope:       &models.MemberScope{Group: "group"},
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				mock.On("DeviceGroupGet", ctx, "tenant", "group").Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: NewErrDeviceGroupNotFound("group", store.ErrNoDocuments),
+		},
+		{
+			description: "succeeds",
+			userID:      "admin",
+			memberID:    "observer",
+			scope:       &models.MemberScope{Tags: []string{"project"}},
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				mock.On("NamespaceSetMemberScope", ctx, "tenant", "observer", &models.MemberScope{Tags: []string{"project"}}).
+					Return(nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "succeeds removing the scope",
+			userID:      "owner",
+			memberID:    "admin",
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				mock.On("NamespaceSetMemberScope", ctx, "tenant", "admin", (*models.MemberScope)(nil)).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			err := s.EditMemberScope(ctx, "tenant", tc.userID, tc.memberID, tc.scope)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestEvaluateMemberScope(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	namespace := &models.Namespace{
+		TenantID: "tenant",
+		Members: []models.Member{
+			{ID: "owner", Role: guard.RoleOwner},
+			{ID: "tagged", Role: guard.RoleObserver, Scope: &models.MemberScope{Tags: []string{"project", "staging"}}},
+			{ID: "grouped", Role: guard.RoleOperator, Scope: &models.MemberScope{Group: "group"}},
+		},
+	}
+
+	tagged := &models.DeviceGroup{
+		TenantID: "tenant",
+		DeviceGroupFields: models.DeviceGroupFields{Filter: []models.Filter{
+			{Type: "property", Params: &models.PropertyParams{Name: "tags", Operator: "eq", Value: "project"}},
+			{Type: "property", Params: &models.PropertyParams{Name: "tags", Operator: "eq", Value: "staging"}},
+		}},
+	}
+
+	group := &models.DeviceGroup{
+		ID:                "group",
+		TenantID:          "tenant",
+		DeviceGroupFields: models.DeviceGroupFields{Name: "group", Devices: []string{"uid"}},
+	}
+
+	cases := []struct {
+		description   string
+		userID        string
+		uid           models.UID
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description:   "succeeds when the user is not known",
+			userID:        "",
+			uid:           "uid",
+			requiredMocks: func() {},
+			expected:      nil,
+		},
+		{
+			description: "succeeds when the member has no scope",
+			userID:      "owner",
+			uid:         "uid",
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "fails when the device has none of the tags",
+			userID:      "tagged",
+			uid:         "other",
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				mock.On("DeviceGroupMembers", ctx, tagged).Return([]models.UID{"uid"}, nil).Once()
+			},
+			expected: NewErrDeviceNotFound("other", nil),
+		},
+		{
+			description: "succeeds when the device has one of the tags",
+			userID:      "tagged",
+			uid:         "uid",
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				mock.On("DeviceGroupMembers", ctx, tagged).Return([]models.UID{"uid"}, nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "fails when the group was deleted",
+			userID:      "grouped",
+			uid:         "uid",
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				mock.On("DeviceGroupGet", ctx, "tenant", "group").Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: NewErrDeviceNotFound("uid", nil),
+		},
+		{
+			description: "succeeds when the device is in the group",
+			userID:      "grouped",
+			uid:         "uid",
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				mock.On("DeviceGroupGet", ctx, "tenant", "group").Return(group, nil).Once()
+				mock.On("DeviceGroupMembers", ctx, group).Return([]models.UID{"uid"}, nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			err := s.EvaluateMemberScope(ctx, "tenant", tc.userID, tc.uid)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestEvaluateKeyScope(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	namespace := &models.Namespace{
+		TenantID: "tenant",
+		Members: []models.Member{
+			{ID: "owner", Role: guard.RoleOwner},
+			{ID: "grouped", Role: guard.RoleOperator, Scope: &models.MemberScope{Group: "group"}},
+		},
+	}
+
+	group := &models.DeviceGroup{
+		ID:                "group",
+		TenantID:          "tenant",
+		DeviceGroupFields: models.DeviceGroupFields{Name: "group", Devices: []string{"uid"}},
+	}
+
+	cases := []struct {
+		description   string
+		uid           models.UID
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "succeeds when the key is not found",
+			uid:         "uid",
+			requiredMocks: func() {
+				mock.On("PublicKeyGet", ctx, "fingerprint", "tenant").Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "fails when the key cannot be got",
+			uid:         "uid",
+			requiredMocks: func() {
+				mock.On("PublicKeyGet", ctx, "fingerprint", "tenant").Return(nil, Err).Once()
+			},
+			expected: Err,
+		},
+		{
+			description: "succeeds when the key was not added by a member",
+			uid:         "other",
+			requiredMocks: func() {
+				mock.On("PublicKeyGet", ctx, "fingerprint", "tenant").Return(&models.PublicKey{TenantID: "tenant"}, nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "fails when the device is out of the scope of the member who added the key",
+			uid:         "other",
+			requiredMocks: func() {
+				mock.On("PublicKeyGet", ctx, "fingerprint", "tenant").
+					Return(&models.PublicKey{TenantID: "tenant", CreatedBy: "grouped"}, nil).Once()
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				mock.On("DeviceGroupGet", ctx, "tenant", "group").Return(group, nil).Once()
+				mock.On("DeviceGroupMembers", ctx, group).Return([]models.UID{"uid"}, nil).Once()
+			},
+			expected: NewErrDeviceNotFound("other", nil),
+		},
+		{
+			description: "succeeds when the device is in the scope of the member who added the key",
+			uid:         "uid",
+			requiredMocks: func() {
+				mock.On("PublicKeyGet", ctx, "fingerprint", "tenant").
+					Return(&models.PublicKey{TenantID: "tenant", CreatedBy: "grouped"}, nil).Once()
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				mock.On("DeviceGroupGet", ctx, "tenant", "group").Return(group, nil).Once()
+				mock.On("DeviceGroupMembers", ctx, group).Return([]models.UID{"uid"}, nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			err := s.EvaluateKeyScope(ctx, "tenant", "fingerprint", tc.uid)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
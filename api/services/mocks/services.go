@@ -13,6 +13,10 @@ import (
 	request "github.com/shellhub-io/shellhub/pkg/api/request"
 
 	rsa "crypto/rsa"
+
+	time "time"
+
+	guard "github.com/shellhub-io/shellhub/api/pkg/guard"
 )
 
 // Service is an autogenerated mock type for the Service type
@@ -20,6 +24,29 @@ type Service struct {
 	mock.Mock
 }
 
+// AcceptInvitation provides a mock function with given fields: ctx, userID, token
+func (_m *Service) AcceptInvitation(ctx context.Context, userID string, token string) (*models.Namespace, error) {
+	ret := _m.Called(ctx, userID, token)
+
+	var r0 *models.Namespace
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Namespace); ok {
+		r0 = rf(ctx, userID, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Namespace)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AddNamespaceUser provides a mock function with given fields: ctx, memberUsername, memberRole, tenantID, userID
 func (_m *Service) AddNamespaceUser(ctx context.Context, memberUsername string, memberRole string, tenantID string, userID string) (*models.Namespace, error) {
 	ret := _m.Called(ctx, memberUsername, memberRole, tenantID, userID)
@@ -57,6 +84,52 @@ func (_m *Service) AddPublicKeyTag(ctx context.Context, tenant string, fingerpri
 	return r0
 }
 
+// ApplyDeviceGroupOperation provides a mock function with given fields: ctx, id, tenant, operation, value
+func (_m *Service) ApplyDeviceGroupOperation(ctx context.Context, id string, tenant string, operation string, value string) (*models.DeviceGroupBulkResult, error) {
+	ret := _m.Called(ctx, id, tenant, operation, value)
+
+	var r0 *models.DeviceGroupBulkResult
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) *models.DeviceGroupBulkResult); ok {
+		r0 = rf(ctx, id, tenant, operation, value)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeviceGroupBulkResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = rf(ctx, id, tenant, operation, value)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ApplyPolicy provides a mock function with given fields: ctx, tenant, policy, dryRun
+func (_m *Service) ApplyPolicy(ctx context.Context, tenant string, policy *models.Policy, dryRun bool) (*models.PolicyDiff, error) {
+	ret := _m.Called(ctx, tenant, policy, dryRun)
+
+	var r0 *models.PolicyDiff
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.Policy, bool) *models.PolicyDiff); ok {
+		r0 = rf(ctx, tenant, policy, dryRun)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PolicyDiff)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, *models.Policy, bool) error); ok {
+		r1 = rf(ctx, tenant, policy, dryRun)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AuthDevice provides a mock function with given fields: ctx, req, remoteAddr
 func (_m *Service) AuthDevice(ctx context.Context, req *models.DeviceAuthRequest, remoteAddr string) (*models.DeviceAuthResponse, error) {
 	ret := _m.Called(ctx, req, remoteAddr)
@@ -149,13 +222,34 @@ func (_m *Service) AuthSwapToken(ctx context.Context, ID string, tenant string)
 	return r0, r1
 }
 
-// AuthUser provides a mock function with given fields: ctx, req
-func (_m *Service) AuthUser(ctx context.Context, req models.UserAuthRequest) (*models.UserAuthResponse, error) {
-	ret := _m.Called(ctx, req)
+// AuthTenant provides a mock function with given fields: ctx, id, tenant
+func (_m *Service) AuthTenant(ctx context.Context, id string, tenant string) (string, error) {
+	ret := _m.Called(ctx, id, tenant)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, id, tenant)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, tenant)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthUser provides a mock function with given fields: ctx, req, remoteAddr
+func (_m *Service) AuthUser(ctx context.Context, req models.UserAuthRequest, remoteAddr string) (*models.UserAuthResponse, error) {
+	ret := _m.Called(ctx, req, remoteAddr)
 
 	var r0 *models.UserAuthResponse
-	if rf, ok := ret.Get(0).(func(context.Context, models.UserAuthRequest) *models.UserAuthResponse); ok {
-		r0 = rf(ctx, req)
+	if rf, ok := ret.Get(0).(func(context.Context, models.UserAuthRequest, string) *models.UserAuthResponse); ok {
+		r0 = rf(ctx, req, remoteAddr)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.UserAuthResponse)
@@ -163,8 +257,8 @@ func (_m *Service) AuthUser(ctx context.Context, req models.UserAuthRequest) (*m
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, models.UserAuthRequest) error); ok {
-		r1 = rf(ctx, req)
+	if rf, ok := ret.Get(1).(func(context.Context, models.UserAuthRequest, string) error); ok {
+		r1 = rf(ctx, req, remoteAddr)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -195,6 +289,57 @@ func (_m *Service) AuthUserInfo(ctx context.Context, username string, tenant str
 	return r0, r1
 }
 
+// AuthUserToken provides a mock function with given fields: ctx, id
+func (_m *Service) AuthUserToken(ctx context.Context, id string) (*models.UserAuthResponse, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.UserAuthResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.UserAuthResponse); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserAuthResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CheckSSHAttempt provides a mock function with given fields: ctx, ip, device
+func (_m *Service) CheckSSHAttempt(ctx context.Context, ip string, device string) error {
+	ret := _m.Called(ctx, ip, device)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, ip, device)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateDeviceGroup provides a mock function with given fields: ctx, group, tenant
+func (_m *Service) CreateDeviceGroup(ctx context.Context, group *models.DeviceGroup, tenant string) error {
+	ret := _m.Called(ctx, group, tenant)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DeviceGroup, string) error); ok {
+		r0 = rf(ctx, group, tenant)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateDeviceTag provides a mock function with given fields: ctx, uid, name
 func (_m *Service) CreateDeviceTag(ctx context.Context, uid models.UID, name string) error {
 	ret := _m.Called(ctx, uid, name)
@@ -209,6 +354,66 @@ func (_m *Service) CreateDeviceTag(ctx context.Context, uid models.UID, name str
 	return r0
 }
 
+// CreateFirewallRule provides a mock function with given fields: ctx, tenant, fields
+func (_m *Service) CreateFirewallRule(ctx context.Context, tenant string, fields models.FirewallRuleFields) (*models.FirewallRule, error) {
+	ret := _m.Called(ctx, tenant, fields)
+
+	var r0 *models.FirewallRule
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.FirewallRuleFields) *models.FirewallRule); ok {
+		r0 = rf(ctx, tenant, fields)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.FirewallRule)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.FirewallRuleFields) error); ok {
+		r1 = rf(ctx, tenant, fields)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateIPSet provides a mock function with given fields: ctx, set, tenant
+func (_m *Service) CreateIPSet(ctx context.Context, set *models.IPSet, tenant string) error {
+	ret := _m.Called(ctx, set, tenant)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.IPSet, string) error); ok {
+		r0 = rf(ctx, set, tenant)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateInvitation provides a mock function with given fields: ctx, tenant, userID, invitation
+func (_m *Service) CreateInvitation(ctx context.Context, tenant string, userID string, invitation *models.InvitationFields) (*models.Invitation, error) {
+	ret := _m.Called(ctx, tenant, userID, invitation)
+
+	var r0 *models.Invitation
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.InvitationFields) *models.Invitation); ok {
+		r0 = rf(ctx, tenant, userID, invitation)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Invitation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *models.InvitationFields) error); ok {
+		r1 = rf(ctx, tenant, userID, invitation)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateNamespace provides a mock function with given fields: ctx, namespace, userID
 func (_m *Service) CreateNamespace(ctx context.Context, namespace *models.Namespace, userID string) (*models.Namespace, error) {
 	ret := _m.Called(ctx, namespace, userID)
@@ -269,6 +474,20 @@ func (_m *Service) CreatePublicKey(ctx context.Context, key *models.PublicKey, t
 	return r0
 }
 
+// CreateRole provides a mock function with given fields: ctx, role, tenant
+func (_m *Service) CreateRole(ctx context.Context, role *models.Role, tenant string) error {
+	ret := _m.Called(ctx, role, tenant)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Role, string) error); ok {
+		r0 = rf(ctx, role, tenant)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateSession provides a mock function with given fields: ctx, session
 func (_m *Service) CreateSession(ctx context.Context, session models.Session) (*models.Session, error) {
 	ret := _m.Called(ctx, session)
@@ -292,6 +511,34 @@ func (_m *Service) CreateSession(ctx context.Context, session models.Session) (*
 	return r0, r1
 }
 
+// CreateSessionApproval provides a mock function with given fields: ctx, approval
+func (_m *Service) CreateSessionApproval(ctx context.Context, approval *models.SessionApproval) error {
+	ret := _m.Called(ctx, approval)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.SessionApproval) error); ok {
+		r0 = rf(ctx, approval)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateWebhook provides a mock function with given fields: ctx, webhook, tenant
+func (_m *Service) CreateWebhook(ctx context.Context, webhook *models.Webhook, tenant string) error {
+	ret := _m.Called(ctx, webhook, tenant)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Webhook, string) error); ok {
+		r0 = rf(ctx, webhook, tenant)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeactivateSession provides a mock function with given fields: ctx, uid
 func (_m *Service) DeactivateSession(ctx context.Context, uid models.UID) error {
 	ret := _m.Called(ctx, uid)
@@ -306,6 +553,43 @@ func (_m *Service) DeactivateSession(ctx context.Context, uid models.UID) error
 	return r0
 }
 
+// DecideSessionApproval provides a mock function with given fields: ctx, uid, tenant, userID, approved
+func (_m *Service) DecideSessionApproval(ctx context.Context, uid models.UID, tenant string, userID string, approved bool) (*models.SessionApproval, error) {
+	ret := _m.Called(ctx, uid, tenant, userID, approved)
+
+	var r0 *models.SessionApproval
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string, string, bool) *models.SessionApproval); ok {
+		r0 = rf(ctx, uid, tenant, userID, approved)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SessionApproval)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.UID, string, string, bool) error); ok {
+		r1 = rf(ctx, uid, tenant, userID, approved)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeclineInvitation provides a mock function with given fields: ctx, userID, token
+func (_m *Service) DeclineInvitation(ctx context.Context, userID string, token string) error {
+	ret := _m.Called(ctx, userID, token)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteDevice provides a mock function with given fields: ctx, uid, tenant
 func (_m *Service) DeleteDevice(ctx context.Context, uid models.UID, tenant string) error {
 	ret := _m.Called(ctx, uid, tenant)
@@ -320,13 +604,13 @@ func (_m *Service) DeleteDevice(ctx context.Context, uid models.UID, tenant stri
 	return r0
 }
 
-// DeleteNamespace provides a mock function with given fields: ctx, tenantID
-func (_m *Service) DeleteNamespace(ctx context.Context, tenantID string) error {
-	ret := _m.Called(ctx, tenantID)
+// DeleteDeviceGroup provides a mock function with given fields: ctx, id, tenant
+func (_m *Service) DeleteDeviceGroup(ctx context.Context, id string, tenant string) error {
+	ret := _m.Called(ctx, id, tenant)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, tenantID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, id, tenant)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -334,13 +618,13 @@ func (_m *Service) DeleteNamespace(ctx context.Context, tenantID string) error {
 	return r0
 }
 
-// DeletePublicKey provides a mock function with given fields: ctx, fingerprint, tenant
-func (_m *Service) DeletePublicKey(ctx context.Context, fingerprint string, tenant string) error {
-	ret := _m.Called(ctx, fingerprint, tenant)
+// DeleteFirewallRule provides a mock function with given fields: ctx, id, tenant
+func (_m *Service) DeleteFirewallRule(ctx context.Context, id string, tenant string) error {
+	ret := _m.Called(ctx, id, tenant)
 
 	var r0 error
 	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(ctx, fingerprint, tenant)
+		r0 = rf(ctx, id, tenant)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -348,13 +632,13 @@ func (_m *Service) DeletePublicKey(ctx context.Context, fingerprint string, tena
 	return r0
 }
 
-// DeleteTag provides a mock function with given fields: ctx, tenant, tag
-func (_m *Service) DeleteTag(ctx context.Context, tenant string, tag string) error {
-	ret := _m.Called(ctx, tenant, tag)
+// DeleteIPSet provides a mock function with given fields: ctx, id, tenant
+func (_m *Service) DeleteIPSet(ctx context.Context, id string, tenant string) error {
+	ret := _m.Called(ctx, id, tenant)
 
 	var r0 error
 	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(ctx, tenant, tag)
+		r0 = rf(ctx, id, tenant)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -362,13 +646,13 @@ func (_m *Service) DeleteTag(ctx context.Context, tenant string, tag string) err
 	return r0
 }
 
-// DeviceHeartbeat provides a mock function with given fields: ctx, uid
-func (_m *Service) DeviceHeartbeat(ctx context.Context, uid models.UID) error {
-	ret := _m.Called(ctx, uid)
+// DeleteInvitation provides a mock function with given fields: ctx, id, tenant
+func (_m *Service) DeleteInvitation(ctx context.Context, id string, tenant string) error {
+	ret := _m.Called(ctx, id, tenant)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID) error); ok {
-		r0 = rf(ctx, uid)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, id, tenant)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -376,36 +660,27 @@ func (_m *Service) DeviceHeartbeat(ctx context.Context, uid models.UID) error {
 	return r0
 }
 
-// EditNamespace provides a mock function with given fields: ctx, tenantID, name
-func (_m *Service) EditNamespace(ctx context.Context, tenantID string, name string) (*models.Namespace, error) {
-	ret := _m.Called(ctx, tenantID, name)
-
-	var r0 *models.Namespace
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Namespace); ok {
-		r0 = rf(ctx, tenantID, name)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Namespace)
-		}
-	}
+// DeleteNamespace provides a mock function with given fields: ctx, tenantID
+func (_m *Service) DeleteNamespace(ctx context.Context, tenantID string) error {
+	ret := _m.Called(ctx, tenantID)
 
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, tenantID, name)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, tenantID)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// EditNamespaceUser provides a mock function with given fields: ctx, tenantID, userID, memberID, memberNewRole
-func (_m *Service) EditNamespaceUser(ctx context.Context, tenantID string, userID string, memberID string, memberNewRole string) error {
-	ret := _m.Called(ctx, tenantID, userID, memberID, memberNewRole)
+// DeletePublicKey provides a mock function with given fields: ctx, fingerprint, tenant
+func (_m *Service) DeletePublicKey(ctx context.Context, fingerprint string, tenant string) error {
+	ret := _m.Called(ctx, fingerprint, tenant)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) error); ok {
-		r0 = rf(ctx, tenantID, userID, memberID, memberNewRole)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, fingerprint, tenant)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -413,13 +688,13 @@ func (_m *Service) EditNamespaceUser(ctx context.Context, tenantID string, userI
 	return r0
 }
 
-// EditSessionRecordStatus provides a mock function with given fields: ctx, sessionRecord, tenantID
-func (_m *Service) EditSessionRecordStatus(ctx context.Context, sessionRecord bool, tenantID string) error {
-	ret := _m.Called(ctx, sessionRecord, tenantID)
+// DeleteRole provides a mock function with given fields: ctx, id, tenant
+func (_m *Service) DeleteRole(ctx context.Context, id string, tenant string) error {
+	ret := _m.Called(ctx, id, tenant)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, bool, string) error); ok {
-		r0 = rf(ctx, sessionRecord, tenantID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, id, tenant)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -427,8 +702,208 @@ func (_m *Service) EditSessionRecordStatus(ctx context.Context, sessionRecord bo
 	return r0
 }
 
-// EvaluateKeyFilter provides a mock function with given fields: ctx, key, dev
-func (_m *Service) EvaluateKeyFilter(ctx context.Context, key *models.PublicKey, dev models.Device) (bool, error) {
+// DeleteTag provides a mock function with given fields: ctx, tenant, tag
+func (_m *Service) DeleteTag(ctx context.Context, tenant string, tag string) error {
+	ret := _m.Called(ctx, tenant, tag)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, tag)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteWebhook provides a mock function with given fields: ctx, id, tenant
+func (_m *Service) DeleteWebhook(ctx context.Context, id string, tenant string) error {
+	ret := _m.Called(ctx, id, tenant)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, id, tenant)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeliverWebhook provides a mock function with given fields: ctx, task, attempt
+func (_m *Service) DeliverWebhook(ctx context.Context, task *models.WebhookDeliveryTask, attempt int) error {
+	ret := _m.Called(ctx, task, attempt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.WebhookDeliveryTask, int) error); ok {
+		r0 = rf(ctx, task, attempt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceHeartbeat provides a mock function with given fields: ctx, uid
+func (_m *Service) DeviceHeartbeat(ctx context.Context, uid models.UID) error {
+	ret := _m.Called(ctx, uid)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) error); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EditDeviceNaming provides a mock function with given fields: ctx, tenantID, naming
+func (_m *Service) EditDeviceNaming(ctx context.Context, tenantID string, naming *models.DeviceNaming) error {
+	ret := _m.Called(ctx, tenantID, naming)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.DeviceNaming) error); ok {
+		r0 = rf(ctx, tenantID, naming)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EditGeoAccess provides a mock function with given fields: ctx, tenantID, geo
+func (_m *Service) EditGeoAccess(ctx context.Context, tenantID string, geo *models.GeoAccess) error {
+	ret := _m.Called(ctx, tenantID, geo)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.GeoAccess) error); ok {
+		r0 = rf(ctx, tenantID, geo)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EditMemberScope provides a mock function with given fields: ctx, tenantID, userID, memberID, scope
+func (_m *Service) EditMemberScope(ctx context.Context, tenantID string, userID string, memberID string, scope *models.MemberScope) error {
+	ret := _m.Called(ctx, tenantID, userID, memberID, scope)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, *models.MemberScope) error); ok {
+		r0 = rf(ctx, tenantID, userID, memberID, scope)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EditNamespace provides a mock function with given fields: ctx, tenantID, name
+func (_m *Service) EditNamespace(ctx context.Context, tenantID string, name string) (*models.Namespace, error) {
+	ret := _m.Called(ctx, tenantID, name)
+
+	var r0 *models.Namespace
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Namespace); ok {
+		r0 = rf(ctx, tenantID, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Namespace)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenantID, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EditNamespaceUser provides a mock function with given fields: ctx, tenantID, userID, memberID, memberNewRole
+func (_m *Service) EditNamespaceUser(ctx context.Context, tenantID string, userID string, memberID string, memberNewRole string) error {
+	ret := _m.Called(ctx, tenantID, userID, memberID, memberNewRole)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) error); ok {
+		r0 = rf(ctx, tenantID, userID, memberID, memberNewRole)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EditSessionRecordStatus provides a mock function with given fields: ctx, sessionRecord, tenantID
+func (_m *Service) EditSessionRecordStatus(ctx context.Context, sessionRecord bool, tenantID string) error {
+	ret := _m.Called(ctx, sessionRecord, tenantID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bool, string) error); ok {
+		r0 = rf(ctx, sessionRecord, tenantID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EditSessionTypes provides a mock function with given fields: ctx, tenantID, types
+func (_m *Service) EditSessionTypes(ctx context.Context, tenantID string, types *models.SessionTypes) error {
+	ret := _m.Called(ctx, tenantID, types)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.SessionTypes) error); ok {
+		r0 = rf(ctx, tenantID, types)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EvaluateFirewall provides a mock function with given fields: ctx, evaluation
+func (_m *Service) EvaluateFirewall(ctx context.Context, evaluation *models.FirewallEvaluation) (*models.FirewallDecision, error) {
+	ret := _m.Called(ctx, evaluation)
+
+	var r0 *models.FirewallDecision
+	if rf, ok := ret.Get(0).(func(context.Context, *models.FirewallEvaluation) *models.FirewallDecision); ok {
+		r0 = rf(ctx, evaluation)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.FirewallDecision)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.FirewallEvaluation) error); ok {
+		r1 = rf(ctx, evaluation)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EvaluateGeoAccess provides a mock function with given fields: ctx, device, ip
+func (_m *Service) EvaluateGeoAccess(ctx context.Context, device *models.Device, ip string) error {
+	ret := _m.Called(ctx, device, ip)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Device, string) error); ok {
+		r0 = rf(ctx, device, ip)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EvaluateKeyFilter provides a mock function with given fields: ctx, key, dev
+func (_m *Service) EvaluateKeyFilter(ctx context.Context, key *models.PublicKey, dev models.Device) (bool, error) {
 	ret := _m.Called(ctx, key, dev)
 
 	var r0 bool
@@ -448,6 +923,20 @@ func (_m *Service) EvaluateKeyFilter(ctx context.Context, key *models.PublicKey,
 	return r0, r1
 }
 
+// EvaluateKeyScope provides a mock function with given fields: ctx, tenantID, fingerprint, uid
+func (_m *Service) EvaluateKeyScope(ctx context.Context, tenantID string, fingerprint string, uid models.UID) error {
+	ret := _m.Called(ctx, tenantID, fingerprint, uid)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.UID) error); ok {
+		r0 = rf(ctx, tenantID, fingerprint, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // EvaluateKeyUsername provides a mock function with given fields: ctx, key, username
 func (_m *Service) EvaluateKeyUsername(ctx context.Context, key *models.PublicKey, username string) (bool, error) {
 	ret := _m.Called(ctx, key, username)
@@ -469,6 +958,57 @@ func (_m *Service) EvaluateKeyUsername(ctx context.Context, key *models.PublicKe
 	return r0, r1
 }
 
+// EvaluateMemberScope provides a mock function with given fields: ctx, tenantID, userID, uid
+func (_m *Service) EvaluateMemberScope(ctx context.Context, tenantID string, userID string, uid models.UID) error {
+	ret := _m.Called(ctx, tenantID, userID, uid)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.UID) error); ok {
+		r0 = rf(ctx, tenantID, userID, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EvaluateSessionType provides a mock function with given fields: ctx, uid, kind
+func (_m *Service) EvaluateSessionType(ctx context.Context, uid models.UID, kind string) error {
+	ret := _m.Called(ctx, uid, kind)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string) error); ok {
+		r0 = rf(ctx, uid, kind)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExportPolicy provides a mock function with given fields: ctx, tenant
+func (_m *Service) ExportPolicy(ctx context.Context, tenant string) (*models.Policy, error) {
+	ret := _m.Called(ctx, tenant)
+
+	var r0 *models.Policy
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Policy); ok {
+		r0 = rf(ctx, tenant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Policy)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenant)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FillMembersData provides a mock function with given fields: ctx, members
 func (_m *Service) FillMembersData(ctx context.Context, members []models.Member) ([]models.Member, error) {
 	ret := _m.Called(ctx, members)
@@ -515,22 +1055,22 @@ func (_m *Service) GetDevice(ctx context.Context, uid models.UID) (*models.Devic
 	return r0, r1
 }
 
-// GetNamespace provides a mock function with given fields: ctx, tenantID
-func (_m *Service) GetNamespace(ctx context.Context, tenantID string) (*models.Namespace, error) {
-	ret := _m.Called(ctx, tenantID)
+// GetDeviceAvailability provides a mock function with given fields: ctx, uid, from, to
+func (_m *Service) GetDeviceAvailability(ctx context.Context, uid models.UID, from time.Time, to time.Time) (*models.DeviceAvailability, error) {
+	ret := _m.Called(ctx, uid, from, to)
 
-	var r0 *models.Namespace
-	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Namespace); ok {
-		r0 = rf(ctx, tenantID)
+	var r0 *models.DeviceAvailability
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, time.Time, time.Time) *models.DeviceAvailability); ok {
+		r0 = rf(ctx, uid, from, to)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Namespace)
+			r0 = ret.Get(0).(*models.DeviceAvailability)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, tenantID)
+	if rf, ok := ret.Get(1).(func(context.Context, models.UID, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, uid, from, to)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -538,22 +1078,22 @@ func (_m *Service) GetNamespace(ctx context.Context, tenantID string) (*models.N
 	return r0, r1
 }
 
-// GetPublicKey provides a mock function with given fields: ctx, fingerprint, tenant
-func (_m *Service) GetPublicKey(ctx context.Context, fingerprint string, tenant string) (*models.PublicKey, error) {
-	ret := _m.Called(ctx, fingerprint, tenant)
+// GetDeviceGroup provides a mock function with given fields: ctx, id, tenant
+func (_m *Service) GetDeviceGroup(ctx context.Context, id string, tenant string) (*models.DeviceGroup, error) {
+	ret := _m.Called(ctx, id, tenant)
 
-	var r0 *models.PublicKey
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.PublicKey); ok {
-		r0 = rf(ctx, fingerprint, tenant)
+	var r0 *models.DeviceGroup
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.DeviceGroup); ok {
+		r0 = rf(ctx, id, tenant)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.PublicKey)
+			r0 = ret.Get(0).(*models.DeviceGroup)
 		}
 	}
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, fingerprint, tenant)
+		r1 = rf(ctx, id, tenant)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -561,22 +1101,22 @@ func (_m *Service) GetPublicKey(ctx context.Context, fingerprint string, tenant
 	return r0, r1
 }
 
-// GetSession provides a mock function with given fields: ctx, uid
-func (_m *Service) GetSession(ctx context.Context, uid models.UID) (*models.Session, error) {
-	ret := _m.Called(ctx, uid)
+// GetFirewallRule provides a mock function with given fields: ctx, id, tenant
+func (_m *Service) GetFirewallRule(ctx context.Context, id string, tenant string) (*models.FirewallRule, error) {
+	ret := _m.Called(ctx, id, tenant)
 
-	var r0 *models.Session
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID) *models.Session); ok {
-		r0 = rf(ctx, uid)
+	var r0 *models.FirewallRule
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.FirewallRule); ok {
+		r0 = rf(ctx, id, tenant)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Session)
+			r0 = ret.Get(0).(*models.FirewallRule)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, models.UID) error); ok {
-		r1 = rf(ctx, uid)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, tenant)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -584,20 +1124,22 @@ func (_m *Service) GetSession(ctx context.Context, uid models.UID) (*models.Sess
 	return r0, r1
 }
 
-// GetSessionRecord provides a mock function with given fields: ctx, tenantID
-func (_m *Service) GetSessionRecord(ctx context.Context, tenantID string) (bool, error) {
-	ret := _m.Called(ctx, tenantID)
+// GetIPSet provides a mock function with given fields: ctx, id, tenant
+func (_m *Service) GetIPSet(ctx context.Context, id string, tenant string) (*models.IPSet, error) {
+	ret := _m.Called(ctx, id, tenant)
 
-	var r0 bool
-	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
-		r0 = rf(ctx, tenantID)
+	var r0 *models.IPSet
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.IPSet); ok {
+		r0 = rf(ctx, id, tenant)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.IPSet)
+		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, tenantID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, tenant)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -605,22 +1147,22 @@ func (_m *Service) GetSessionRecord(ctx context.Context, tenantID string) (bool,
 	return r0, r1
 }
 
-// GetStats provides a mock function with given fields: ctx
-func (_m *Service) GetStats(ctx context.Context) (*models.Stats, error) {
-	ret := _m.Called(ctx)
+// GetLoginLockouts provides a mock function with given fields: ctx, username, ip
+func (_m *Service) GetLoginLockouts(ctx context.Context, username string, ip string) (*models.LoginLockouts, error) {
+	ret := _m.Called(ctx, username, ip)
 
-	var r0 *models.Stats
-	if rf, ok := ret.Get(0).(func(context.Context) *models.Stats); ok {
-		r0 = rf(ctx)
+	var r0 *models.LoginLockouts
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.LoginLockouts); ok {
+		r0 = rf(ctx, username, ip)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Stats)
+			r0 = ret.Get(0).(*models.LoginLockouts)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, username, ip)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -628,8 +1170,211 @@ func (_m *Service) GetStats(ctx context.Context) (*models.Stats, error) {
 	return r0, r1
 }
 
-// GetTags provides a mock function with given fields: ctx, tenant
-func (_m *Service) GetTags(ctx context.Context, tenant string) ([]string, int, error) {
+// GetNamespace provides a mock function with given fields: ctx, tenantID
+func (_m *Service) GetNamespace(ctx context.Context, tenantID string) (*models.Namespace, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	var r0 *models.Namespace
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Namespace); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Namespace)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPublicKey provides a mock function with given fields: ctx, fingerprint, tenant
+func (_m *Service) GetPublicKey(ctx context.Context, fingerprint string, tenant string) (*models.PublicKey, error) {
+	ret := _m.Called(ctx, fingerprint, tenant)
+
+	var r0 *models.PublicKey
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.PublicKey); ok {
+		r0 = rf(ctx, fingerprint, tenant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PublicKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, fingerprint, tenant)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRole provides a mock function with given fields: ctx, id, tenant
+func (_m *Service) GetRole(ctx context.Context, id string, tenant string) (*models.Role, error) {
+	ret := _m.Called(ctx, id, tenant)
+
+	var r0 *models.Role
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Role); ok {
+		r0 = rf(ctx, id, tenant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Role)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, tenant)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRolePermissions provides a mock function with given fields: ctx, tenant, name
+func (_m *Service) GetRolePermissions(ctx context.Context, tenant string, name string) (guard.Permissions, bool) {
+	ret := _m.Called(ctx, tenant, name)
+
+	var r0 guard.Permissions
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) guard.Permissions); ok {
+		r0 = rf(ctx, tenant, name)
+	} else {
+		r0 = ret.Get(0).(guard.Permissions)
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) bool); ok {
+		r1 = rf(ctx, tenant, name)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// GetSSHLimits provides a mock function with given fields: ctx, tenant, ip, device
+func (_m *Service) GetSSHLimits(ctx context.Context, tenant string, ip string, device string) (*models.SSHLimits, error) {
+	ret := _m.Called(ctx, tenant, ip, device)
+
+	var r0 *models.SSHLimits
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *models.SSHLimits); ok {
+		r0 = rf(ctx, tenant, ip, device)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SSHLimits)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, tenant, ip, device)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSession provides a mock function with given fields: ctx, uid
+func (_m *Service) GetSession(ctx context.Context, uid models.UID) (*models.Session, error) {
+	ret := _m.Called(ctx, uid)
+
+	var r0 *models.Session
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) *models.Session); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Session)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.UID) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSessionApproval provides a mock function with given fields: ctx, uid, tenant
+func (_m *Service) GetSessionApproval(ctx context.Context, uid models.UID, tenant string) (*models.SessionApproval, error) {
+	ret := _m.Called(ctx, uid, tenant)
+
+	var r0 *models.SessionApproval
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string) *models.SessionApproval); ok {
+		r0 = rf(ctx, uid, tenant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SessionApproval)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.UID, string) error); ok {
+		r1 = rf(ctx, uid, tenant)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSessionRecord provides a mock function with given fields: ctx, tenantID
+func (_m *Service) GetSessionRecord(ctx context.Context, tenantID string) (bool, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStats provides a mock function with given fields: ctx
+func (_m *Service) GetStats(ctx context.Context) (*models.Stats, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *models.Stats
+	if rf, ok := ret.Get(0).(func(context.Context) *models.Stats); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Stats)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTags provides a mock function with given fields: ctx, tenant
+func (_m *Service) GetTags(ctx context.Context, tenant string) ([]string, int, error) {
 	ret := _m.Called(ctx, tenant)
 
 	var r0 []string
@@ -637,20 +1382,348 @@ func (_m *Service) GetTags(ctx context.Context, tenant string) ([]string, int, e
 		r0 = rf(ctx, tenant)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(context.Context, string) int); ok {
+		r1 = rf(ctx, tenant)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, tenant)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetWebhook provides a mock function with given fields: ctx, id, tenant
+func (_m *Service) GetWebhook(ctx context.Context, id string, tenant string) (*models.Webhook, error) {
+	ret := _m.Called(ctx, id, tenant)
+
+	var r0 *models.Webhook
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Webhook); ok {
+		r0 = rf(ctx, id, tenant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Webhook)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, tenant)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HandleReportDelete provides a mock function with given fields: ns
+func (_m *Service) HandleReportDelete(ns *models.Namespace) error {
+	ret := _m.Called(ns)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Namespace) error); ok {
+		r0 = rf(ns)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// HandleReportUsage provides a mock function with given fields: ns, ui, inc, device
+func (_m *Service) HandleReportUsage(ns *models.Namespace, ui models.UID, inc bool, device *models.Device) error {
+	ret := _m.Called(ns, ui, inc, device)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Namespace, models.UID, bool, *models.Device) error); ok {
+		r0 = rf(ns, ui, inc, device)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// KeepAliveSession provides a mock function with given fields: ctx, uid
+func (_m *Service) KeepAliveSession(ctx context.Context, uid models.UID) error {
+	ret := _m.Called(ctx, uid)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) error); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListAuditLogs provides a mock function with given fields: ctx, pagination, filter
+func (_m *Service) ListAuditLogs(ctx context.Context, pagination paginator.Query, filter *models.AuditLogFilter) ([]models.AuditLog, int, error) {
+	ret := _m.Called(ctx, pagination, filter)
+
+	var r0 []models.AuditLog
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query, *models.AuditLogFilter) []models.AuditLog); ok {
+		r0 = rf(ctx, pagination, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AuditLog)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(context.Context, paginator.Query, *models.AuditLogFilter) int); ok {
+		r1 = rf(ctx, pagination, filter)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, paginator.Query, *models.AuditLogFilter) error); ok {
+		r2 = rf(ctx, pagination, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListDeviceGroupMembers provides a mock function with given fields: ctx, id, tenant
+func (_m *Service) ListDeviceGroupMembers(ctx context.Context, id string, tenant string) ([]models.UID, error) {
+	ret := _m.Called(ctx, id, tenant)
+
+	var r0 []models.UID
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []models.UID); ok {
+		r0 = rf(ctx, id, tenant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.UID)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, tenant)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListDeviceGroups provides a mock function with given fields: ctx, pagination
+func (_m *Service) ListDeviceGroups(ctx context.Context, pagination paginator.Query) ([]models.DeviceGroup, int, error) {
+	ret := _m.Called(ctx, pagination)
+
+	var r0 []models.DeviceGroup
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) []models.DeviceGroup); ok {
+		r0 = rf(ctx, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceGroup)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(context.Context, paginator.Query) int); ok {
+		r1 = rf(ctx, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, paginator.Query) error); ok {
+		r2 = rf(ctx, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListDeviceHistory provides a mock function with given fields: ctx, uid, pagination, from, to
+func (_m *Service) ListDeviceHistory(ctx context.Context, uid models.UID, pagination paginator.Query, from time.Time, to time.Time) ([]models.DeviceConnectionEvent, int, error) {
+	ret := _m.Called(ctx, uid, pagination, from, to)
+
+	var r0 []models.DeviceConnectionEvent
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, paginator.Query, time.Time, time.Time) []models.DeviceConnectionEvent); ok {
+		r0 = rf(ctx, uid, pagination, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceConnectionEvent)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(context.Context, models.UID, paginator.Query, time.Time, time.Time) int); ok {
+		r1 = rf(ctx, uid, pagination, from, to)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, models.UID, paginator.Query, time.Time, time.Time) error); ok {
+		r2 = rf(ctx, uid, pagination, from, to)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListDevices provides a mock function with given fields: ctx, pagination, filter, status, sort, order, tenantID, userID
+func (_m *Service) ListDevices(ctx context.Context, pagination paginator.Query, filter string, status string, sort string, order string, tenantID string, userID string) ([]models.Device, int, error) {
+	ret := _m.Called(ctx, pagination, filter, status, sort, order, tenantID, userID)
+
+	var r0 []models.Device
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query, string, string, string, string, string, string) []models.Device); ok {
+		r0 = rf(ctx, pagination, filter, status, sort, order, tenantID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Device)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(context.Context, paginator.Query, string, string, string, string, string, string) int); ok {
+		r1 = rf(ctx, pagination, filter, status, sort, order, tenantID, userID)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, paginator.Query, string, string, string, string, string, string) error); ok {
+		r2 = rf(ctx, pagination, filter, status, sort, order, tenantID, userID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListFirewallRules provides a mock function with given fields: ctx, pagination
+func (_m *Service) ListFirewallRules(ctx context.Context, pagination paginator.Query) ([]models.FirewallRule, int, error) {
+	ret := _m.Called(ctx, pagination)
+
+	var r0 []models.FirewallRule
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) []models.FirewallRule); ok {
+		r0 = rf(ctx, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.FirewallRule)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(context.Context, paginator.Query) int); ok {
+		r1 = rf(ctx, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, paginator.Query) error); ok {
+		r2 = rf(ctx, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListIPSets provides a mock function with given fields: ctx, pagination
+func (_m *Service) ListIPSets(ctx context.Context, pagination paginator.Query) ([]models.IPSet, int, error) {
+	ret := _m.Called(ctx, pagination)
+
+	var r0 []models.IPSet
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) []models.IPSet); ok {
+		r0 = rf(ctx, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.IPSet)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(context.Context, paginator.Query) int); ok {
+		r1 = rf(ctx, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, paginator.Query) error); ok {
+		r2 = rf(ctx, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListInvitations provides a mock function with given fields: ctx, pagination
+func (_m *Service) ListInvitations(ctx context.Context, pagination paginator.Query) ([]models.Invitation, int, error) {
+	ret := _m.Called(ctx, pagination)
+
+	var r0 []models.Invitation
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) []models.Invitation); ok {
+		r0 = rf(ctx, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Invitation)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(context.Context, paginator.Query) int); ok {
+		r1 = rf(ctx, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, paginator.Query) error); ok {
+		r2 = rf(ctx, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListNamespaces provides a mock function with given fields: ctx, pagination, filterB64, export
+func (_m *Service) ListNamespaces(ctx context.Context, pagination paginator.Query, filterB64 string, export bool) ([]models.Namespace, int, error) {
+	ret := _m.Called(ctx, pagination, filterB64, export)
+
+	var r0 []models.Namespace
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query, string, bool) []models.Namespace); ok {
+		r0 = rf(ctx, pagination, filterB64, export)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Namespace)
 		}
 	}
 
 	var r1 int
-	if rf, ok := ret.Get(1).(func(context.Context, string) int); ok {
-		r1 = rf(ctx, tenant)
+	if rf, ok := ret.Get(1).(func(context.Context, paginator.Query, string, bool) int); ok {
+		r1 = rf(ctx, pagination, filterB64, export)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
 
 	var r2 error
-	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
-		r2 = rf(ctx, tenant)
+	if rf, ok := ret.Get(2).(func(context.Context, paginator.Query, string, bool) error); ok {
+		r2 = rf(ctx, pagination, filterB64, export)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -658,71 +1731,59 @@ func (_m *Service) GetTags(ctx context.Context, tenant string) ([]string, int, e
 	return r0, r1, r2
 }
 
-// HandleReportDelete provides a mock function with given fields: ns
-func (_m *Service) HandleReportDelete(ns *models.Namespace) error {
-	ret := _m.Called(ns)
+// ListPublicKeys provides a mock function with given fields: ctx, pagination
+func (_m *Service) ListPublicKeys(ctx context.Context, pagination paginator.Query) ([]models.PublicKey, int, error) {
+	ret := _m.Called(ctx, pagination)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.Namespace) error); ok {
-		r0 = rf(ns)
+	var r0 []models.PublicKey
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) []models.PublicKey); ok {
+		r0 = rf(ctx, pagination)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PublicKey)
+		}
 	}
 
-	return r0
-}
-
-// HandleReportUsage provides a mock function with given fields: ns, ui, inc, device
-func (_m *Service) HandleReportUsage(ns *models.Namespace, ui models.UID, inc bool, device *models.Device) error {
-	ret := _m.Called(ns, ui, inc, device)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.Namespace, models.UID, bool, *models.Device) error); ok {
-		r0 = rf(ns, ui, inc, device)
+	var r1 int
+	if rf, ok := ret.Get(1).(func(context.Context, paginator.Query) int); ok {
+		r1 = rf(ctx, pagination)
 	} else {
-		r0 = ret.Error(0)
+		r1 = ret.Get(1).(int)
 	}
 
-	return r0
-}
-
-// KeepAliveSession provides a mock function with given fields: ctx, uid
-func (_m *Service) KeepAliveSession(ctx context.Context, uid models.UID) error {
-	ret := _m.Called(ctx, uid)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID) error); ok {
-		r0 = rf(ctx, uid)
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, paginator.Query) error); ok {
+		r2 = rf(ctx, pagination)
 	} else {
-		r0 = ret.Error(0)
+		r2 = ret.Error(2)
 	}
 
-	return r0
+	return r0, r1, r2
 }
 
-// ListDevices provides a mock function with given fields: ctx, pagination, filter, status, sort, order
-func (_m *Service) ListDevices(ctx context.Context, pagination paginator.Query, filter string, status string, sort string, order string) ([]models.Device, int, error) {
-	ret := _m.Called(ctx, pagination, filter, status, sort, order)
+// ListRoles provides a mock function with given fields: ctx, pagination
+func (_m *Service) ListRoles(ctx context.Context, pagination paginator.Query) ([]models.Role, int, error) {
+	ret := _m.Called(ctx, pagination)
 
-	var r0 []models.Device
-	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query, string, string, string, string) []models.Device); ok {
-		r0 = rf(ctx, pagination, filter, status, sort, order)
+	var r0 []models.Role
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) []models.Role); ok {
+		r0 = rf(ctx, pagination)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.Device)
+			r0 = ret.Get(0).([]models.Role)
 		}
 	}
 
 	var r1 int
-	if rf, ok := ret.Get(1).(func(context.Context, paginator.Query, string, string, string, string) int); ok {
-		r1 = rf(ctx, pagination, filter, status, sort, order)
+	if rf, ok := ret.Get(1).(func(context.Context, paginator.Query) int); ok {
+		r1 = rf(ctx, pagination)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
 
 	var r2 error
-	if rf, ok := ret.Get(2).(func(context.Context, paginator.Query, string, string, string, string) error); ok {
-		r2 = rf(ctx, pagination, filter, status, sort, order)
+	if rf, ok := ret.Get(2).(func(context.Context, paginator.Query) error); ok {
+		r2 = rf(ctx, pagination)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -730,29 +1791,29 @@ func (_m *Service) ListDevices(ctx context.Context, pagination paginator.Query,
 	return r0, r1, r2
 }
 
-// ListNamespaces provides a mock function with given fields: ctx, pagination, filterB64, export
-func (_m *Service) ListNamespaces(ctx context.Context, pagination paginator.Query, filterB64 string, export bool) ([]models.Namespace, int, error) {
-	ret := _m.Called(ctx, pagination, filterB64, export)
+// ListSessionApprovals provides a mock function with given fields: ctx, pagination
+func (_m *Service) ListSessionApprovals(ctx context.Context, pagination paginator.Query) ([]models.SessionApproval, int, error) {
+	ret := _m.Called(ctx, pagination)
 
-	var r0 []models.Namespace
-	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query, string, bool) []models.Namespace); ok {
-		r0 = rf(ctx, pagination, filterB64, export)
+	var r0 []models.SessionApproval
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) []models.SessionApproval); ok {
+		r0 = rf(ctx, pagination)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.Namespace)
+			r0 = ret.Get(0).([]models.SessionApproval)
 		}
 	}
 
 	var r1 int
-	if rf, ok := ret.Get(1).(func(context.Context, paginator.Query, string, bool) int); ok {
-		r1 = rf(ctx, pagination, filterB64, export)
+	if rf, ok := ret.Get(1).(func(context.Context, paginator.Query) int); ok {
+		r1 = rf(ctx, pagination)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
 
 	var r2 error
-	if rf, ok := ret.Get(2).(func(context.Context, paginator.Query, string, bool) error); ok {
-		r2 = rf(ctx, pagination, filterB64, export)
+	if rf, ok := ret.Get(2).(func(context.Context, paginator.Query) error); ok {
+		r2 = rf(ctx, pagination)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -760,16 +1821,16 @@ func (_m *Service) ListNamespaces(ctx context.Context, pagination paginator.Quer
 	return r0, r1, r2
 }
 
-// ListPublicKeys provides a mock function with given fields: ctx, pagination
-func (_m *Service) ListPublicKeys(ctx context.Context, pagination paginator.Query) ([]models.PublicKey, int, error) {
+// ListSessions provides a mock function with given fields: ctx, pagination
+func (_m *Service) ListSessions(ctx context.Context, pagination paginator.Query) ([]models.Session, int, error) {
 	ret := _m.Called(ctx, pagination)
 
-	var r0 []models.PublicKey
-	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) []models.PublicKey); ok {
+	var r0 []models.Session
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) []models.Session); ok {
 		r0 = rf(ctx, pagination)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.PublicKey)
+			r0 = ret.Get(0).([]models.Session)
 		}
 	}
 
@@ -790,16 +1851,46 @@ func (_m *Service) ListPublicKeys(ctx context.Context, pagination paginator.Quer
 	return r0, r1, r2
 }
 
-// ListSessions provides a mock function with given fields: ctx, pagination
-func (_m *Service) ListSessions(ctx context.Context, pagination paginator.Query) ([]models.Session, int, error) {
+// ListWebhookDeliveries provides a mock function with given fields: ctx, id, tenant, pagination
+func (_m *Service) ListWebhookDeliveries(ctx context.Context, id string, tenant string, pagination paginator.Query) ([]models.WebhookDelivery, int, error) {
+	ret := _m.Called(ctx, id, tenant, pagination)
+
+	var r0 []models.WebhookDelivery
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, paginator.Query) []models.WebhookDelivery); ok {
+		r0 = rf(ctx, id, tenant, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.WebhookDelivery)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, paginator.Query) int); ok {
+		r1 = rf(ctx, id, tenant, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, paginator.Query) error); ok {
+		r2 = rf(ctx, id, tenant, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListWebhooks provides a mock function with given fields: ctx, pagination
+func (_m *Service) ListWebhooks(ctx context.Context, pagination paginator.Query) ([]models.Webhook, int, error) {
 	ret := _m.Called(ctx, pagination)
 
-	var r0 []models.Session
-	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) []models.Session); ok {
+	var r0 []models.Webhook
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) []models.Webhook); ok {
 		r0 = rf(ctx, pagination)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.Session)
+			r0 = ret.Get(0).([]models.Webhook)
 		}
 	}
 
@@ -859,6 +1950,20 @@ func (_m *Service) PublicKey() *rsa.PublicKey {
 	return r0
 }
 
+// RecordSSHAttempt provides a mock function with given fields: ctx, attempt
+func (_m *Service) RecordSSHAttempt(ctx context.Context, attempt *models.SSHAttempt) error {
+	ret := _m.Called(ctx, attempt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.SSHAttempt) error); ok {
+		r0 = rf(ctx, attempt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RemoveDeviceTag provides a mock function with given fields: ctx, uid, name
 func (_m *Service) RemoveDeviceTag(ctx context.Context, uid models.UID, name string) error {
 	ret := _m.Called(ctx, uid, name)
@@ -966,6 +2071,89 @@ func (_m *Service) SetSessionAuthenticated(ctx context.Context, uid models.UID,
 	return r0
 }
 
+// SimulateFirewall provides a mock function with given fields: ctx, tenant, simulation
+func (_m *Service) SimulateFirewall(ctx context.Context, tenant string, simulation *models.FirewallSimulation) (*models.FirewallDecision, error) {
+	ret := _m.Called(ctx, tenant, simulation)
+
+	var r0 *models.FirewallDecision
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.FirewallSimulation) *models.FirewallDecision); ok {
+		r0 = rf(ctx, tenant, simulation)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.FirewallDecision)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, *models.FirewallSimulation) error); ok {
+		r1 = rf(ctx, tenant, simulation)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TransferDevice provides a mock function with given fields: ctx, uid, from, to, userID, sessions
+func (_m *Service) TransferDevice(ctx context.Context, uid models.UID, from string, to string, userID string, sessions bool) (*models.Device, error) {
+	ret := _m.Called(ctx, uid, from, to, userID, sessions)
+
+	var r0 *models.Device
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string, string, string, bool) *models.Device); ok {
+		r0 = rf(ctx, uid, from, to, userID, sessions)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Device)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.UID, string, string, string, bool) error); ok {
+		r1 = rf(ctx, uid, from, to, userID, sessions)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TransferNamespaceOwnership provides a mock function with given fields: ctx, tenantID, userID, memberID
+func (_m *Service) TransferNamespaceOwnership(ctx context.Context, tenantID string, userID string, memberID string) (*models.Namespace, error) {
+	ret := _m.Called(ctx, tenantID, userID, memberID)
+
+	var r0 *models.Namespace
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *models.Namespace); ok {
+		r0 = rf(ctx, tenantID, userID, memberID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Namespace)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, tenantID, userID, memberID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UnlockLogin provides a mock function with given fields: ctx, username, ip
+func (_m *Service) UnlockLogin(ctx context.Context, username string, ip string) error {
+	ret := _m.Called(ctx, username, ip)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, username, ip)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateDataUser provides a mock function with given fields: ctx, id, userData
 func (_m *Service) UpdateDataUser(ctx context.Context, id string, userData request.UserDataUpdate) ([]string, error) {
 	ret := _m.Called(ctx, id, userData)
@@ -989,6 +2177,29 @@ func (_m *Service) UpdateDataUser(ctx context.Context, id string, userData reque
 	return r0, r1
 }
 
+// UpdateDeviceGroup provides a mock function with given fields: ctx, id, tenant, group
+func (_m *Service) UpdateDeviceGroup(ctx context.Context, id string, tenant string, group *models.DeviceGroupUpdate) (*models.DeviceGroup, error) {
+	ret := _m.Called(ctx, id, tenant, group)
+
+	var r0 *models.DeviceGroup
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.DeviceGroupUpdate) *models.DeviceGroup); ok {
+		r0 = rf(ctx, id, tenant, group)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeviceGroup)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *models.DeviceGroupUpdate) error); ok {
+		r1 = rf(ctx, id, tenant, group)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateDeviceStatus provides a mock function with given fields: ctx, uid, online
 func (_m *Service) UpdateDeviceStatus(ctx context.Context, uid models.UID, online bool) error {
 	ret := _m.Called(ctx, uid, online)
@@ -1017,6 +2228,52 @@ func (_m *Service) UpdateDeviceTag(ctx context.Context, uid models.UID, tags []s
 	return r0
 }
 
+// UpdateFirewallRule provides a mock function with given fields: ctx, id, tenant, rule
+func (_m *Service) UpdateFirewallRule(ctx context.Context, id string, tenant string, rule models.FirewallRuleUpdate) (*models.FirewallRule, error) {
+	ret := _m.Called(ctx, id, tenant, rule)
+
+	var r0 *models.FirewallRule
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.FirewallRuleUpdate) *models.FirewallRule); ok {
+		r0 = rf(ctx, id, tenant, rule)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.FirewallRule)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, models.FirewallRuleUpdate) error); ok {
+		r1 = rf(ctx, id, tenant, rule)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateIPSet provides a mock function with given fields: ctx, id, tenant, set
+func (_m *Service) UpdateIPSet(ctx context.Context, id string, tenant string, set *models.IPSetUpdate) (*models.IPSet, error) {
+	ret := _m.Called(ctx, id, tenant, set)
+
+	var r0 *models.IPSet
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.IPSetUpdate) *models.IPSet); ok {
+		r0 = rf(ctx, id, tenant, set)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.IPSet)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *models.IPSetUpdate) error); ok {
+		r1 = rf(ctx, id, tenant, set)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdatePasswordUser provides a mock function with given fields: ctx, id, currentPassword, newPassword
 func (_m *Service) UpdatePasswordUser(ctx context.Context, id string, currentPassword string, newPassword string) error {
 	ret := _m.Called(ctx, id, currentPassword, newPassword)
@@ -1081,3 +2338,49 @@ func (_m *Service) UpdatePublicKeyTags(ctx context.Context, tenant string, finge
 
 	return r0
 }
+
+// UpdateRole provides a mock function with given fields: ctx, id, tenant, role
+func (_m *Service) UpdateRole(ctx context.Context, id string, tenant string, role *models.RoleUpdate) (*models.Role, error) {
+	ret := _m.Called(ctx, id, tenant, role)
+
+	var r0 *models.Role
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.RoleUpdate) *models.Role); ok {
+		r0 = rf(ctx, id, tenant, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Role)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *models.RoleUpdate) error); ok {
+		r1 = rf(ctx, id, tenant, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateWebhook provides a mock function with given fields: ctx, id, tenant, webhook
+func (_m *Service) UpdateWebhook(ctx context.Context, id string, tenant string, webhook *models.WebhookUpdate) (*models.Webhook, error) {
+	ret := _m.Called(ctx, id, tenant, webhook)
+
+	var r0 *models.Webhook
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.WebhookUpdate) *models.Webhook); ok {
+		r0 = rf(ctx, id, tenant, webhook)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Webhook)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *models.WebhookUpdate) error); ok {
+		r1 = rf(ctx, id, tenant, webhook)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
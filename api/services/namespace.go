@@ -10,11 +10,13 @@ import (
 	"github.com/shellhub-io/shellhub/api/store"
 	req "github.com/shellhub-io/shellhub/pkg/api/internalclient"
 	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/clock"
 	"github.com/shellhub-io/shellhub/pkg/envs"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	hp "github.com/shellhub-io/shellhub/pkg/requests"
 	"github.com/shellhub-io/shellhub/pkg/uuid"
 	"github.com/shellhub-io/shellhub/pkg/validator"
+	"github.com/sirupsen/logrus"
 )
 
 type NamespaceService interface {
@@ -26,9 +28,11 @@ type NamespaceService interface {
 	AddNamespaceUser(ctx context.Context, memberUsername, memberRole, tenantID, userID string) (*models.Namespace, error)
 	RemoveNamespaceUser(ctx context.Context, tenantID, memberID, userID string) (*models.Namespace, error)
 	EditNamespaceUser(ctx context.Context, tenantID, userID, memberID, memberNewRole string) error
+	TransferNamespaceOwnership(ctx context.Context, tenantID, userID, memberID string) (*models.Namespace, error)
 	FillMembersData(ctx context.Context, members []models.Member) ([]models.Member, error)
 	EditSessionRecordStatus(ctx context.Context, sessionRecord bool, tenantID string) error
 	GetSessionRecord(ctx context.Context, tenantID string) (bool, error)
+	EditDeviceNaming(ctx context.Context, tenantID string, naming *models.DeviceNaming) error
 	HandleReportDelete(ns *models.Namespace) error
 }
 
@@ -137,6 +141,8 @@ func (s *service) CreateNamespace(ctx context.Context, namespace *models.Namespa
 		return nil, NewErrNamespaceCreateStore(err)
 	}
 
+	s.audit(ctx, ns.TenantID, models.AuditNamespaceCreate, ns.TenantID, nil, ns)
+
 	return ns, nil
 }
 
@@ -175,7 +181,13 @@ func (s *service) DeleteNamespace(ctx context.Context, tenantID string) error {
 		return err
 	}
 
-	return s.store.NamespaceDelete(ctx, tenantID)
+	if err := s.store.NamespaceDelete(ctx, tenantID); err != nil {
+		return err
+	}
+
+	s.audit(ctx, tenantID, models.AuditNamespaceRemove, tenantID, ns, nil)
+
+	return nil
 }
 
 // FillMembersData fill the member data with the user data.
@@ -222,7 +234,15 @@ func (s *service) EditNamespace(ctx context.Context, tenantID, name string) (*mo
 		return nil, NewErrNamespaceDuplicated(nil)
 	}
 
-	return s.store.NamespaceRename(ctx, namespace.TenantID, name)
+	renamed, err := s.store.NamespaceRename(ctx, namespace.TenantID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, tenantID, models.AuditNamespaceRename, tenantID,
+		map[string]string{"name": namespace.Name}, map[string]string{"name": renamed.Name})
+
+	return renamed, nil
 }
 
 // AddNamespaceUser adds a member to a namespace.
@@ -267,11 +287,24 @@ func (s *service) AddNamespaceUser(ctx context.Context, memberUsername, memberRo
 		return nil, NewErrNamespaceMemberDuplicated(passive.ID, nil)
 	}
 
-	if !guard.CheckRole(active.Role, memberRole) {
+	if !checkMemberRole(active.Role, memberRole) {
 		return nil, guard.ErrForbidden
 	}
 
-	return s.store.NamespaceAddMember(ctx, tenantID, passive.ID, memberRole)
+	if err := s.validateMemberRole(ctx, tenantID, memberRole); err != nil {
+		return nil, err
+	}
+
+	namespace, err = s.store.NamespaceAddMember(ctx, tenantID, passive.ID, memberRole)
+	if err != nil {
+		return nil, err
+	}
+
+	member := &models.Member{ID: passive.ID, Username: passive.Username, Role: memberRole}
+	s.dispatchWebhookEvent(ctx, tenantID, models.WebhookEventMemberAdded, member)
+	s.audit(ctx, tenantID, models.AuditMemberAdd, passive.ID, nil, member)
+
+	return namespace, nil
 }
 
 // RemoveNamespaceUser removes member from a namespace.
@@ -316,11 +349,18 @@ func (s *service) RemoveNamespaceUser(ctx context.Context, tenantID, memberID, u
 	}
 
 	// checks if the active member can act over the passive member.
-	if !guard.CheckRole(active.Role, passive.Role) {
+	if !checkMemberRole(active.Role, passive.Role) {
 		return nil, guard.ErrForbidden
 	}
 
-	return s.store.NamespaceRemoveMember(ctx, tenantID, member.ID)
+	namespace, err = s.store.NamespaceRemoveMember(ctx, tenantID, member.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, tenantID, models.AuditMemberRemove, member.ID, passive, nil)
+
+	return namespace, nil
 }
 
 // EditNamespaceUser edits a member's role.
@@ -366,11 +406,71 @@ func (s *service) EditNamespaceUser(ctx context.Context, tenantID, userID, membe
 	}
 
 	// checks if the active member can act over the passive member.
-	if !guard.CheckRole(active.Role, memberNewRole) {
+	if !checkMemberRole(active.Role, passive.Role) || !checkMemberRole(active.Role, memberNewRole) {
 		return guard.ErrForbidden
 	}
 
-	return s.store.NamespaceEditMember(ctx, tenantID, member.ID, memberNewRole)
+	if err := s.validateMemberRole(ctx, tenantID, memberNewRole); err != nil {
+		return err
+	}
+
+	if err := s.store.NamespaceEditMember(ctx, tenantID, member.ID, memberNewRole); err != nil {
+		return err
+	}
+
+	s.audit(ctx, tenantID, models.AuditMemberRole, member.ID,
+		map[string]string{"role": passive.Role}, map[string]string{"role": memberNewRole})
+
+	return nil
+}
+
+// TransferNamespaceOwnership makes a member the owner of the namespace, demoting the current owner to administrator.
+//
+// It receives a context, used to "control" the request flow, the tenant ID from models.Namespace, the user ID from
+// models.User who owns the namespace and the ID of the member who will own it. The transfer is recorded on the
+// namespace.
+//
+// It can return an error if the namespace is not found, NewErrNamespaceNotFound, if the user is not the owner,
+// NewErrNamespaceNotOwner, if the member is the owner, NewErrNamespaceMemberInvalid, or if the member is not in the
+// namespace, NewErrNamespaceMemberNotFound.
+func (s *service) TransferNamespaceOwnership(ctx context.Context, tenantID, userID, memberID string) (*models.Namespace, error) {
+	namespace, err := s.store.NamespaceGet(ctx, tenantID)
+	if err != nil {
+		return nil, NewErrNamespaceNotFound(tenantID, err)
+	}
+
+	if namespace.Owner != userID {
+		return nil, NewErrNamespaceNotOwner(tenantID, nil)
+	}
+
+	if memberID == userID {
+		return nil, NewErrNamespaceMemberInvalid(nil)
+	}
+
+	if _, ok := guard.CheckMember(namespace, memberID); !ok {
+		return nil, NewErrNamespaceMemberNotFound(memberID, nil)
+	}
+
+	transfer := &models.OwnershipTransfer{From: userID, To: memberID, TransferredAt: clock.Now()}
+	if err := s.store.NamespaceTransferOwnership(ctx, tenantID, transfer); err != nil {
+		// The ownership changed since the namespace was got.
+		if err == store.ErrNoDocuments {
+			return nil, NewErrNamespaceNotOwner(tenantID, err)
+		}
+
+		return nil, err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"tenant_id": tenantID,
+		"from":      userID,
+		"to":        memberID,
+	}).Info("Namespace ownership transferred")
+
+	s.audit(ctx, tenantID, models.AuditNamespaceTransfer, memberID,
+		map[string]string{"owner": userID}, map[string]string{"owner": memberID})
+
+	return s.store.NamespaceGet(ctx, tenantID)
 }
 
 // EditSessionRecordStatus defines if the sessions will be recorded.
@@ -378,7 +478,15 @@ func (s *service) EditNamespaceUser(ctx context.Context, tenantID, userID, membe
 // It receives a context, used to "control" the request flow, a boolean to define if the sessions will be recorded and
 // the tenant ID from models.Namespace.
 func (s *service) EditSessionRecordStatus(ctx context.Context, sessionRecord bool, tenantID string) error {
-	return s.store.NamespaceSetSessionRecord(ctx, sessionRecord, tenantID)
+	before := s.auditSettings(ctx, tenantID)
+
+	if err := s.store.NamespaceSetSessionRecord(ctx, sessionRecord, tenantID); err != nil {
+		return err
+	}
+
+	s.audit(ctx, tenantID, models.AuditNamespaceSessionRecord, tenantID, before, s.auditSettings(ctx, tenantID))
+
+	return nil
 }
 
 // GetSessionRecord gets the session record data.
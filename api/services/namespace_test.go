@@ -786,7 +786,7 @@ func TestAddNamespaceUser(t *testing.T) {
 		{
 			Name:     "AddNamespaceUser fails when Role is not valid",
 			Username: user2.Username,
-			Role:     "invalid",
+			Role:     guard.RoleOwner,
 			ID:       user1.ID,
 			TenantID: namespace.TenantID,
 			RequiredMocks: func() {
@@ -894,6 +894,45 @@ func TestAddNamespaceUser(t *testing.T) {
 				namespace: namespaceTwoMembers,
 				err:       nil,
 			},
+		}, {
+			Name:     "AddNamespaceUser fails when the custom role does not exist",
+			Username: user2.Username,
+			Role:     "support",
+			ID:       user1.ID,
+			TenantID: namespace.TenantID,
+			RequiredMocks: func() {
+				mock.On("NamespaceGet", ctx, namespace.TenantID).Return(namespace, nil).Once()
+
+				mock.On("UserGetByID", ctx, user1.ID, false).Return(user1, 0, nil).Once()
+				mock.On("UserGetByUsername", ctx, user2.Username).Return(user2, nil).Once()
+
+				mock.On("RoleGetByName", ctx, namespace.TenantID, "support").Return(nil, store.ErrNoDocuments).Once()
+			},
+			Expected: Expected{
+				namespace: nil,
+				err:       NewErrRoleNotFound("support", store.ErrNoDocuments),
+			},
+		},
+		{
+			Name:     "AddNamespaceUser succeeds with a custom role",
+			Username: user2.Username,
+			Role:     "support",
+			ID:       user1.ID,
+			TenantID: namespace.TenantID,
+			RequiredMocks: func() {
+				mock.On("NamespaceGet", ctx, namespace.TenantID).Return(namespace, nil).Once()
+
+				mock.On("UserGetByID", ctx, user1.ID, false).Return(user1, 0, nil).Once()
+				mock.On("UserGetByUsername", ctx, user2.Username).Return(user2, nil).Once()
+
+				mock.On("RoleGetByName", ctx, namespace.TenantID, "support").
+					Return(&models.Role{TenantID: namespace.TenantID, RoleFields: models.RoleFields{Name: "support"}}, nil).Once()
+				mock.On("NamespaceAddMember", ctx, namespace.TenantID, user2.ID, "support").Return(namespaceTwoMembers, nil).Once()
+			},
+			Expected: Expected{
+				namespace: namespaceTwoMembers,
+				err:       nil,
+			},
 		},
 	}
 
@@ -1215,6 +1254,122 @@ func TestEditNamespaceUser(t *testing.T) {
 	mock.AssertExpectations(t)
 }
 
+func TestTransferNamespaceOwnership(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+	ctx := context.TODO()
+	Err := errors.New("error")
+
+	namespace := &models.Namespace{
+		Name:     "group1",
+		Owner:    "ownerID",
+		TenantID: "tenant",
+		Members: []models.Member{
+			{ID: "ownerID", Role: guard.RoleOwner},
+			{ID: "adminID", Role: guard.RoleAdministrator},
+		},
+	}
+
+	transferred := &models.Namespace{
+		Name:     "group1",
+		Owner:    "adminID",
+		TenantID: "tenant",
+		Members: []models.Member{
+			{ID: "ownerID", Role: guard.RoleAdministrator},
+			{ID: "adminID", Role: guard.RoleOwner},
+		},
+		Transfers: []models.OwnershipTransfer{{From: "ownerID", To: "adminID", TransferredAt: now}},
+	}
+
+	type Expected struct {
+		namespace *models.Namespace
+		err       error
+	}
+
+	cases := []struct {
+		description   string
+		userID        string
+		memberID      string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the namespace is not found",
+			userID:      "ownerID",
+			memberID:    "adminID",
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(nil, Err).Once()
+			},
+			expected: Expected{nil, NewErrNamespaceNotFound("tenant", Err)},
+		},
+		{
+			description: "fails when the user is not the owner",
+			userID:      "adminID",
+			memberID:    "adminID",
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+			},
+			expected: Expected{nil, NewErrNamespaceNotOwner("tenant", nil)},
+		},
+		{
+			description: "fails when the member is the owner",
+			userID:      "ownerID",
+			memberID:    "ownerID",
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+			},
+			expected: Expected{nil, NewErrNamespaceMemberInvalid(nil)},
+		},
+		{
+			description: "fails when the member is not in the namespace",
+			userID:      "ownerID",
+			memberID:    "unknownID",
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+			},
+			expected: Expected{nil, NewErrNamespaceMemberNotFound("unknownID", nil)},
+		},
+		{
+			description: "fails when the ownership changed concurrently",
+			userID:      "ownerID",
+			memberID:    "adminID",
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("NamespaceTransferOwnership", ctx, "tenant",
+					&models.OwnershipTransfer{From: "ownerID", To: "adminID", TransferredAt: now}).
+					Return(store.ErrNoDocuments).Once()
+			},
+			expected: Expected{nil, NewErrNamespaceNotOwner("tenant", store.ErrNoDocuments)},
+		},
+		{
+			description: "succeeds",
+			userID:      "ownerID",
+			memberID:    "adminID",
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("NamespaceTransferOwnership", ctx, "tenant",
+					&models.OwnershipTransfer{From: "ownerID", To: "adminID", TransferredAt: now}).
+					Return(nil).Once()
+				mock.On("NamespaceGet", ctx, "tenant").Return(transferred, nil).Once()
+			},
+			expected: Expected{transferred, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			namespace, err := s.TransferNamespaceOwnership(ctx, "tenant", tc.userID, tc.memberID)
+			assert.Equal(t, tc.expected, Expected{namespace, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
 func TestGetSessionRecord(t *testing.T) {
 	mock := &mocks.Store{}
 	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
@@ -0,0 +1,377 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/validator"
+	"golang.org/x/crypto/ssh"
+)
+
+type PolicyService interface {
+	ExportPolicy(ctx context.Context, tenant string) (*models.Policy, error)
+	ApplyPolicy(ctx context.Context, tenant string, policy *models.Policy, dryRun bool) (*models.PolicyDiff, error)
+}
+
+// policyStep is a change required to make a namespace match a policy and the function that applies it.
+type policyStep struct {
+	change models.PolicyChange
+	apply  func() error
+}
+
+// ExportPolicy exports the firewall rules, the public keys and the tags of the devices of a namespace as a policy.
+func (s *service) ExportPolicy(ctx context.Context, tenant string) (*models.Policy, error) {
+	if _, err := s.store.NamespaceGet(ctx, tenant); err != nil {
+		return nil, NewErrNamespaceNotFound(tenant, err)
+	}
+
+	rules, err := s.listPolicyFirewallRules(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := s.listPolicyPublicKeys(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	devices, err := s.listPolicyDevices(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := &models.Policy{
+		FirewallRules: make([]models.PolicyFirewallRule, 0, len(rules)),
+		PublicKeys:    make([]models.PolicyPublicKey, 0, len(keys)),
+		Tags:          make(map[string][]string),
+	}
+
+	for _, rule := range rules {
+		policy.FirewallRules = append(policy.FirewallRules, models.PolicyFirewallRule{ID: rule.ID, FirewallRuleFields: rule.FirewallRuleFields})
+	}
+
+	for _, key := range keys {
+		policy.PublicKeys = append(policy.PublicKeys, models.PolicyPublicKey{Data: string(key.Data), PublicKeyFields: key.PublicKeyFields})
+	}
+
+	for _, device := range devices {
+		if len(device.Tags) > 0 {
+			policy.Tags[device.Name] = device.Tags
+		}
+	}
+
+	return policy, nil
+}
+
+// ApplyPolicy computes the changes required to make a namespace match a policy and, unless dryRun is set, applies them.
+//
+// The changes are applied with the same validations of the API calls that change each item, the tags first, so the
+// filters of the rules and keys can use them. Applying a policy that the namespace already matches changes nothing.
+func (s *service) ApplyPolicy(ctx context.Context, tenant string, policy *models.Policy, dryRun bool) (*models.PolicyDiff, error) {
+	if err := policy.Validate(); err != nil {
+		data, _ := validator.GetInvalidFieldsValues(err)
+
+		return nil, NewErrPolicyInvalid(data, nil)
+	}
+
+	if _, err := s.store.NamespaceGet(ctx, tenant); err != nil {
+		return nil, NewErrNamespaceNotFound(tenant, err)
+	}
+
+	var steps []policyStep
+	for _, diff := range []func(context.Context, string, *models.Policy) ([]policyStep, error){
+		s.diffPolicyTags,
+		s.diffPolicyPublicKeys,
+		s.diffPolicyFirewallRules,
+	} {
+		changes, err := diff(ctx, tenant, policy)
+		if err != nil {
+			return nil, err
+		}
+
+		steps = append(steps, changes...)
+	}
+
+	result := &models.PolicyDiff{Changes: make([]models.PolicyChange, 0, len(steps))}
+	for _, step := range steps {
+		result.Changes = append(result.Changes, step.change)
+	}
+
+	if dryRun {
+		return result, nil
+	}
+
+	for _, step := range steps {
+		if err := step.apply(); err != nil {
+			return nil, err
+		}
+	}
+
+	result.Applied = true
+
+	s.audit(ctx, tenant, models.AuditNamespacePolicy, tenant, nil, result)
+
+	return result, nil
+}
+
+// diffPolicyTags computes the changes to the tags of the namespace's devices. A device not listed has its tags removed.
+func (s *service) diffPolicyTags(ctx context.Context, tenant string, policy *models.Policy) ([]policyStep, error) {
+	if policy.Tags == nil {
+		return nil, nil
+	}
+
+	devices, err := s.listPolicyDevices(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool)
+	steps := make([]policyStep, 0)
+	for _, device := range devices {
+		device := device
+		names[device.Name] = true
+
+		tags := policy.Tags[device.Name]
+		if equalTags(device.Tags, tags) {
+			continue
+		}
+
+		steps = append(steps, policyStep{
+			change: models.PolicyChange{Action: models.PolicyChangeUpdate, Resource: models.PolicyResourceTags, ID: device.Name},
+			apply: func() error {
+				return s.UpdateDeviceTag(ctx, models.UID(device.UID), append([]string{}, tags...))
+			},
+		})
+	}
+
+	for name := range policy.Tags {
+		if !names[name] {
+			return nil, NewErrPolicyInvalid(map[string]interface{}{"Tags": name}, nil)
+		}
+	}
+
+	return steps, nil
+}
+
+// diffPolicyPublicKeys computes the changes to the namespace's public keys, identified by their fingerprints.
+func (s *service) diffPolicyPublicKeys(ctx context.Context, tenant string, policy *models.Policy) ([]policyStep, error) {
+	if policy.PublicKeys == nil {
+		return nil, nil
+	}
+
+	keys, err := s.listPolicyPublicKeys(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]*models.PublicKey)
+	for i := range keys {
+		existing[keys[i].Fingerprint] = &keys[i]
+	}
+
+	seen := make(map[string]bool)
+	steps := make([]policyStep, 0)
+	for _, key := range policy.PublicKeys {
+		key := key
+
+		pubKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(key.Data)) //nolint:dogsled
+		if err != nil {
+			return nil, NewErrPublicKeyDataInvalid([]byte(key.Data), nil)
+		}
+
+		fingerprint := ssh.FingerprintLegacyMD5(pubKey)
+		if seen[fingerprint] {
+			return nil, NewErrPublicKeyDuplicated([]string{fingerprint}, nil)
+		}
+
+		seen[fingerprint] = true
+
+		current, ok := existing[fingerprint]
+		switch {
+		case !ok:
+			steps = append(steps, policyStep{
+				change: models.PolicyChange{Action: models.PolicyChangeCreate, Resource: models.PolicyResourcePublicKey, ID: fingerprint},
+				apply: func() error {
+					return s.CreatePublicKey(ctx, &models.PublicKey{Data: []byte(key.Data), TenantID: tenant, PublicKeyFields: key.PublicKeyFields}, tenant)
+				},
+			})
+		case !equalJSON(current.PublicKeyFields, key.PublicKeyFields):
+			steps = append(steps, policyStep{
+				change: models.PolicyChange{Action: models.PolicyChangeUpdate, Resource: models.PolicyResourcePublicKey, ID: fingerprint},
+				apply: func() error {
+					_, err := s.UpdatePublicKey(ctx, fingerprint, tenant, &models.PublicKeyUpdate{PublicKeyFields: key.PublicKeyFields})
+
+					return err
+				},
+			})
+		}
+	}
+
+	for _, key := range keys {
+		if seen[key.Fingerprint] {
+			continue
+		}
+
+		fingerprint := key.Fingerprint
+		steps = append(steps, policyStep{
+			change: models.PolicyChange{Action: models.PolicyChangeDelete, Resource: models.PolicyResourcePublicKey, ID: fingerprint},
+			apply: func() error {
+				return s.DeletePublicKey(ctx, fingerprint, tenant)
+			},
+		})
+	}
+
+	return steps, nil
+}
+
+// diffPolicyFirewallRules computes the changes to the namespace's firewall rules.
+//
+// A rule of the policy is matched to the existing rule with its ID or, when it has no ID or its ID is not found, to an
+// existing rule with the same fields. The rules not matched are created and the existing rules not matched are deleted.
+func (s *service) diffPolicyFirewallRules(ctx context.Context, tenant string, policy *models.Policy) ([]policyStep, error) {
+	if policy.FirewallRules == nil {
+		return nil, nil
+	}
+
+	rules, err := s.listPolicyFirewallRules(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	matched := make(map[string]bool)
+	pending := make([]models.PolicyFirewallRule, 0)
+	steps := make([]policyStep, 0)
+	for _, rule := range policy.FirewallRules {
+		rule := rule
+
+		var current *models.FirewallRule
+		for i := range rules {
+			if rule.ID != "" && rules[i].ID == rule.ID && !matched[rule.ID] {
+				current = &rules[i]
+
+				break
+			}
+		}
+
+		if current == nil {
+			pending = append(pending, rule)
+
+			continue
+		}
+
+		matched[current.ID] = true
+
+		if !equalJSON(current.FirewallRuleFields, rule.FirewallRuleFields) {
+			steps = append(steps, policyStep{
+				change: models.PolicyChange{Action: models.PolicyChangeUpdate, Resource: models.PolicyResourceFirewallRule, ID: rule.ID},
+				apply: func() error {
+					_, err := s.UpdateFirewallRule(ctx, rule.ID, tenant, models.FirewallRuleUpdate{FirewallRuleFields: rule.FirewallRuleFields})
+
+					return err
+				},
+			})
+		}
+	}
+
+	for _, rule := range pending {
+		rule := rule
+
+		found := false
+		for i := range rules {
+			if !matched[rules[i].ID] && equalJSON(rules[i].FirewallRuleFields, rule.FirewallRuleFields) {
+				matched[rules[i].ID] = true
+				found = true
+
+				break
+			}
+		}
+
+		if found {
+			continue
+		}
+
+		steps = append(steps, policyStep{
+			change: models.PolicyChange{Action: models.PolicyChangeCreate, Resource: models.PolicyResourceFirewallRule},
+			apply: func() error {
+				_, err := s.CreateFirewallRule(ctx, tenant, rule.FirewallRuleFields)
+
+				return err
+			},
+		})
+	}
+
+	for _, rule := range rules {
+		if matched[rule.ID] {
+			continue
+		}
+
+		id := rule.ID
+		steps = append(steps, policyStep{
+			change: models.PolicyChange{Action: models.PolicyChangeDelete, Resource: models.PolicyResourceFirewallRule, ID: id},
+			apply: func() error {
+				return s.DeleteFirewallRule(ctx, id, tenant)
+			},
+		})
+	}
+
+	return steps, nil
+}
+
+func (s *service) listPolicyFirewallRules(ctx context.Context, tenant string) ([]models.FirewallRule, error) {
+	return s.store.FirewallRuleListByTenant(ctx, tenant)
+}
+
+func (s *service) listPolicyPublicKeys(ctx context.Context, tenant string) ([]models.PublicKey, error) {
+	return s.store.PublicKeyListByTenant(ctx, tenant)
+}
+
+func (s *service) listPolicyDevices(ctx context.Context, tenant string) ([]models.Device, error) {
+	filters := []models.Filter{
+		{
+			Type:   "property",
+			Params: &models.PropertyParams{Name: "tenant_id", Operator: "eq", Value: tenant},
+		},
+	}
+
+	devices, _, err := s.store.DeviceList(ctx, paginator.Query{Page: 1, PerPage: -1}, filters, "", "", "", nil)
+
+	return devices, err
+}
+
+// equalTags checks if two lists have the same tags, regardless of their order.
+func equalTags(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	a = append([]string{}, a...)
+	b = append([]string{}, b...)
+	sort.Strings(a)
+	sort.Strings(b)
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// equalJSON checks if two values have the same JSON representation.
+func equalJSON(a, b interface{}) bool {
+	x, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+
+	y, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+
+	return string(x) == string(y)
+}
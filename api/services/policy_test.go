@@ -0,0 +1,285 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	storecache "github.com/shellhub-io/shellhub/api/cache"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestExportPolicy(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	query := paginator.Query{Page: 1, PerPage: -1}
+	filters := []models.Filter{
+		{Type: "property", Params: &models.PropertyParams{Name: "tenant_id", Operator: "eq", Value: "tenant"}},
+	}
+	fields := models.FirewallRuleFields{Action: FirewallActionDeny, Active: true, SourceIP: "*", Username: ".*", Filter: models.FirewallFilter{Hostname: ".*"}}
+	keyFields := models.PublicKeyFields{Name: "key", Username: ".*", Filter: models.PublicKeyFilter{Tags: []string{"production"}}}
+	Err := errors.New("error", "", 0)
+
+	type Expected struct {
+		policy *models.Policy
+		err    error
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the namespace is not found",
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(nil, Err).Once()
+			},
+			expected: Expected{nil, NewErrNamespaceNotFound("tenant", Err)},
+		},
+		{
+			description: "succeeds",
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(&models.Namespace{TenantID: "tenant"}, nil).Once()
+				mock.On("FirewallRuleListByTenant", ctx, "tenant").Return([]models.FirewallRule{
+					{ID: "rule", TenantID: "tenant", FirewallRuleFields: fields},
+				}, nil).Once()
+				mock.On("PublicKeyListByTenant", ctx, "tenant").Return([]models.PublicKey{
+					{Data: []byte("data"), Fingerprint: "fingerprint", TenantID: "tenant", PublicKeyFields: keyFields},
+				}, nil).Once()
+				mock.On("DeviceList", ctx, query, filters, "", "", "", []models.UID(nil)).Return([]models.Device{
+					{UID: "uid", Name: "web", TenantID: "tenant", Tags: []string{"production"}},
+					{UID: "uid2", Name: "db", TenantID: "tenant"},
+				}, 2, nil).Once()
+			},
+			expected: Expected{
+				&models.Policy{
+					FirewallRules: []models.PolicyFirewallRule{{ID: "rule", FirewallRuleFields: fields}},
+					PublicKeys:    []models.PolicyPublicKey{{Data: "data", PublicKeyFields: keyFields}},
+					Tags:          map[string][]string{"web": {"production"}},
+				},
+				nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			policy, err := s.ExportPolicy(ctx, "tenant")
+			assert.Equal(t, tc.expected, Expected{policy, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestApplyPolicy(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	query := paginator.Query{Page: 1, PerPage: -1}
+	filters := []models.Filter{
+		{Type: "property", Params: &models.PropertyParams{Name: "tenant_id", Operator: "eq", Value: "tenant"}},
+	}
+	namespace := &models.Namespace{TenantID: "tenant"}
+
+	pubKey, _ := ssh.NewPublicKey(publicKey)
+	data := string(ssh.MarshalAuthorizedKey(pubKey))
+	fingerprint := ssh.FingerprintLegacyMD5(pubKey)
+
+	rule := func(username string) models.FirewallRuleFields {
+		return models.FirewallRuleFields{Action: FirewallActionDeny, Active: true, SourceIP: "*", Username: username, Filter: models.FirewallFilter{Hostname: ".*"}}
+	}
+	rules := []models.FirewallRule{
+		{ID: "updated", TenantID: "tenant", FirewallRuleFields: rule("^root$")},
+		{ID: "unchanged", TenantID: "tenant", FirewallRuleFields: rule("^admin$")},
+		{ID: "deleted", TenantID: "tenant", FirewallRuleFields: rule("^guest$")},
+	}
+	keyFields := models.PublicKeyFields{Name: "key", Username: ".*", Filter: models.PublicKeyFilter{Hostname: ".*"}}
+
+	type Expected struct {
+		diff *models.PolicyDiff
+		err  error
+	}
+
+	cases := []struct {
+		description   string
+		policy        *models.Policy
+		dryRun        bool
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the policy is invalid",
+			policy: &models.Policy{
+				FirewallRules: []models.PolicyFirewallRule{{FirewallRuleFields: models.FirewallRuleFields{
+					Action: "drop", SourceIP: "*", Username: ".*", Filter: models.FirewallFilter{Hostname: ".*"},
+				}}},
+			},
+			requiredMocks: func() {},
+			expected:      Expected{nil, NewErrPolicyInvalid(map[string]interface{}{"Action": "drop"}, nil)},
+		},
+		{
+			description: "fails when a device of the tags is not found",
+			policy:      &models.Policy{Tags: map[string][]string{"unknown": {"production"}}},
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				mock.On("DeviceList", ctx, query, filters, "", "", "", []models.UID(nil)).Return([]models.Device{}, 0, nil).Once()
+			},
+			expected: Expected{nil, NewErrPolicyInvalid(map[string]interface{}{"Tags": "unknown"}, nil)},
+		},
+		{
+			description: "fails when the public key data is invalid",
+			policy:      &models.Policy{PublicKeys: []models.PolicyPublicKey{{Data: "invalid", PublicKeyFields: keyFields}}},
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				mock.On("PublicKeyListByTenant", ctx, "tenant").Return([]models.PublicKey{}, nil).Once()
+			},
+			expected: Expected{nil, NewErrPublicKeyDataInvalid([]byte("invalid"), nil)},
+		},
+		{
+			description: "computes the changes without applying them on a dry run",
+			policy: &models.Policy{
+				FirewallRules: []models.PolicyFirewallRule{
+					{ID: "updated", FirewallRuleFields: rule("^ubuntu$")},
+					{FirewallRuleFields: rule("^admin$")},
+					{FirewallRuleFields: rule("^deploy$")},
+				},
+				PublicKeys: []models.PolicyPublicKey{{Data: data, PublicKeyFields: keyFields}},
+				Tags:       map[string][]string{"web": {"production"}},
+			},
+			dryRun: true,
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				mock.On("DeviceList", ctx, query, filters, "", "", "", []models.UID(nil)).Return([]models.Device{
+					{UID: "uid", Name: "web", TenantID: "tenant", Tags: []string{"production"}},
+					{UID: "uid2", Name: "db", TenantID: "tenant", Tags: []string{"staging"}},
+				}, 2, nil).Once()
+				mock.On("PublicKeyListByTenant", ctx, "tenant").Return([]models.PublicKey{
+					{Data: []byte("old"), Fingerprint: "old", TenantID: "tenant", PublicKeyFields: keyFields},
+				}, nil).Once()
+				mock.On("FirewallRuleListByTenant", ctx, "tenant").Return(rules, nil).Once()
+			},
+			expected: Expected{
+				&models.PolicyDiff{
+					Changes: []models.PolicyChange{
+						{Action: models.PolicyChangeUpdate, Resource: models.PolicyResourceTags, ID: "db"},
+						{Action: models.PolicyChangeCreate, Resource: models.PolicyResourcePublicKey, ID: fingerprint},
+						{Action: models.PolicyChangeDelete, Resource: models.PolicyResourcePublicKey, ID: "old"},
+						{Action: models.PolicyChangeUpdate, Resource: models.PolicyResourceFirewallRule, ID: "updated"},
+						{Action: models.PolicyChangeCreate, Resource: models.PolicyResourceFirewallRule},
+						{Action: models.PolicyChangeDelete, Resource: models.PolicyResourceFirewallRule, ID: "deleted"},
+					},
+					Applied: false,
+				},
+				nil,
+			},
+		},
+		{
+			description: "changes nothing when the namespace matches the policy",
+			policy: &models.Policy{
+				FirewallRules: []models.PolicyFirewallRule{
+					{FirewallRuleFields: rule("^guest$")},
+					{ID: "unchanged", FirewallRuleFields: rule("^admin$")},
+					{FirewallRuleFields: rule("^root$")},
+				},
+			},
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				mock.On("FirewallRuleListByTenant", ctx, "tenant").Return(rules, nil).Once()
+			},
+			expected: Expected{&models.PolicyDiff{Changes: []models.PolicyChange{}, Applied: true}, nil},
+		},
+		{
+			description: "applies the changes",
+			policy: &models.Policy{
+				FirewallRules: []models.PolicyFirewallRule{
+					{ID: "unchanged", FirewallRuleFields: rule("^admin$")},
+					{FirewallRuleFields: rule("^deploy$")},
+				},
+			},
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				mock.On("FirewallRuleListByTenant", ctx, "tenant").Return(rules[1:], nil).Once()
+				mock.On("FirewallRuleCreate", ctx, &models.FirewallRule{TenantID: "tenant", FirewallRuleFields: rule("^deploy$")}).
+					Return(nil).Once()
+				mock.On("FirewallRuleGet", ctx, "deleted").Return(&rules[2], nil).Once()
+				mock.On("FirewallRuleDelete", ctx, "deleted").Return(nil).Once()
+			},
+			expected: Expected{
+				&models.PolicyDiff{
+					Changes: []models.PolicyChange{
+						{Action: models.PolicyChangeCreate, Resource: models.PolicyResourceFirewallRule},
+						{Action: models.PolicyChangeDelete, Resource: models.PolicyResourceFirewallRule, ID: "deleted"},
+					},
+					Applied: true,
+				},
+				nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			diff, err := s.ApplyPolicy(ctx, "tenant", tc.policy, tc.dryRun)
+			assert.Equal(t, tc.expected, Expected{diff, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestApplyPolicyTwice(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	namespace := &models.Namespace{TenantID: "tenant"}
+	fields := models.FirewallRuleFields{Action: FirewallActionDeny, Active: true, SourceIP: "*", Username: ".*", Filter: models.FirewallFilter{Hostname: ".*"}}
+
+	scheduled := fields
+	scheduled.Schedule = &models.FirewallSchedule{Weekdays: []string{"monday"}}
+	scheduled.SessionTypes = []string{models.SessionTypeExec}
+	scheduled.Commands = []string{"uptime"}
+	scheduled.Countries = []string{"BR"}
+
+	current := models.FirewallRule{ID: "rule", TenantID: "tenant", FirewallRuleFields: scheduled}
+	updated := models.FirewallRule{ID: "rule", TenantID: "tenant", FirewallRuleFields: fields}
+
+	// The policy removes the rule's schedule, session types, commands and countries.
+	policy := &models.Policy{FirewallRules: []models.PolicyFirewallRule{{ID: "rule", FirewallRuleFields: fields}}}
+
+	mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Twice()
+	mock.On("FirewallRuleListByTenant", ctx, "tenant").Return([]models.FirewallRule{current}, nil).Once()
+	mock.On("FirewallRuleGet", ctx, "rule").Return(&current, nil).Once()
+	mock.On("FirewallRuleUpdate", ctx, "rule", models.FirewallRuleUpdate{FirewallRuleFields: fields}).Return(&updated, nil).Once()
+
+	diff, err := s.ApplyPolicy(ctx, "tenant", policy, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.PolicyChange{
+		{Action: models.PolicyChangeUpdate, Resource: models.PolicyResourceFirewallRule, ID: "rule"},
+	}, diff.Changes)
+
+	mock.On("FirewallRuleListByTenant", ctx, "tenant").Return([]models.FirewallRule{updated}, nil).Once()
+
+	diff, err = s.ApplyPolicy(ctx, "tenant", policy, false)
+	assert.NoError(t, err)
+	assert.Empty(t, diff.Changes)
+
+	mock.AssertExpectations(t)
+}
@@ -0,0 +1,186 @@
+package services
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/validator"
+	"github.com/sirupsen/logrus"
+)
+
+type RoleService interface {
+	ListRoles(ctx context.Context, pagination paginator.Query) ([]models.Role, int, error)
+	GetRole(ctx context.Context, id, tenant string) (*models.Role, error)
+	CreateRole(ctx context.Context, role *models.Role, tenant string) error
+	UpdateRole(ctx context.Context, id, tenant string, role *models.RoleUpdate) (*models.Role, error)
+	DeleteRole(ctx context.Context, id, tenant string) error
+	GetRolePermissions(ctx context.Context, tenant, name string) (guard.Permissions, bool)
+}
+
+func (s *service) ListRoles(ctx context.Context, pagination paginator.Query) ([]models.Role, int, error) {
+	return s.store.RoleList(ctx, pagination)
+}
+
+func (s *service) GetRole(ctx context.Context, id, tenant string) (*models.Role, error) {
+	role, err := s.store.RoleGet(ctx, tenant, id)
+	if err != nil {
+		return nil, NewErrRoleNotFound(id, err)
+	}
+
+	return role, nil
+}
+
+// validateRole checks the fields of a custom role. Its name cannot be one of the built-in roles and its permissions
+// must be the names of actions that can be granted to a custom role.
+func validateRole(role *models.RoleFields) error {
+	if err := role.Validate(); err != nil {
+		data, _ := validator.GetInvalidFieldsValues(err)
+
+		return NewErrRoleInvalid(data, nil)
+	}
+
+	if _, ok := guard.Roles[role.Name]; ok {
+		return NewErrRoleInvalid(map[string]interface{}{"Name": role.Name}, nil)
+	}
+
+	if _, ok := guard.GetCustomRolePermissions(role.Permissions); !ok {
+		return NewErrRoleInvalid(map[string]interface{}{"Permissions": role.Permissions}, nil)
+	}
+
+	return nil
+}
+
+func (s *service) CreateRole(ctx context.Context, role *models.Role, tenant string) error {
+	if err := validateRole(&role.RoleFields); err != nil {
+		return err
+	}
+
+	role.TenantID = tenant
+	role.CreatedAt = clock.Now()
+
+	if err := s.store.RoleCreate(ctx, role); err != nil {
+		if err == store.ErrDuplicate {
+			return NewErrRoleDuplicated(role.Name, err)
+		}
+
+		return err
+	}
+
+	s.audit(ctx, tenant, models.AuditRoleCreate, role.ID, nil, role)
+
+	return nil
+}
+
+// UpdateRole updates a custom role of a namespace. The new permissions apply to its members on their next request.
+//
+// As the members reference a custom role by its name, a role assigned to members cannot be renamed.
+func (s *service) UpdateRole(ctx context.Context, id, tenant string, role *models.RoleUpdate) (*models.Role, error) {
+	if err := validateRole(&role.RoleFields); err != nil {
+		return nil, err
+	}
+
+	current, err := s.store.RoleGet(ctx, tenant, id)
+	if err != nil {
+		return nil, NewErrRoleNotFound(id, err)
+	}
+
+	if current.Name != role.Name {
+		if err := s.checkRoleUnassigned(ctx, tenant, current.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	updated, err := s.store.RoleUpdate(ctx, tenant, id, role)
+	switch err {
+	case nil:
+		s.audit(ctx, tenant, models.AuditRoleUpdate, id, current, updated)
+
+		return updated, nil
+	case store.ErrDuplicate:
+		return nil, NewErrRoleDuplicated(role.Name, err)
+	case store.ErrNoDocuments, store.ErrInvalidHex:
+		return nil, NewErrRoleNotFound(id, err)
+	default:
+		return nil, err
+	}
+}
+
+// DeleteRole deletes a custom role of a namespace, which cannot be assigned to any member.
+func (s *service) DeleteRole(ctx context.Context, id, tenant string) error {
+	role, err := s.store.RoleGet(ctx, tenant, id)
+	if err != nil {
+		return NewErrRoleNotFound(id, err)
+	}
+
+	if err := s.checkRoleUnassigned(ctx, tenant, role.Name); err != nil {
+		return err
+	}
+
+	if err := s.store.RoleDelete(ctx, tenant, id); err != nil {
+		return NewErrRoleNotFound(id, err)
+	}
+
+	s.audit(ctx, tenant, models.AuditRoleRemove, id, role, nil)
+
+	return nil
+}
+
+// GetRolePermissions gets the permissions of a custom role of a namespace, returning false when it does not exist. It
+// resolves the custom roles for guard.EvaluatePermission.
+func (s *service) GetRolePermissions(ctx context.Context, tenant, name string) (guard.Permissions, bool) {
+	role, err := s.store.RoleGetByName(ctx, tenant, name)
+	if err != nil {
+		if err != store.ErrNoDocuments {
+			logrus.WithError(err).WithFields(logrus.Fields{"tenant_id": tenant, "role": name}).Error("failed to get the custom role")
+		}
+
+		return nil, false
+	}
+
+	return guard.GetCustomRolePermissions(role.Permissions)
+}
+
+// checkRoleUnassigned checks that no member of the namespace has a custom role.
+func (s *service) checkRoleUnassigned(ctx context.Context, tenant, name string) error {
+	namespace, err := s.store.NamespaceGet(ctx, tenant)
+	if err != nil {
+		return NewErrNamespaceNotFound(tenant, err)
+	}
+
+	for _, member := range namespace.Members {
+		if member.Role == name {
+			return NewErrRoleInUse(name, nil)
+		}
+	}
+
+	return nil
+}
+
+// validateMemberRole checks if a role can be assigned to a member of the namespace, being a built-in role or one of its
+// custom roles.
+func (s *service) validateMemberRole(ctx context.Context, tenant, role string) error {
+	if _, ok := guard.Roles[role]; ok {
+		return nil
+	}
+
+	if _, err := s.store.RoleGetByName(ctx, tenant, role); err != nil {
+		return NewErrRoleNotFound(role, err)
+	}
+
+	return nil
+}
+
+// checkMemberRole checks if a member with the active role can act over a member with the passive role, or assign the
+// passive role to another member. As the custom roles are not ranked, only the administrators and the owner act over
+// the members with a custom role, and a member with a custom role cannot act over the other members.
+func checkMemberRole(active, passive string) bool {
+	if _, ok := guard.Roles[passive]; ok {
+		return guard.CheckRole(active, passive)
+	}
+
+	return guard.GetRoleCode(active) >= guard.RoleAdministratorCode
+}
@@ -0,0 +1,205 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	storecache "github.com/shellhub-io/shellhub/api/cache"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateRole(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		description   string
+		role          *models.Role
+		requiredMocks func(role *models.Role)
+		expected      error
+	}{
+		{
+			description:   "fails when the name is of a built-in role",
+			role:          &models.Role{RoleFields: models.RoleFields{Name: guard.RoleAdministrator, Permissions: []string{"session_play"}}},
+			requiredMocks: func(_ *models.Role) {},
+			expected:      NewErrRoleInvalid(map[string]interface{}{"Name": guard.RoleAdministrator}, nil),
+		},
+		{
+			description:   "fails when a permission cannot be granted to a custom role",
+			role:          &models.Role{RoleFields: models.RoleFields{Name: "support", Permissions: []string{"session_play", "namespace_delete"}}},
+			requiredMocks: func(_ *models.Role) {},
+			expected: NewErrRoleInvalid(map[string]interface{}{
+				"Permissions": []string{"session_play", "namespace_delete"},
+			}, nil),
+		},
+		{
+			description: "fails when the name is duplicated",
+			role:        &models.Role{RoleFields: models.RoleFields{Name: "support", Permissions: []string{"session_play"}}},
+			requiredMocks: func(role *models.Role) {
+				clockMock.On("Now").Return(now).Once()
+				mock.On("RoleCreate", ctx, role).Return(store.ErrDuplicate).Once()
+			},
+			expected: NewErrRoleDuplicated("support", store.ErrDuplicate),
+		},
+		{
+			description: "succeeds",
+			role:        &models.Role{RoleFields: models.RoleFields{Name: "support", Permissions: []string{"session_play"}}},
+			requiredMocks: func(role *models.Role) {
+				clockMock.On("Now").Return(now).Once()
+				mock.On("RoleCreate", ctx, role).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks(tc.role)
+
+			err := s.CreateRole(ctx, tc.role, "tenant")
+			assert.Equal(t, tc.expected, err)
+
+			if err == nil {
+				assert.Equal(t, "tenant", tc.role.TenantID)
+				assert.Equal(t, now, tc.role.CreatedAt)
+			}
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestUpdateRole(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	role := &models.Role{ID: "id", TenantID: "tenant", RoleFields: models.RoleFields{Name: "support", Permissions: []string{"session_play"}}}
+	namespace := &models.Namespace{TenantID: "tenant", Members: []models.Member{{ID: "user", Role: "support"}}}
+
+	renamed := &models.RoleUpdate{RoleFields: models.RoleFields{Name: "helpdesk", Permissions: []string{"session_play"}}}
+	granted := &models.RoleUpdate{RoleFields: models.RoleFields{Name: "support", Permissions: []string{"session_play", "device_connect"}}}
+
+	type Expected struct {
+		role *models.Role
+		err  error
+	}
+
+	cases := []struct {
+		description   string
+		update        *models.RoleUpdate
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the role is not found",
+			update:      granted,
+			requiredMocks: func() {
+				mock.On("RoleGet", ctx, "tenant", "id").Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{nil, NewErrRoleNotFound("id", store.ErrNoDocuments)},
+		},
+		{
+			description: "fails when a role assigned to members is renamed",
+			update:      renamed,
+			requiredMocks: func() {
+				mock.On("RoleGet", ctx, "tenant", "id").Return(role, nil).Once()
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+			},
+			expected: Expected{nil, NewErrRoleInUse("support", nil)},
+		},
+		{
+			description: "succeeds",
+			update:      granted,
+			requiredMocks: func() {
+				mock.On("RoleGet", ctx, "tenant", "id").Return(role, nil).Once()
+				mock.On("RoleUpdate", ctx, "tenant", "id", granted).
+					Return(&models.Role{ID: "id", TenantID: "tenant", RoleFields: granted.RoleFields}, nil).Once()
+			},
+			expected: Expected{&models.Role{ID: "id", TenantID: "tenant", RoleFields: granted.RoleFields}, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			role, err := s.UpdateRole(ctx, "id", "tenant", tc.update)
+			assert.Equal(t, tc.expected, Expected{role, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestDeleteRole(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	role := &models.Role{ID: "id", TenantID: "tenant", RoleFields: models.RoleFields{Name: "support", Permissions: []string{"session_play"}}}
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the role is assigned to members",
+			requiredMocks: func() {
+				mock.On("RoleGet", ctx, "tenant", "id").Return(role, nil).Once()
+				mock.On("NamespaceGet", ctx, "tenant").
+					Return(&models.Namespace{TenantID: "tenant", Members: []models.Member{{ID: "user", Role: "support"}}}, nil).Once()
+			},
+			expected: NewErrRoleInUse("support", nil),
+		},
+		{
+			description: "succeeds",
+			requiredMocks: func() {
+				mock.On("RoleGet", ctx, "tenant", "id").Return(role, nil).Once()
+				mock.On("NamespaceGet", ctx, "tenant").
+					Return(&models.Namespace{TenantID: "tenant", Members: []models.Member{{ID: "user", Role: guard.RoleOwner}}}, nil).Once()
+				mock.On("RoleDelete", ctx, "tenant", "id").Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			assert.Equal(t, tc.expected, s.DeleteRole(ctx, "id", "tenant"))
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestGetRolePermissions(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	mock.On("RoleGetByName", ctx, "tenant", "support").
+		Return(&models.Role{RoleFields: models.RoleFields{Name: "support", Permissions: []string{"session_play"}}}, nil).Once()
+	mock.On("RoleGetByName", ctx, "tenant", "unknown").Return(nil, store.ErrNoDocuments).Once()
+
+	permissions, ok := s.GetRolePermissions(ctx, "tenant", "support")
+	assert.True(t, ok)
+	assert.Equal(t, guard.Permissions{guard.SessionPlay}, permissions)
+
+	_, ok = s.GetRolePermissions(ctx, "tenant", "unknown")
+	assert.False(t, ok)
+
+	mock.AssertExpectations(t)
+}
@@ -2,8 +2,11 @@ package services
 
 import (
 	"crypto/rsa"
+	"net/http"
 
 	"github.com/shellhub-io/shellhub/api/cache"
+	"github.com/shellhub-io/shellhub/api/pkg/exporter"
+	"github.com/shellhub-io/shellhub/api/pkg/mailer"
 	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/pkg/geoip"
 )
@@ -15,22 +18,52 @@ type service struct {
 	cache   cache.Cache
 	client  interface{}
 	locator geoip.Locator
+	lockout LoginLockout
+	// notifyLockout is called when a username or a source IP address is locked out.
+	notifyLockout LockoutNotifier
+	// webhooks enqueues the deliveries of the events to the webhooks. The events are not delivered when it is nil.
+	webhooks      WebhookQueue
+	webhookClient *http.Client
+	// mailer sends the invitations, answered on the page at invitationURL.
+	mailer        mailer.Mailer
+	invitationURL string
+	// auditLog enables the records of the mutating actions on the audit log.
+	auditLog bool
+	// exporter streams the events to external systems. The events are not exported when it is nil.
+	exporter exporter.Exporter
 }
 
+// Option configures an optional behavior of the service.
+type Option func(*service)
+
 type Service interface {
 	TagsService
 	DeviceService
 	DeviceTags
+	DeviceGroupService
 	UserService
 	SSHKeysService
 	SSHKeysTagsService
+	FirewallService
+	IPSetService
+	RoleService
+	InvitationService
+	AuditService
+	WebhookService
+	PolicyService
+	GeoAccessService
+	SessionTypesService
+	MemberScopeService
+	SSHLimitService
 	SessionService
+	SessionApprovalService
 	NamespaceService
 	AuthService
+	LoginLockoutService
 	StatsService
 }
 
-func NewService(store store.Store, privKey *rsa.PrivateKey, pubKey *rsa.PublicKey, cache cache.Cache, c interface{}, l geoip.Locator) Service {
+func NewService(store store.Store, privKey *rsa.PrivateKey, pubKey *rsa.PublicKey, cache cache.Cache, c interface{}, l geoip.Locator, opts ...Option) Service {
 	if privKey == nil || pubKey == nil {
 		var err error
 		privKey, pubKey, err = LoadKeys()
@@ -39,7 +72,24 @@ func NewService(store store.Store, privKey *rsa.PrivateKey, pubKey *rsa.PublicKe
 		}
 	}
 
-	return &service{store, privKey, pubKey, cache, c, l}
+	s := &service{
+		store:         store,
+		privKey:       privKey,
+		pubKey:        pubKey,
+		cache:         cache,
+		client:        c,
+		locator:       l,
+		lockout:       DefaultLoginLockout,
+		notifyLockout: logLockout,
+		webhookClient: newWebhookClient(),
+		mailer:        mailer.NewNullMailer(),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
 }
 
 // FIXME: private function.
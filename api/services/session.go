@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 
+	"github.com/shellhub-io/shellhub/api/pkg/exporter"
 	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/pkg/api/paginator"
 	"github.com/shellhub-io/shellhub/pkg/models"
@@ -26,7 +27,17 @@ func (s *service) GetSession(ctx context.Context, uid models.UID) (*models.Sessi
 }
 
 func (s *service) CreateSession(ctx context.Context, session models.Session) (*models.Session, error) {
-	return s.store.SessionCreate(ctx, session)
+	session.Country = s.resolveCountry(session.IPAddress)
+
+	created, err := s.store.SessionCreate(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	s.dispatchWebhookEvent(ctx, created.TenantID, models.WebhookEventSessionStarted, created)
+	s.export(ctx, exporter.EventSessionStarted, created.TenantID, created)
+
+	return created, nil
 }
 
 func (s *service) DeactivateSession(ctx context.Context, uid models.UID) error {
@@ -35,7 +46,19 @@ func (s *service) DeactivateSession(ctx context.Context, uid models.UID) error {
 		return NewErrSessionNotFound(uid, err)
 	}
 
-	return err
+	if err != nil {
+		return err
+	}
+
+	// The session is looked up only when its event can be delivered or exported.
+	if s.webhooks != nil || s.exporter != nil {
+		if session, err := s.store.SessionGet(ctx, uid); err == nil {
+			s.dispatchWebhookEvent(ctx, session.TenantID, models.WebhookEventSessionFinished, session)
+			s.export(ctx, exporter.EventSessionFinished, session.TenantID, session)
+		}
+	}
+
+	return nil
 }
 
 func (s *service) KeepAliveSession(ctx context.Context, uid models.UID) error {
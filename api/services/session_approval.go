@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/validator"
+)
+
+type SessionApprovalService interface {
+	ListSessionApprovals(ctx context.Context, pagination paginator.Query) ([]models.SessionApproval, int, error)
+	GetSessionApproval(ctx context.Context, uid models.UID, tenant string) (*models.SessionApproval, error)
+	CreateSessionApproval(ctx context.Context, approval *models.SessionApproval) error
+	DecideSessionApproval(ctx context.Context, uid models.UID, tenant, userID string, approved bool) (*models.SessionApproval, error)
+}
+
+func (s *service) ListSessionApprovals(ctx context.Context, pagination paginator.Query) ([]models.SessionApproval, int, error) {
+	return s.store.SessionApprovalList(ctx, pagination)
+}
+
+// GetSessionApproval gets a session approval. When the tenant is set, the approval must belong to its namespace.
+func (s *service) GetSessionApproval(ctx context.Context, uid models.UID, tenant string) (*models.SessionApproval, error) {
+	approval, err := s.store.SessionApprovalGet(ctx, uid)
+	if err != nil {
+		return nil, NewErrSessionApprovalNotFound(uid, err)
+	}
+
+	if tenant != "" && approval.TenantID != tenant {
+		return nil, NewErrSessionApprovalNotFound(uid, nil)
+	}
+
+	return approval, nil
+}
+
+// CreateSessionApproval holds a session to a device until a member of the device's namespace decides about it.
+func (s *service) CreateSessionApproval(ctx context.Context, approval *models.SessionApproval) error {
+	if data, err := validator.ValidateStructFields(approval); err != nil {
+		return NewErrSessionApprovalInvalid(data, nil)
+	}
+
+	device, err := s.store.DeviceGet(ctx, approval.DeviceUID)
+	if err != nil {
+		return NewErrDeviceNotFound(approval.DeviceUID, err)
+	}
+
+	approval.TenantID = device.TenantID
+	approval.Status = models.SessionApprovalPending
+	approval.CreatedAt = clock.Now()
+	approval.DecidedBy = ""
+	approval.DecidedAt = nil
+
+	return s.store.SessionApprovalCreate(ctx, approval)
+}
+
+// DecideSessionApproval approves or denies a pending session approval of a namespace. An approval can be decided only
+// once and only until it expires.
+func (s *service) DecideSessionApproval(ctx context.Context, uid models.UID, tenant, userID string, approved bool) (*models.SessionApproval, error) {
+	approval, err := s.GetSessionApproval(ctx, uid, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	now := clock.Now()
+	if approval.Status != models.SessionApprovalPending || !now.Before(approval.ExpiresAt) {
+		return nil, NewErrSessionApprovalDecided(uid, nil)
+	}
+
+	status := models.SessionApprovalDenied
+	if approved {
+		status = models.SessionApprovalApproved
+	}
+
+	decided, err := s.store.SessionApprovalDecide(ctx, uid, status, userID, now)
+	switch err {
+	case nil:
+		s.audit(ctx, tenant, models.AuditSessionApprovalDecide, string(uid), approval, decided)
+
+		return decided, nil
+	case store.ErrNoDocuments:
+		// The approval was decided by someone else after it was got.
+		return nil, NewErrSessionApprovalDecided(uid, err)
+	default:
+		return nil, err
+	}
+}
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	storecache "github.com/shellhub-io/shellhub/api/cache"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateSessionApproval(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		description   string
+		approval      *models.SessionApproval
+		requiredMocks func(approval *models.SessionApproval)
+		expected      error
+	}{
+		{
+			description:   "fails when the expiration is not set",
+			approval:      &models.SessionApproval{UID: "uid", DeviceUID: "device"},
+			requiredMocks: func(_ *models.SessionApproval) {},
+			expected:      NewErrSessionApprovalInvalid(map[string]interface{}{"ExpiresAt": time.Time{}}, nil),
+		},
+		{
+			description: "fails when the device is not found",
+			approval:    &models.SessionApproval{UID: "uid", DeviceUID: "device", ExpiresAt: now.Add(time.Minute)},
+			requiredMocks: func(_ *models.SessionApproval) {
+				mock.On("DeviceGet", ctx, models.UID("device")).Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: NewErrDeviceNotFound("device", store.ErrNoDocuments),
+		},
+		{
+			description: "succeeds",
+			approval: &models.SessionApproval{
+				UID:       "uid",
+				DeviceUID: "device",
+				Status:    models.SessionApprovalApproved,
+				ExpiresAt: now.Add(time.Minute),
+			},
+			requiredMocks: func(approval *models.SessionApproval) {
+				clockMock.On("Now").Return(now).Once()
+				mock.On("DeviceGet", ctx, models.UID("device")).Return(&models.Device{UID: "device", TenantID: "tenant"}, nil).Once()
+				mock.On("SessionApprovalCreate", ctx, approval).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks(tc.approval)
+
+			err := s.CreateSessionApproval(ctx, tc.approval)
+			assert.Equal(t, tc.expected, err)
+
+			if err == nil {
+				assert.Equal(t, "tenant", tc.approval.TenantID)
+				assert.Equal(t, models.SessionApprovalPending, tc.approval.Status)
+				assert.Equal(t, now, tc.approval.CreatedAt)
+			}
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestDecideSessionApproval(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	pending := &models.SessionApproval{
+		UID:       "uid",
+		TenantID:  "tenant",
+		Status:    models.SessionApprovalPending,
+		ExpiresAt: now.Add(time.Minute),
+	}
+
+	approved := &models.SessionApproval{
+		UID:       "uid",
+		TenantID:  "tenant",
+		Status:    models.SessionApprovalApproved,
+		ExpiresAt: now.Add(time.Minute),
+		DecidedBy: "user",
+		DecidedAt: &now,
+	}
+
+	type Expected struct {
+		approval *models.SessionApproval
+		err      error
+	}
+
+	cases := []struct {
+		description   string
+		tenant        string
+		approved      bool
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the approval is not found",
+			tenant:      "tenant",
+			requiredMocks: func() {
+				mock.On("SessionApprovalGet", ctx, models.UID("uid")).Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{nil, NewErrSessionApprovalNotFound("uid", store.ErrNoDocuments)},
+		},
+		{
+			description: "fails when the approval belongs to another namespace",
+			tenant:      "other",
+			requiredMocks: func() {
+				mock.On("SessionApprovalGet", ctx, models.UID("uid")).Return(pending, nil).Once()
+			},
+			expected: Expected{nil, NewErrSessionApprovalNotFound("uid", nil)},
+		},
+		{
+			description: "fails when the approval was already decided",
+			tenant:      "tenant",
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				mock.On("SessionApprovalGet", ctx, models.UID("uid")).Return(approved, nil).Once()
+			},
+			expected: Expected{nil, NewErrSessionApprovalDecided("uid", nil)},
+		},
+		{
+			description: "fails when the approval expired",
+			tenant:      "tenant",
+			requiredMocks: func() {
+				expired := *pending
+				expired.ExpiresAt = now.Add(-time.Minute)
+
+				clockMock.On("Now").Return(now).Once()
+				mock.On("SessionApprovalGet", ctx, models.UID("uid")).Return(&expired, nil).Once()
+			},
+			expected: Expected{nil, NewErrSessionApprovalDecided("uid", nil)},
+		},
+		{
+			description: "fails when the approval is decided concurrently",
+			tenant:      "tenant",
+			approved:    true,
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				mock.On("SessionApprovalGet", ctx, models.UID("uid")).Return(pending, nil).Once()
+				mock.On("SessionApprovalDecide", ctx, models.UID("uid"), models.SessionApprovalApproved, "user", now).
+					Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{nil, NewErrSessionApprovalDecided("uid", store.ErrNoDocuments)},
+		},
+		{
+			description: "succeeds",
+			tenant:      "tenant",
+			approved:    true,
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				mock.On("SessionApprovalGet", ctx, models.UID("uid")).Return(pending, nil).Once()
+				mock.On("SessionApprovalDecide", ctx, models.UID("uid"), models.SessionApprovalApproved, "user", now).
+					Return(approved, nil).Once()
+			},
+			expected: Expected{approved, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			approval, err := s.DecideSessionApproval(ctx, "uid", tc.tenant, "user", tc.approved)
+			assert.Equal(t, tc.expected, Expected{approval, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -3,12 +3,14 @@ package services
 import (
 	"context"
 	"errors"
+	"net"
 	"testing"
 
 	storecache "github.com/shellhub-io/shellhub/api/cache"
 	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/api/store/mocks"
 	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	mocksGeoIp "github.com/shellhub-io/shellhub/pkg/geoip/mocks"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/stretchr/testify/assert"
 )
@@ -140,8 +142,9 @@ func TestGetSession(t *testing.T) {
 }
 
 func TestCreateSession(t *testing.T) {
+	locator := &mocksGeoIp.Locator{}
 	mock := &mocks.Store{}
-	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, locator)
 
 	ctx := context.TODO()
 
@@ -151,6 +154,7 @@ func TestCreateSession(t *testing.T) {
 	}
 
 	session := models.Session{UID: "uid"}
+	located := models.Session{UID: "uid", IPAddress: "8.8.8.8", Country: "US"}
 
 	Err := errors.New("error")
 
@@ -184,6 +188,19 @@ func TestCreateSession(t *testing.T) {
 				err:     nil,
 			},
 		},
+		{
+			name:    "CreateSession stores the country of the IP address",
+			session: models.Session{UID: "uid", IPAddress: "8.8.8.8"},
+			requiredMocks: func() {
+				locator.On("GetCountry", net.ParseIP("8.8.8.8")).Return("US", nil).Once()
+				mock.On("SessionCreate", ctx, located).
+					Return(&located, nil).Once()
+			},
+			expected: Expected{
+				session: &located,
+				err:     nil,
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -195,6 +212,7 @@ func TestCreateSession(t *testing.T) {
 	}
 
 	mock.AssertExpectations(t)
+	locator.AssertExpectations(t)
 }
 
 func TestDeactivateSession(t *testing.T) {
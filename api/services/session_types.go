@@ -0,0 +1,55 @@
+package services
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type SessionTypesService interface {
+	EditSessionTypes(ctx context.Context, tenantID string, types *models.SessionTypes) error
+	EvaluateSessionType(ctx context.Context, uid models.UID, kind string) error
+}
+
+// EditSessionTypes sets the session types, and the forwardings, enabled on a namespace's devices.
+//
+// Nil types enable every session type again. It can return an error if the namespace is not found,
+// NewErrNamespaceNotFound.
+func (s *service) EditSessionTypes(ctx context.Context, tenantID string, types *models.SessionTypes) error {
+	before := s.auditSettings(ctx, tenantID)
+
+	if err := s.store.NamespaceSetSessionTypes(ctx, tenantID, types); err != nil {
+		return NewErrNamespaceNotFound(tenantID, err)
+	}
+
+	s.audit(ctx, tenantID, models.AuditNamespaceSessionTypes, tenantID, before, s.auditSettings(ctx, tenantID))
+
+	return nil
+}
+
+// EvaluateSessionType checks if a session type, a models.SessionType constant, or a forwarding,
+// models.SessionPortForwarding or models.SessionAgentForwarding, is enabled on the namespace of a device.
+//
+// It returns NewErrDeviceNotFound when the device does not exist and NewErrSessionTypeDenied when the namespace
+// disables the session type.
+func (s *service) EvaluateSessionType(ctx context.Context, uid models.UID, kind string) error {
+	device, err := s.store.DeviceGet(ctx, uid)
+	if err != nil {
+		return NewErrDeviceNotFound(uid, err)
+	}
+
+	namespace, err := s.store.NamespaceGet(ctx, device.TenantID)
+	if err != nil {
+		return NewErrNamespaceNotFound(device.TenantID, err)
+	}
+
+	if namespace.Settings == nil || namespace.Settings.SessionTypes == nil {
+		return nil
+	}
+
+	if !namespace.Settings.SessionTypes.Allows(kind) {
+		return NewErrSessionTypeDenied(kind, nil)
+	}
+
+	return nil
+}
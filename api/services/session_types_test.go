@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	storecache "github.com/shellhub-io/shellhub/api/cache"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEditSessionTypes(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	types := &models.SessionTypes{Term: true, Exec: true, Web: true}
+	Err := errors.New("error", "", 0)
+
+	cases := []struct {
+		description   string
+		types         *models.SessionTypes
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the namespace is not found",
+			types:       types,
+			requiredMocks: func() {
+				mock.On("NamespaceSetSessionTypes", ctx, "tenant", types).Return(Err).Once()
+			},
+			expected: NewErrNamespaceNotFound("tenant", Err),
+		},
+		{
+			description: "succeeds",
+			types:       types,
+			requiredMocks: func() {
+				mock.On("NamespaceSetSessionTypes", ctx, "tenant", types).Return(nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "enables every session type",
+			types:       nil,
+			requiredMocks: func() {
+				mock.On("NamespaceSetSessionTypes", ctx, "tenant", (*models.SessionTypes)(nil)).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			err := s.EditSessionTypes(ctx, "tenant", tc.types)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestEvaluateSessionType(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	device := &models.Device{UID: "uid", TenantID: "tenant"}
+	namespace := func(types *models.SessionTypes) *models.Namespace {
+		return &models.Namespace{TenantID: "tenant", Settings: &models.NamespaceSettings{SessionTypes: types}}
+	}
+	staging := &models.SessionTypes{Term: true, Exec: true, SCP: true, Web: true, PortForwarding: true, AgentForwarding: true}
+	production := &models.SessionTypes{Term: true, Exec: true, Web: true}
+	Err := errors.New("error", "", 0)
+
+	cases := []struct {
+		description   string
+		kind          string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the device is not found",
+			kind:        models.SessionTypeSCP,
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("uid")).Return(nil, Err).Once()
+			},
+			expected: NewErrDeviceNotFound("uid", Err),
+		},
+		{
+			description: "fails when the namespace is not found",
+			kind:        models.SessionTypeSCP,
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("uid")).Return(device, nil).Once()
+				mock.On("NamespaceGet", ctx, "tenant").Return(nil, Err).Once()
+			},
+			expected: NewErrNamespaceNotFound("tenant", Err),
+		},
+		{
+			description: "allows when the namespace has no session types",
+			kind:        models.SessionTypeSCP,
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("uid")).Return(device, nil).Once()
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace(nil), nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "allows when the session type is enabled",
+			kind:        models.SessionTypeSCP,
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("uid")).Return(device, nil).Once()
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace(staging), nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "denies when the session type is disabled",
+			kind:        models.SessionTypeSCP,
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("uid")).Return(device, nil).Once()
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace(production), nil).Once()
+			},
+			expected: NewErrSessionTypeDenied(models.SessionTypeSCP, nil),
+		},
+		{
+			description: "denies an unknown session type",
+			kind:        "",
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("uid")).Return(device, nil).Once()
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace(staging), nil).Once()
+			},
+			expected: NewErrSessionTypeDenied("", nil),
+		},
+		{
+			description: "denies when the forwarding is disabled",
+			kind:        models.SessionAgentForwarding,
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("uid")).Return(device, nil).Once()
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace(production), nil).Once()
+			},
+			expected: NewErrSessionTypeDenied(models.SessionAgentForwarding, nil),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			err := s.EvaluateSessionType(ctx, "uid", tc.kind)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -0,0 +1,249 @@
+package services
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/exporter"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/validator"
+)
+
+const (
+	// SSHLimitIPAddressFailures is the number of failed attempts from a source IP address before it is banned.
+	SSHLimitIPAddressFailures = 5
+	// SSHLimitDeviceFailures is the number of failed attempts to a device, from any source, before it is banned.
+	SSHLimitDeviceFailures = 20
+	// SSHLimitBackoff is the delay after the first failed attempt, doubled on each following failure.
+	SSHLimitBackoff = time.Second
+	// SSHLimitBan is the duration of the first ban, doubled on each following ban.
+	SSHLimitBan = 5 * time.Minute
+	// SSHLimitMaxBan is the maximum duration of a ban.
+	SSHLimitMaxBan = 24 * time.Hour
+	// SSHLimitTTL is how long the state of a source IP address or a device is kept after its last failed attempt.
+	SSHLimitTTL = 2 * SSHLimitMaxBan
+)
+
+type SSHLimitService interface {
+	CheckSSHAttempt(ctx context.Context, ip, device string) error
+	RecordSSHAttempt(ctx context.Context, attempt *models.SSHAttempt) error
+	GetSSHLimits(ctx context.Context, tenant, ip, device string) (*models.SSHLimits, error)
+}
+
+// CheckSSHAttempt checks if an attempt to authenticate on a device from a source IP address is allowed.
+//
+// An empty device checks only the source IP address. It returns NewErrSSHAttemptBlocked when the source IP address or
+// the device is blocked. The state is kept on the cache, so the attempts are not limited when the cache is disabled.
+func (s *service) CheckSSHAttempt(ctx context.Context, ip, device string) error {
+	now := clock.Now()
+
+	for _, key := range sshLimitKeys(ip, device) {
+		until, err := s.getSSHLimitBlockedUntil(ctx, key)
+		if err != nil {
+			return err
+		}
+
+		if now.Before(until) {
+			return NewErrSSHAttemptBlocked(until, nil)
+		}
+	}
+
+	return nil
+}
+
+// RecordSSHAttempt updates the rate limiting state of the source IP address and of the device of an attempt.
+//
+// Each failed attempt blocks the next ones with an exponential backoff until the number of failures reaches the limit,
+// when the source IP address or the device is banned. A successful attempt clears the failures, but not the bans, so a
+// source banned again is banned for longer.
+//
+// The failures and the bans are atomic counters on the cache, so the concurrent attempts, from any of the SSH servers,
+// are all counted.
+func (s *service) RecordSSHAttempt(ctx context.Context, attempt *models.SSHAttempt) error {
+	if data, err := validator.ValidateStructFields(attempt); err != nil {
+		return NewErrSSHAttemptInvalid(data, err)
+	}
+
+	// The device is looked up only when the failure can be exported, to bind it to the device's namespace.
+	if !attempt.Success && s.exporter != nil {
+		var tenant string
+		if device, err := s.store.DeviceGet(ctx, models.UID(attempt.Device)); err == nil {
+			tenant = device.TenantID
+		}
+
+		s.export(ctx, exporter.EventAuthFailed, tenant, &exporter.AuthFailure{
+			Method:    exporter.AuthMethodSSH,
+			IPAddress: attempt.IPAddress,
+			Device:    attempt.Device,
+		})
+	}
+
+	now := clock.Now()
+
+	for _, key := range sshLimitKeys(attempt.IPAddress, attempt.Device) {
+		if attempt.Success {
+			for _, suffix := range []string{sshLimitFailuresKey, sshLimitBackoffKey, sshLimitBanKey} {
+				if err := s.cache.Delete(ctx, key+suffix); err != nil {
+					return err
+				}
+			}
+
+			continue
+		}
+
+		failures, err := s.cache.Incr(ctx, key+sshLimitFailuresKey, SSHLimitTTL)
+		if err != nil {
+			return err
+		}
+
+		// The counter is only zero when the cache is disabled.
+		if failures == 0 {
+			continue
+		}
+
+		max := int64(SSHLimitDeviceFailures)
+		if strings.HasPrefix(key, sshLimitIPAddressKey) {
+			max = SSHLimitIPAddressFailures
+		}
+
+		if failures < max {
+			backoff := SSHLimitBackoff << uint(failures-1)
+			if err := s.cache.Set(ctx, key+sshLimitBackoffKey, now.Add(backoff), backoff); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		// Only the attempt reaching the limit bans, as the concurrent ones go past it.
+		if failures > max {
+			continue
+		}
+
+		bans, err := s.cache.Incr(ctx, key+sshLimitBansKey, SSHLimitTTL)
+		if err != nil {
+			return err
+		}
+
+		ban := SSHLimitBan
+		for i := int64(1); i < bans && ban < SSHLimitMaxBan; i++ {
+			ban *= 2
+		}
+
+		if ban > SSHLimitMaxBan {
+			ban = SSHLimitMaxBan
+		}
+
+		if err := s.cache.Set(ctx, key+sshLimitBanKey, now.Add(ban), ban); err != nil {
+			return err
+		}
+
+		if err := s.cache.Delete(ctx, key+sshLimitFailuresKey); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetSSHLimits gets the rate limiting state of a source IP address and of a device of a namespace. An empty IP address
+// or device is not returned.
+func (s *service) GetSSHLimits(ctx context.Context, tenant, ip, device string) (*models.SSHLimits, error) {
+	limits := new(models.SSHLimits)
+
+	if ip != "" {
+		limit, err := s.getSSHLimit(ctx, sshLimitIPAddressKey+ip)
+		if err != nil {
+			return nil, err
+		}
+
+		limits.IPAddress = limit
+	}
+
+	if device != "" {
+		if _, err := s.store.DeviceGetByUID(ctx, models.UID(device), tenant); err != nil {
+			return nil, NewErrDeviceNotFound(models.UID(device), err)
+		}
+
+		limit, err := s.getSSHLimit(ctx, sshLimitDeviceKey+device)
+		if err != nil {
+			return nil, err
+		}
+
+		limits.Device = limit
+	}
+
+	return limits, nil
+}
+
+const (
+	sshLimitIPAddressKey = "ssh_limit/ip_address/"
+	sshLimitDeviceKey    = "ssh_limit/device/"
+
+	// Suffixes of the keys of a source IP address or a device with each part of its rate limiting state.
+	sshLimitFailuresKey = "/failures"
+	sshLimitBansKey     = "/bans"
+	sshLimitBackoffKey  = "/backoff"
+	sshLimitBanKey      = "/ban"
+)
+
+// sshLimitKeys returns the cache keys of the rate limiting state of a source IP address and of a device.
+func sshLimitKeys(ip, device string) []string {
+	keys := []string{sshLimitIPAddressKey + ip}
+	if device != "" {
+		keys = append(keys, sshLimitDeviceKey+device)
+	}
+
+	return keys
+}
+
+func (s *service) getSSHLimit(ctx context.Context, key string) (*models.SSHLimit, error) {
+	failures, err := s.getCacheCounter(ctx, key+sshLimitFailuresKey)
+	if err != nil {
+		return nil, err
+	}
+
+	bans, err := s.getCacheCounter(ctx, key+sshLimitBansKey)
+	if err != nil {
+		return nil, err
+	}
+
+	until, err := s.getSSHLimitBlockedUntil(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.SSHLimit{Failures: failures, Bans: bans, BlockedUntil: until}, nil
+}
+
+// getSSHLimitBlockedUntil returns the time until which a source IP address or a device is blocked, the later of the
+// end of its backoff and of its ban.
+func (s *service) getSSHLimitBlockedUntil(ctx context.Context, key string) (time.Time, error) {
+	var backoff, ban time.Time
+	if err := s.cache.Get(ctx, key+sshLimitBackoffKey, &backoff); err != nil {
+		return time.Time{}, err
+	}
+
+	if err := s.cache.Get(ctx, key+sshLimitBanKey, &ban); err != nil {
+		return time.Time{}, err
+	}
+
+	if ban.After(backoff) {
+		return ban, nil
+	}
+
+	return backoff, nil
+}
+
+// getCacheCounter gets a counter incremented by the Incr of the cache, which is zero when it is not set.
+func (s *service) getCacheCounter(ctx context.Context, key string) (int, error) {
+	var value string
+	if err := s.cache.Get(ctx, key, &value); err != nil || value == "" {
+		return 0, err
+	}
+
+	return strconv.Atoi(value)
+}
@@ -0,0 +1,299 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cachemocks "github.com/shellhub-io/shellhub/api/cache/mocks"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/validator"
+	"github.com/stretchr/testify/assert"
+	mocklib "github.com/stretchr/testify/mock"
+)
+
+// cachedSSHLimitTime returns a function to be returned by the Get of the cache mock that loads the end of a backoff or
+// of a ban.
+func cachedSSHLimitTime(t time.Time) func(context.Context, string, interface{}) error {
+	return func(_ context.Context, _ string, value interface{}) error {
+		*value.(*time.Time) = t
+
+		return nil
+	}
+}
+
+// cachedSSHLimitCounter returns a function to be returned by the Get of the cache mock that loads a counter.
+func cachedSSHLimitCounter(counter string) func(context.Context, string, interface{}) error {
+	return func(_ context.Context, _ string, value interface{}) error {
+		*value.(*string) = counter
+
+		return nil
+	}
+}
+
+func TestCheckSSHAttempt(t *testing.T) {
+	cache := &cachemocks.Cache{}
+	s := NewService(store.Store(&mocks.Store{}), privateKey, publicKey, cache, clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	cases := []struct {
+		description   string
+		device        string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the cache fails",
+			device:      "uid",
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				cache.On("Get", ctx, "ssh_limit/ip_address/10.0.0.1/backoff", &time.Time{}).Return(Err).Once()
+			},
+			expected: Err,
+		},
+		{
+			description: "fails when the source IP address is blocked",
+			device:      "uid",
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				cache.On("Get", ctx, "ssh_limit/ip_address/10.0.0.1/backoff", &time.Time{}).
+					Return(cachedSSHLimitTime(now.Add(time.Second))).Once()
+				cache.On("Get", ctx, "ssh_limit/ip_address/10.0.0.1/ban", &time.Time{}).Return(nil).Once()
+			},
+			expected: NewErrSSHAttemptBlocked(now.Add(time.Second), nil),
+		},
+		{
+			description: "fails when the device is banned",
+			device:      "uid",
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				cache.On("Get", ctx, "ssh_limit/ip_address/10.0.0.1/backoff", &time.Time{}).
+					Return(cachedSSHLimitTime(now.Add(-time.Second))).Once()
+				cache.On("Get", ctx, "ssh_limit/ip_address/10.0.0.1/ban", &time.Time{}).Return(nil).Once()
+				cache.On("Get", ctx, "ssh_limit/device/uid/backoff", &time.Time{}).
+					Return(cachedSSHLimitTime(now.Add(time.Second))).Once()
+				cache.On("Get", ctx, "ssh_limit/device/uid/ban", &time.Time{}).
+					Return(cachedSSHLimitTime(now.Add(SSHLimitBan))).Once()
+			},
+			expected: NewErrSSHAttemptBlocked(now.Add(SSHLimitBan), nil),
+		},
+		{
+			description: "succeeds when only the source IP address is checked",
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				cache.On("Get", ctx, "ssh_limit/ip_address/10.0.0.1/backoff", &time.Time{}).Return(nil).Once()
+				cache.On("Get", ctx, "ssh_limit/ip_address/10.0.0.1/ban", &time.Time{}).Return(nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "succeeds when neither the source IP address nor the device are blocked",
+			device:      "uid",
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				cache.On("Get", ctx, "ssh_limit/ip_address/10.0.0.1/backoff", &time.Time{}).Return(nil).Once()
+				cache.On("Get", ctx, "ssh_limit/ip_address/10.0.0.1/ban", &time.Time{}).Return(nil).Once()
+				cache.On("Get", ctx, "ssh_limit/device/uid/backoff", &time.Time{}).Return(nil).Once()
+				cache.On("Get", ctx, "ssh_limit/device/uid/ban", &time.Time{}).
+					Return(cachedSSHLimitTime(now.Add(-time.Minute))).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			err := s.CheckSSHAttempt(ctx, "10.0.0.1", tc.device)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	cache.AssertExpectations(t)
+}
+
+func TestRecordSSHAttempt(t *testing.T) {
+	cache := &cachemocks.Cache{}
+	s := NewService(store.Store(&mocks.Store{}), privateKey, publicKey, cache, clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	cases := []struct {
+		description   string
+		attempt       *models.SSHAttempt
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description:   "fails when the source IP address is invalid",
+			attempt:       &models.SSHAttempt{IPAddress: "invalid", Device: "uid"},
+			requiredMocks: func() {},
+			expected:      NewErrSSHAttemptInvalid(map[string]interface{}{"IPAddress": "invalid"}, validator.ErrInvalidFields),
+		},
+		{
+			description: "fails when the cache fails",
+			attempt:     &models.SSHAttempt{IPAddress: "10.0.0.1", Device: "uid"},
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				cache.On("Incr", ctx, "ssh_limit/ip_address/10.0.0.1/failures", SSHLimitTTL).Return(int64(0), Err).Once()
+			},
+			expected: Err,
+		},
+		{
+			description: "does not limit when the cache is disabled",
+			attempt:     &models.SSHAttempt{IPAddress: "10.0.0.1", Device: "uid"},
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				cache.On("Incr", ctx, "ssh_limit/ip_address/10.0.0.1/failures", SSHLimitTTL).Return(int64(0), nil).Once()
+				cache.On("Incr", ctx, "ssh_limit/device/uid/failures", SSHLimitTTL).Return(int64(0), nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "backs off exponentially on a failed attempt",
+			attempt:     &models.SSHAttempt{IPAddress: "10.0.0.1", Device: "uid"},
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				cache.On("Incr", ctx, "ssh_limit/ip_address/10.0.0.1/failures", SSHLimitTTL).Return(int64(3), nil).Once()
+				cache.On("Set", ctx, "ssh_limit/ip_address/10.0.0.1/backoff", now.Add(4*time.Second), 4*time.Second).
+					Return(nil).Once()
+				cache.On("Incr", ctx, "ssh_limit/device/uid/failures", SSHLimitTTL).Return(int64(1), nil).Once()
+				cache.On("Set", ctx, "ssh_limit/device/uid/backoff", now.Add(time.Second), time.Second).Return(nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "bans when the failed attempts reach the limit",
+			attempt:     &models.SSHAttempt{IPAddress: "10.0.0.1", Device: "uid"},
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				cache.On("Incr", ctx, "ssh_limit/ip_address/10.0.0.1/failures", SSHLimitTTL).
+					Return(int64(SSHLimitIPAddressFailures), nil).Once()
+				cache.On("Incr", ctx, "ssh_limit/ip_address/10.0.0.1/bans", SSHLimitTTL).Return(int64(2), nil).Once()
+				cache.On("Set", ctx, "ssh_limit/ip_address/10.0.0.1/ban", now.Add(2*SSHLimitBan), 2*SSHLimitBan).
+					Return(nil).Once()
+				cache.On("Delete", ctx, "ssh_limit/ip_address/10.0.0.1/failures").Return(nil).Once()
+				cache.On("Incr", ctx, "ssh_limit/device/uid/failures", SSHLimitTTL).
+					Return(int64(SSHLimitDeviceFailures), nil).Once()
+				cache.On("Incr", ctx, "ssh_limit/device/uid/bans", SSHLimitTTL).Return(int64(21), nil).Once()
+				cache.On("Set", ctx, "ssh_limit/device/uid/ban", now.Add(SSHLimitMaxBan), SSHLimitMaxBan).
+					Return(nil).Once()
+				cache.On("Delete", ctx, "ssh_limit/device/uid/failures").Return(nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "does not ban again on the concurrent attempts past the limit",
+			attempt:     &models.SSHAttempt{IPAddress: "10.0.0.1", Device: "uid"},
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				cache.On("Incr", ctx, "ssh_limit/ip_address/10.0.0.1/failures", SSHLimitTTL).
+					Return(int64(SSHLimitIPAddressFailures+1), nil).Once()
+				cache.On("Incr", ctx, "ssh_limit/device/uid/failures", SSHLimitTTL).
+					Return(int64(SSHLimitDeviceFailures+1), nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "clears the failures on a successful attempt",
+			attempt:     &models.SSHAttempt{IPAddress: "10.0.0.1", Device: "uid", Success: true},
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				for _, key := range []string{"ssh_limit/ip_address/10.0.0.1", "ssh_limit/device/uid"} {
+					cache.On("Delete", ctx, key+"/failures").Return(nil).Once()
+					cache.On("Delete", ctx, key+"/backoff").Return(nil).Once()
+					cache.On("Delete", ctx, key+"/ban").Return(nil).Once()
+				}
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			err := s.RecordSSHAttempt(ctx, tc.attempt)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	cache.AssertExpectations(t)
+}
+
+func TestGetSSHLimits(t *testing.T) {
+	mock := &mocks.Store{}
+	cache := &cachemocks.Cache{}
+	s := NewService(store.Store(mock), privateKey, publicKey, cache, clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	type Expected struct {
+		limits *models.SSHLimits
+		err    error
+	}
+
+	cases := []struct {
+		description   string
+		ip            string
+		device        string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the device is not found",
+			device:      "uid",
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(nil, Err).Once()
+			},
+			expected: Expected{nil, NewErrDeviceNotFound(models.UID("uid"), Err)},
+		},
+		{
+			description: "succeeds",
+			ip:          "10.0.0.1",
+			device:      "uid",
+			requiredMocks: func() {
+				cache.On("Get", ctx, "ssh_limit/ip_address/10.0.0.1/failures", mocklib.Anything).
+					Return(cachedSSHLimitCounter("2")).Once()
+				cache.On("Get", ctx, "ssh_limit/ip_address/10.0.0.1/bans", mocklib.Anything).
+					Return(cachedSSHLimitCounter("1")).Once()
+				cache.On("Get", ctx, "ssh_limit/ip_address/10.0.0.1/backoff", &time.Time{}).
+					Return(cachedSSHLimitTime(now)).Once()
+				cache.On("Get", ctx, "ssh_limit/ip_address/10.0.0.1/ban", &time.Time{}).
+					Return(cachedSSHLimitTime(now.Add(-SSHLimitBan))).Once()
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(&models.Device{UID: "uid"}, nil).Once()
+				cache.On("Get", ctx, "ssh_limit/device/uid/failures", mocklib.Anything).Return(nil).Once()
+				cache.On("Get", ctx, "ssh_limit/device/uid/bans", mocklib.Anything).Return(nil).Once()
+				cache.On("Get", ctx, "ssh_limit/device/uid/backoff", &time.Time{}).Return(nil).Once()
+				cache.On("Get", ctx, "ssh_limit/device/uid/ban", &time.Time{}).Return(nil).Once()
+			},
+			expected: Expected{
+				&models.SSHLimits{IPAddress: &models.SSHLimit{Failures: 2, Bans: 1, BlockedUntil: now}, Device: &models.SSHLimit{}},
+				nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			limits, err := s.GetSSHLimits(ctx, "tenant", tc.ip, tc.device)
+			assert.Equal(t, tc.expected, Expected{limits, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+	cache.AssertExpectations(t)
+}
@@ -8,6 +8,7 @@ import (
 	"encoding/pem"
 	"regexp"
 
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
 	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/pkg/api/paginator"
 	"github.com/shellhub-io/shellhub/pkg/clock"
@@ -47,6 +48,8 @@ func (s *service) EvaluateKeyFilter(ctx context.Context, key *models.PublicKey,
 		}
 
 		return false, nil
+	} else if key.Filter.Group != "" {
+		return s.isDeviceGroupMember(ctx, key.Filter.Group, key.TenantID, models.UID(dev.UID))
 	}
 
 	return true, nil
@@ -100,8 +103,20 @@ func (s *service) CreatePublicKey(ctx context.Context, key *models.PublicKey, te
 		}
 	}
 
+	if key.Filter.Group != "" {
+		if _, err := s.store.DeviceGroupGet(ctx, tenant, key.Filter.Group); err != nil {
+			return NewErrDeviceGroupNotFound(key.Filter.Group, err)
+		}
+	}
+
 	key.CreatedAt = clock.Now()
 
+	// The key is bound to the user adding it, so the sessions it authenticates are restricted to the user's scope.
+	key.CreatedBy = ""
+	if id := gateway.IDFromContext(ctx); id != nil {
+		key.CreatedBy = id.ID
+	}
+
 	pubKey, _, _, _, err := ssh.ParseAuthorizedKey(key.Data) //nolint:dogsled
 	if err != nil {
 		return NewErrPublicKeyDataInvalid(key.Data, nil)
@@ -123,6 +138,8 @@ func (s *service) CreatePublicKey(ctx context.Context, key *models.PublicKey, te
 		return err
 	}
 
+	s.audit(ctx, tenant, models.AuditPublicKeyCreate, key.Fingerprint, nil, key)
+
 	return err
 }
 
@@ -156,7 +173,24 @@ func (s *service) UpdatePublicKey(ctx context.Context, fingerprint, tenant strin
 		}
 	}
 
-	return s.store.PublicKeyUpdate(ctx, fingerprint, tenant, key)
+	if key.Filter.Group != "" {
+		if _, err := s.store.DeviceGroupGet(ctx, tenant, key.Filter.Group); err != nil {
+			return nil, NewErrDeviceGroupNotFound(key.Filter.Group, err)
+		}
+	}
+
+	before := s.auditGet(func() (interface{}, error) {
+		return s.store.PublicKeyGet(ctx, fingerprint, tenant)
+	})
+
+	updated, err := s.store.PublicKeyUpdate(ctx, fingerprint, tenant, key)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, tenant, models.AuditPublicKeyUpdate, fingerprint, before, updated)
+
+	return updated, nil
 }
 
 func (s *service) DeletePublicKey(ctx context.Context, fingerprint, tenant string) error {
@@ -165,12 +199,18 @@ func (s *service) DeletePublicKey(ctx context.Context, fingerprint, tenant strin
 		return NewErrNamespaceNotFound(tenant, err)
 	}
 
-	_, err = s.store.PublicKeyGet(ctx, fingerprint, tenant)
+	key, err := s.store.PublicKeyGet(ctx, fingerprint, tenant)
 	if err != nil {
 		return NewErrPublicKeyNotFound(fingerprint, err)
 	}
 
-	return s.store.PublicKeyDelete(ctx, fingerprint, tenant)
+	if err := s.store.PublicKeyDelete(ctx, fingerprint, tenant); err != nil {
+		return err
+	}
+
+	s.audit(ctx, tenant, models.AuditPublicKeyRemove, fingerprint, key, nil)
+
+	return nil
 }
 
 func (s *service) CreatePrivateKey(ctx context.Context) (*models.PrivateKey, error) {
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
 )
 
 type SSHKeysTagsService interface {
@@ -56,6 +57,9 @@ func (s *service) AddPublicKeyTag(ctx context.Context, tenant, fingerprint, tag
 		}
 	}
 
+	s.audit(ctx, tenant, models.AuditPublicKeyUpdate, fingerprint,
+		map[string][]string{"tags": key.Filter.Tags}, map[string][]string{"tags": append(append([]string{}, key.Filter.Tags...), tag)})
+
 	return nil
 }
 
@@ -88,6 +92,16 @@ func (s *service) RemovePublicKeyTag(ctx context.Context, tenant, fingerprint, t
 		return err
 	}
 
+	tags := make([]string, 0, len(key.Filter.Tags))
+	for _, item := range key.Filter.Tags {
+		if item != tag {
+			tags = append(tags, item)
+		}
+	}
+
+	s.audit(ctx, tenant, models.AuditPublicKeyUpdate, fingerprint,
+		map[string][]string{"tags": key.Filter.Tags}, map[string][]string{"tags": tags})
+
 	return nil
 }
 
@@ -152,5 +166,8 @@ func (s *service) UpdatePublicKeyTags(ctx context.Context, tenant, fingerprint s
 		}
 	}
 
+	s.audit(ctx, tenant, models.AuditPublicKeyUpdate, fingerprint,
+		map[string][]string{"tags": key.Filter.Tags}, map[string][]string{"tags": tags})
+
 	return nil
 }
@@ -85,6 +85,29 @@ func TestEvaluateKeyFilter(t *testing.T) {
 	}
 	deviceNoFilter := models.Device{}
 
+	keyGroup := &models.PublicKey{
+		TenantID: "tenant",
+		PublicKeyFields: models.PublicKeyFields{
+			Filter: models.PublicKeyFilter{
+				Group: "group",
+			},
+		},
+	}
+	group := &models.DeviceGroup{
+		ID:       "group",
+		TenantID: "tenant",
+		DeviceGroupFields: models.DeviceGroupFields{
+			Name:    "group",
+			Devices: []string{"uid"},
+		},
+	}
+	deviceGroup := models.Device{
+		UID: "uid",
+	}
+	deviceGroupNoMember := models.Device{
+		UID: "uid2",
+	}
+
 	cases := []struct {
 		description   string
 		key           *models.PublicKey
@@ -132,6 +155,35 @@ func TestEvaluateKeyFilter(t *testing.T) {
 			},
 			expected: Expected{true, nil},
 		},
+		{
+			description: "fail to evaluate filter group when group does not exist",
+			key:         keyGroup,
+			device:      deviceGroup,
+			requiredMocks: func() {
+				mock.On("DeviceGroupGet", ctx, "tenant", "group").Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{false, nil},
+		},
+		{
+			description: "fail to evaluate filter group when device is not a member",
+			key:         keyGroup,
+			device:      deviceGroupNoMember,
+			requiredMocks: func() {
+				mock.On("DeviceGroupGet", ctx, "tenant", "group").Return(group, nil).Once()
+				mock.On("DeviceGroupMembers", ctx, group).Return([]models.UID{"uid"}, nil).Once()
+			},
+			expected: Expected{false, nil},
+		},
+		{
+			description: "success to evaluate filter group",
+			key:         keyGroup,
+			device:      deviceGroup,
+			requiredMocks: func() {
+				mock.On("DeviceGroupGet", ctx, "tenant", "group").Return(group, nil).Once()
+				mock.On("DeviceGroupMembers", ctx, group).Return([]models.UID{"uid"}, nil).Once()
+			},
+			expected: Expected{true, nil},
+		},
 	}
 
 	for _, tc := range cases {
@@ -382,7 +434,7 @@ func TestUpdatePublicKeys(t *testing.T) {
 			tenantID:      "tenant",
 			keyUpdate:     keyInvalidUpdateNoHostnameTags,
 			requiredMocks: func() {},
-			expected:      Expected{key: nil, err: NewErrPublicKeyInvalid(map[string]interface{}{"Hostname": keyInvalidUpdateNoHostnameTags.Filter.Hostname, "Tags": keyInvalidUpdateNoHostnameTags.Filter.Tags}, nil)},
+			expected:      Expected{key: nil, err: NewErrPublicKeyInvalid(map[string]interface{}{"Hostname": keyInvalidUpdateNoHostnameTags.Filter.Hostname, "Tags": keyInvalidUpdateNoHostnameTags.Filter.Tags, "Group": keyInvalidUpdateNoHostnameTags.Filter.Group}, nil)},
 		},
 		{
 			description: "fails to update a public key when filter has hostname and tags",
@@ -400,7 +452,7 @@ func TestUpdatePublicKeys(t *testing.T) {
 			keyUpdate:   keyInvalidUpdateHostnameEmpty,
 			requiredMocks: func() {
 			},
-			expected: Expected{key: nil, err: NewErrPublicKeyInvalid(map[string]interface{}{"Hostname": keyInvalidUpdateHostnameEmpty.Filter.Hostname, "Tags": keyInvalidUpdateHostnameEmpty.Filter.Tags}, nil)},
+			expected: Expected{key: nil, err: NewErrPublicKeyInvalid(map[string]interface{}{"Hostname": keyInvalidUpdateHostnameEmpty.Filter.Hostname, "Tags": keyInvalidUpdateHostnameEmpty.Filter.Tags, "Group": keyInvalidUpdateHostnameEmpty.Filter.Group}, nil)},
 		},
 		{
 			description: "successful update the key when filter is hostname",
@@ -658,7 +710,7 @@ func TestCreatePublicKeys(t *testing.T) {
 			key:         keyInvalidNoFilter,
 			requiredMocks: func() {
 			},
-			expected: NewErrPublicKeyInvalid(map[string]interface{}{"Hostname": keyInvalidNoFilter.Filter.Hostname, "Tags": keyInvalidNoFilter.Filter.Tags}, nil),
+			expected: NewErrPublicKeyInvalid(map[string]interface{}{"Hostname": keyInvalidNoFilter.Filter.Hostname, "Tags": keyInvalidNoFilter.Filter.Tags, "Group": keyInvalidNoFilter.Filter.Group}, nil),
 		},
 		{
 			description: "fail when public key has hostname and tags filter",
@@ -710,7 +762,7 @@ func TestCreatePublicKeys(t *testing.T) {
 			key:         keyInvalidHostnameEmpty,
 			requiredMocks: func() {
 			},
-			expected: NewErrPublicKeyInvalid(map[string]interface{}{"Hostname": keyInvalidHostnameEmpty.Filter.Hostname, "Tags": keyInvalidHostnameEmpty.Filter.Tags}, nil),
+			expected: NewErrPublicKeyInvalid(map[string]interface{}{"Hostname": keyInvalidHostnameEmpty.Filter.Hostname, "Tags": keyInvalidHostnameEmpty.Filter.Tags, "Group": keyInvalidHostnameEmpty.Filter.Group}, nil),
 		},
 		{
 			description: "success create a public key when filter is hostname",
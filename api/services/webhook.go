@@ -0,0 +1,344 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/webhook"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	"github.com/shellhub-io/shellhub/pkg/validator"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// WebhookMaxRetries is the number of times a failed delivery to a webhook is retried before it is dropped.
+	WebhookMaxRetries = 5
+	// WebhookDeliveryTimeout is how long a delivery waits for the response of a webhook.
+	WebhookDeliveryTimeout = 10 * time.Second
+)
+
+// WebhookQueue enqueues the deliveries of the events to the webhooks, which are sent through DeliverWebhook and
+// retried while it fails.
+type WebhookQueue interface {
+	EnqueueWebhookDelivery(ctx context.Context, task *models.WebhookDeliveryTask) error
+}
+
+// WithWebhookQueue configures the queue of the deliveries to the webhooks. Without it, the events are not delivered.
+func WithWebhookQueue(queue WebhookQueue) Option {
+	return func(s *service) {
+		s.webhooks = queue
+	}
+}
+
+type WebhookService interface {
+	ListWebhooks(ctx context.Context, pagination paginator.Query) ([]models.Webhook, int, error)
+	GetWebhook(ctx context.Context, id, tenant string) (*models.Webhook, error)
+	CreateWebhook(ctx context.Context, webhook *models.Webhook, tenant string) error
+	UpdateWebhook(ctx context.Context, id, tenant string, webhook *models.WebhookUpdate) (*models.Webhook, error)
+	DeleteWebhook(ctx context.Context, id, tenant string) error
+	ListWebhookDeliveries(ctx context.Context, id, tenant string, pagination paginator.Query) ([]models.WebhookDelivery, int, error)
+	DeliverWebhook(ctx context.Context, task *models.WebhookDeliveryTask, attempt int) error
+}
+
+// ListWebhooks lists the webhooks without their secrets.
+func (s *service) ListWebhooks(ctx context.Context, pagination paginator.Query) ([]models.Webhook, int, error) {
+	webhooks, count, err := s.store.WebhookList(ctx, pagination)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+	}
+
+	return webhooks, count, nil
+}
+
+// GetWebhook gets a webhook of a namespace without its secret.
+func (s *service) GetWebhook(ctx context.Context, id, tenant string) (*models.Webhook, error) {
+	webhook, err := s.store.WebhookGet(ctx, tenant, id)
+	if err != nil {
+		return nil, NewErrWebhookNotFound(id, err)
+	}
+
+	webhook.Secret = ""
+
+	return webhook, nil
+}
+
+// CreateWebhook subscribes an URL to events of a namespace. When the secret used to sign its deliveries is not set, a
+// random one is generated.
+func (s *service) CreateWebhook(ctx context.Context, webhook *models.Webhook, tenant string) error {
+	if err := webhook.Validate(); err != nil {
+		data, _ := validator.GetInvalidFieldsValues(err)
+
+		return NewErrWebhookInvalid(data, nil)
+	}
+
+	if webhook.Secret == "" {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return err
+		}
+
+		webhook.Secret = hex.EncodeToString(secret)
+	}
+
+	webhook.TenantID = tenant
+	webhook.CreatedAt = clock.Now()
+
+	if err := s.store.WebhookCreate(ctx, webhook); err != nil {
+		return err
+	}
+
+	// The secret is never recorded on the audit log.
+	created := *webhook
+	created.Secret = ""
+	s.audit(ctx, tenant, models.AuditWebhookCreate, webhook.ID, nil, &created)
+
+	return nil
+}
+
+// UpdateWebhook updates a webhook of a namespace, replacing its secret only when a new one is set.
+func (s *service) UpdateWebhook(ctx context.Context, id, tenant string, webhook *models.WebhookUpdate) (*models.Webhook, error) {
+	if err := webhook.Validate(); err != nil {
+		data, _ := validator.GetInvalidFieldsValues(err)
+
+		return nil, NewErrWebhookInvalid(data, nil)
+	}
+
+	before := s.auditGet(func() (interface{}, error) {
+		return s.GetWebhook(ctx, id, tenant)
+	})
+
+	updated, err := s.store.WebhookUpdate(ctx, tenant, id, webhook)
+	switch err {
+	case nil:
+		updated.Secret = ""
+		s.audit(ctx, tenant, models.AuditWebhookUpdate, id, before, updated)
+
+		return updated, nil
+	case store.ErrNoDocuments, store.ErrInvalidHex:
+		return nil, NewErrWebhookNotFound(id, err)
+	default:
+		return nil, err
+	}
+}
+
+func (s *service) DeleteWebhook(ctx context.Context, id, tenant string) error {
+	before := s.auditGet(func() (interface{}, error) {
+		return s.GetWebhook(ctx, id, tenant)
+	})
+
+	if err := s.store.WebhookDelete(ctx, tenant, id); err != nil {
+		return NewErrWebhookNotFound(id, err)
+	}
+
+	s.audit(ctx, tenant, models.AuditWebhookRemove, id, before, nil)
+
+	return nil
+}
+
+// ListWebhookDeliveries lists the attempts to deliver the events to a webhook, the latest first.
+func (s *service) ListWebhookDeliveries(ctx context.Context, id, tenant string, pagination paginator.Query) ([]models.WebhookDelivery, int, error) {
+	if _, err := s.store.WebhookGet(ctx, tenant, id); err != nil {
+		return nil, 0, NewErrWebhookNotFound(id, err)
+	}
+
+	return s.store.WebhookDeliveryList(ctx, tenant, id, pagination)
+}
+
+// DeliverWebhook sends an event to a webhook, signing its body and the time of the attempt with the webhook secret, and
+// records the attempt.
+//
+// It returns an error when the webhook does not respond with a 2xx status code, so the delivery is retried. A delivery
+// to a webhook deleted or deactivated since the event is dropped.
+func (s *service) DeliverWebhook(ctx context.Context, task *models.WebhookDeliveryTask, attempt int) error {
+	hook, err := s.store.WebhookGet(ctx, task.TenantID, task.WebhookID)
+	switch {
+	case err == store.ErrNoDocuments:
+		return nil
+	case err != nil:
+		return err
+	case !hook.Active:
+		return nil
+	}
+
+	delivery := &models.WebhookDelivery{
+		WebhookID: hook.ID,
+		TenantID:  hook.TenantID,
+		EventID:   task.EventID,
+		Event:     task.Event,
+		Attempt:   attempt,
+		CreatedAt: clock.Now(),
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(task.Body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhook.WebhookIDHeader, task.EventID)
+	req.Header.Set(webhook.WebhookEventHeader, task.Event)
+	req.Header.Set(webhook.WebhookTimestampHeader, strconv.FormatInt(delivery.CreatedAt.Unix(), 10))
+	req.Header.Set(webhook.WebhookSignatureHeader, webhook.Sign(hook.Secret, delivery.CreatedAt.Unix(), task.Body))
+
+	res, err := s.webhookClient.Do(req)
+	if err != nil {
+		logrus.WithError(err).WithField("webhook", hook.ID).Warn("failed to deliver the event to the webhook")
+
+		delivery.Error = webhookRequestError(err)
+	} else {
+		res.Body.Close()
+
+		delivery.StatusCode = res.StatusCode
+		if delivery.Success = res.StatusCode >= 200 && res.StatusCode < 300; !delivery.Success {
+			delivery.Error = http.StatusText(res.StatusCode)
+		}
+	}
+
+	if err := s.store.WebhookDeliveryCreate(ctx, delivery); err != nil {
+		logrus.WithError(err).WithField("webhook", hook.ID).Error("failed to record the webhook delivery")
+	}
+
+	if !delivery.Success {
+		return errors.New(delivery.Error, ErrLayer, ErrCodeInvalid)
+	}
+
+	return nil
+}
+
+// webhookDeniedNetworks are the networks the webhooks cannot be delivered to, as they reach the services inside the
+// ShellHub's network or the host's one: the loopback, private, shared, link-local and unspecified addresses.
+var webhookDeniedNetworks = []string{
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"::/128",
+	"::1/128",
+	"fc00::/7",
+	"fe80::/10",
+}
+
+// newWebhookClient creates the client of the deliveries to the webhooks. Its connections are only made to the public
+// addresses, checked when dialing, after the webhook's host was resolved, so a host resolving to an internal address
+// or a redirect to it are refused too.
+func newWebhookClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   WebhookDeliveryTimeout,
+		KeepAlive: 30 * time.Second,
+		Control:   webhookDialControl,
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{Timeout: WebhookDeliveryTimeout, Transport: transport}
+}
+
+// webhookDialControl refuses, with ErrWebhookAddressDenied, the connections to the addresses in webhookDeniedNetworks.
+func webhookDialControl(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return ErrWebhookAddressDenied
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return ErrWebhookAddressDenied
+	}
+
+	for _, cidr := range webhookDeniedNetworks {
+		if _, denied, _ := net.ParseCIDR(cidr); denied.Contains(ip) {
+			return ErrWebhookAddressDenied
+		}
+	}
+
+	return nil
+}
+
+// webhookRequestError describes why a request to a webhook failed. As the deliveries are shown to the namespace's
+// members, it does not carry the network errors, which could reveal the internal network.
+func webhookRequestError(err error) string {
+	if e, ok := err.(net.Error); ok && e.Timeout() {
+		return "the webhook did not respond in time"
+	}
+
+	if e, ok := err.(*url.Error); ok {
+		if op, ok := e.Err.(*net.OpError); ok && op.Err == ErrWebhookAddressDenied {
+			return ErrWebhookAddressDenied.Error()
+		}
+	}
+
+	return "failed to connect to the webhook"
+}
+
+// dispatchWebhookEvent enqueues the deliveries of an event of a namespace to its active webhooks subscribed to it. As
+// it is called after the event happened, failures are only logged.
+func (s *service) dispatchWebhookEvent(ctx context.Context, tenant, event string, data interface{}) {
+	if s.webhooks == nil {
+		return
+	}
+
+	log := logrus.WithFields(logrus.Fields{"tenant_id": tenant, "event": event})
+
+	hooks, err := s.store.WebhookListByEvent(ctx, tenant, event)
+	if err != nil {
+		log.WithError(err).Error("failed to list the webhooks of the event")
+
+		return
+	}
+
+	if len(hooks) == 0 {
+		return
+	}
+
+	payload := &models.WebhookEvent{
+		ID:        uuid.Generate(),
+		Event:     event,
+		TenantID:  tenant,
+		CreatedAt: clock.Now(),
+		Data:      data,
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		log.WithError(err).Error("failed to encode the webhook event")
+
+		return
+	}
+
+	for _, hook := range hooks {
+		task := &models.WebhookDeliveryTask{
+			WebhookID: hook.ID,
+			TenantID:  tenant,
+			EventID:   payload.ID,
+			Event:     event,
+			Body:      body,
+		}
+
+		if err := s.webhooks.EnqueueWebhookDelivery(ctx, task); err != nil {
+			log.WithError(err).WithField("webhook", hook.ID).Error("failed to enqueue the webhook delivery")
+		}
+	}
+}
@@ -0,0 +1,400 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	storecache "github.com/shellhub-io/shellhub/api/cache"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/webhook"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuid_mocks "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+// webhookQueue is a WebhookQueue that keeps the enqueued deliveries.
+type webhookQueue struct {
+	tasks []models.WebhookDeliveryTask
+}
+
+func (q *webhookQueue) EnqueueWebhookDelivery(_ context.Context, task *models.WebhookDeliveryTask) error {
+	q.tasks = append(q.tasks, *task)
+
+	return nil
+}
+
+func TestCreateWebhook(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	cases := []struct {
+		description   string
+		webhook       *models.Webhook
+		requiredMocks func(webhook *models.Webhook)
+		// secret is the expected secret of the created webhook, being a generated one when empty.
+		secret   string
+		expected error
+	}{
+		{
+			description: "fails when the event is invalid",
+			webhook: &models.Webhook{
+				WebhookFields: models.WebhookFields{URL: "https://example.com", Events: []string{"device_deleted"}},
+			},
+			requiredMocks: func(_ *models.Webhook) {},
+			expected:      NewErrWebhookInvalid(map[string]interface{}{"Events[0]": "device_deleted"}, nil),
+		},
+		{
+			description: "fails when the URL is invalid",
+			webhook: &models.Webhook{
+				WebhookFields: models.WebhookFields{URL: "example", Events: []string{models.WebhookEventDeviceAccepted}},
+			},
+			requiredMocks: func(_ *models.Webhook) {},
+			expected:      NewErrWebhookInvalid(map[string]interface{}{"URL": "example"}, nil),
+		},
+		{
+			description: "fails when the URL is not HTTP",
+			webhook: &models.Webhook{
+				WebhookFields: models.WebhookFields{URL: "gopher://example.com", Events: []string{models.WebhookEventDeviceAccepted}},
+			},
+			requiredMocks: func(_ *models.Webhook) {},
+			expected:      NewErrWebhookInvalid(map[string]interface{}{"URL": "gopher://example.com"}, nil),
+		},
+		{
+			description: "fails when the secret is too short",
+			webhook: &models.Webhook{
+				Secret:        "secret",
+				WebhookFields: models.WebhookFields{URL: "https://example.com", Events: []string{models.WebhookEventDeviceAccepted}},
+			},
+			requiredMocks: func(_ *models.Webhook) {},
+			expected:      NewErrWebhookInvalid(map[string]interface{}{"Secret": "secret"}, nil),
+		},
+		{
+			description: "fails when the store fails",
+			webhook: &models.Webhook{
+				WebhookFields: models.WebhookFields{URL: "https://example.com", Events: []string{models.WebhookEventDeviceAccepted}},
+			},
+			requiredMocks: func(webhook *models.Webhook) {
+				clockMock.On("Now").Return(now).Once()
+				mock.On("WebhookCreate", ctx, webhook).Return(Err).Once()
+			},
+			expected: Err,
+		},
+		{
+			description: "succeeds",
+			webhook: &models.Webhook{
+				WebhookFields: models.WebhookFields{URL: "https://example.com", Events: []string{models.WebhookEventDeviceAccepted}},
+			},
+			requiredMocks: func(webhook *models.Webhook) {
+				clockMock.On("Now").Return(now).Once()
+				mock.On("WebhookCreate", ctx, webhook).Return(nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "succeeds with the configured secret",
+			webhook: &models.Webhook{
+				Secret:        "0123456789abcdef",
+				WebhookFields: models.WebhookFields{URL: "https://example.com", Events: []string{models.WebhookEventDeviceAccepted}},
+			},
+			requiredMocks: func(webhook *models.Webhook) {
+				clockMock.On("Now").Return(now).Once()
+				mock.On("WebhookCreate", ctx, webhook).Return(nil).Once()
+			},
+			secret:   "0123456789abcdef",
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks(tc.webhook)
+
+			err := s.CreateWebhook(ctx, tc.webhook, "tenant")
+			assert.Equal(t, tc.expected, err)
+
+			if err == nil {
+				assert.Equal(t, "tenant", tc.webhook.TenantID)
+				assert.Equal(t, now, tc.webhook.CreatedAt)
+				if tc.secret != "" {
+					assert.Equal(t, tc.secret, tc.webhook.Secret)
+				} else {
+					assert.Len(t, tc.webhook.Secret, 64)
+				}
+			}
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestUpdateWebhook(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	update := &models.WebhookUpdate{
+		WebhookFields: models.WebhookFields{URL: "https://example.com", Events: []string{models.WebhookEventSessionStarted}},
+	}
+
+	type Expected struct {
+		webhook *models.Webhook
+		err     error
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the webhook is not found",
+			requiredMocks: func() {
+				mock.On("WebhookUpdate", ctx, "tenant", "id", update).Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{nil, NewErrWebhookNotFound("id", store.ErrNoDocuments)},
+		},
+		{
+			description: "succeeds without returning the secret",
+			requiredMocks: func() {
+				mock.On("WebhookUpdate", ctx, "tenant", "id", update).
+					Return(&models.Webhook{ID: "id", Secret: "secret", WebhookFields: update.WebhookFields}, nil).Once()
+			},
+			expected: Expected{&models.Webhook{ID: "id", WebhookFields: update.WebhookFields}, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			webhook, err := s.UpdateWebhook(ctx, "id", "tenant", update)
+			assert.Equal(t, tc.expected, Expected{webhook, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestDeliverWebhook(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	body := []byte(`{"id":"event","event":"device_accepted"}`)
+
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ := ioutil.ReadAll(r.Body)
+		if string(received) != string(body) ||
+			r.Header.Get(webhook.WebhookIDHeader) != "event" ||
+			r.Header.Get(webhook.WebhookEventHeader) != models.WebhookEventDeviceAccepted ||
+			r.Header.Get(webhook.WebhookTimestampHeader) != strconv.FormatInt(now.Unix(), 10) ||
+			r.Header.Get(webhook.WebhookSignatureHeader) != webhook.Sign("secret", now.Unix(), body) {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	// The test server listens on the loopback address, refused by the service's client.
+	s.(*service).webhookClient = server.Client()
+
+	hook := &models.Webhook{
+		ID:            "id",
+		TenantID:      "tenant",
+		Secret:        "secret",
+		WebhookFields: models.WebhookFields{URL: server.URL, Events: []string{models.WebhookEventDeviceAccepted}, Active: true},
+	}
+
+	task := &models.WebhookDeliveryTask{
+		WebhookID: "id",
+		TenantID:  "tenant",
+		EventID:   "event",
+		Event:     models.WebhookEventDeviceAccepted,
+		Body:      body,
+	}
+
+	Err := errors.New("error", "", 0)
+
+	cases := []struct {
+		description   string
+		status        int
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the store fails",
+			requiredMocks: func() {
+				mock.On("WebhookGet", ctx, "tenant", "id").Return(nil, Err).Once()
+			},
+			expected: Err,
+		},
+		{
+			description: "drops the delivery when the webhook was deleted",
+			requiredMocks: func() {
+				mock.On("WebhookGet", ctx, "tenant", "id").Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "drops the delivery when the webhook was deactivated",
+			requiredMocks: func() {
+				inactive := *hook
+				inactive.Active = false
+				mock.On("WebhookGet", ctx, "tenant", "id").Return(&inactive, nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "fails to be retried when the webhook does not accept the delivery",
+			status:      http.StatusInternalServerError,
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				mock.On("WebhookGet", ctx, "tenant", "id").Return(hook, nil).Once()
+				mock.On("WebhookDeliveryCreate", ctx, &models.WebhookDelivery{
+					WebhookID:  "id",
+					TenantID:   "tenant",
+					EventID:    "event",
+					Event:      models.WebhookEventDeviceAccepted,
+					Attempt:    2,
+					StatusCode: http.StatusInternalServerError,
+					Error:      "Internal Server Error",
+					CreatedAt:  now,
+				}).Return(nil).Once()
+			},
+			expected: errors.New("Internal Server Error", ErrLayer, ErrCodeInvalid),
+		},
+		{
+			description: "succeeds",
+			status:      http.StatusNoContent,
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				mock.On("WebhookGet", ctx, "tenant", "id").Return(hook, nil).Once()
+				mock.On("WebhookDeliveryCreate", ctx, &models.WebhookDelivery{
+					WebhookID:  "id",
+					TenantID:   "tenant",
+					EventID:    "event",
+					Event:      models.WebhookEventDeviceAccepted,
+					Attempt:    2,
+					StatusCode: http.StatusNoContent,
+					Success:    true,
+					CreatedAt:  now,
+				}).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			status = tc.status
+			tc.requiredMocks()
+
+			err := s.DeliverWebhook(ctx, task, 2)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestDispatchWebhookEvent(t *testing.T) {
+	uuidMock := &uuid_mocks.Uuid{}
+	uuid.DefaultBackend = uuidMock
+
+	mock := &mocks.Store{}
+	queue := &webhookQueue{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil, WithWebhookQueue(queue))
+
+	ctx := context.TODO()
+
+	session := &models.Session{UID: "uid", TenantID: "tenant"}
+
+	clockMock.On("Now").Return(now).Once()
+	uuidMock.On("Generate").Return("event").Once()
+	mock.On("SessionDeleteActives", ctx, models.UID("uid")).Return(nil).Once()
+	mock.On("SessionGet", ctx, models.UID("uid")).Return(session, nil).Once()
+	mock.On("WebhookListByEvent", ctx, "tenant", models.WebhookEventSessionFinished).
+		Return([]models.Webhook{{ID: "first"}, {ID: "second"}}, nil).Once()
+
+	err := s.DeactivateSession(ctx, models.UID("uid"))
+	assert.NoError(t, err)
+
+	body, err := json.Marshal(&models.WebhookEvent{
+		ID:        "event",
+		Event:     models.WebhookEventSessionFinished,
+		TenantID:  "tenant",
+		CreatedAt: now,
+		Data:      session,
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []models.WebhookDeliveryTask{
+		{WebhookID: "first", TenantID: "tenant", EventID: "event", Event: models.WebhookEventSessionFinished, Body: body},
+		{WebhookID: "second", TenantID: "tenant", EventID: "event", Event: models.WebhookEventSessionFinished, Body: body},
+	}, queue.tasks)
+
+	mock.AssertExpectations(t)
+	uuidMock.AssertExpectations(t)
+}
+
+func TestDeliverWebhookToInternalAddress(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	hook := &models.Webhook{
+		ID:            "id",
+		TenantID:      "tenant",
+		Secret:        "secret",
+		WebhookFields: models.WebhookFields{URL: server.URL, Events: []string{models.WebhookEventDeviceAccepted}, Active: true},
+	}
+
+	task := &models.WebhookDeliveryTask{
+		WebhookID: "id",
+		TenantID:  "tenant",
+		EventID:   "event",
+		Event:     models.WebhookEventDeviceAccepted,
+		Body:      []byte(`{"id":"event","event":"device_accepted"}`),
+	}
+
+	clockMock.On("Now").Return(now).Once()
+	mock.On("WebhookGet", ctx, "tenant", "id").Return(hook, nil).Once()
+	mock.On("WebhookDeliveryCreate", ctx, &models.WebhookDelivery{
+		WebhookID: "id",
+		TenantID:  "tenant",
+		EventID:   "event",
+		Event:     models.WebhookEventDeviceAccepted,
+		Attempt:   1,
+		Error:     ErrWebhookAddressDenied.Error(),
+		CreatedAt: now,
+	}).Return(nil).Once()
+
+	err := s.DeliverWebhook(ctx, task, 1)
+	assert.Equal(t, errors.New(ErrWebhookAddressDenied.Error(), ErrLayer, ErrCodeInvalid), err)
+
+	mock.AssertExpectations(t)
+}
@@ -0,0 +1,15 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// AuditStore keeps the audit log, which is only appended to.
+type AuditStore interface {
+	// AuditList lists the audit logs selected by the filter, from the newest.
+	AuditList(ctx context.Context, pagination paginator.Query, filter *models.AuditLogFilter) ([]models.AuditLog, int, error)
+	AuditCreate(ctx context.Context, log *models.AuditLog) error
+}
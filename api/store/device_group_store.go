@@ -0,0 +1,18 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type DeviceGroupStore interface {
+	DeviceGroupList(ctx context.Context, pagination paginator.Query) ([]models.DeviceGroup, int, error)
+	DeviceGroupGet(ctx context.Context, tenant string, id string) (*models.DeviceGroup, error)
+	DeviceGroupCreate(ctx context.Context, group *models.DeviceGroup) error
+	DeviceGroupUpdate(ctx context.Context, tenant string, id string, group *models.DeviceGroupUpdate) (*models.DeviceGroup, error)
+	DeviceGroupDelete(ctx context.Context, tenant string, id string) error
+	// DeviceGroupMembers returns the UIDs of the namespace's devices that belong to a group.
+	DeviceGroupMembers(ctx context.Context, group *models.DeviceGroup) ([]models.UID, error)
+}
@@ -9,9 +9,15 @@ import (
 )
 
 type DeviceStore interface {
-	DeviceList(ctx context.Context, pagination paginator.Query, filters []models.Filter, status string, sort string, order string) ([]models.Device, int, error)
+	// DeviceList lists the devices matching the filters. When uids is not nil, only the devices it contains are listed.
+	DeviceList(ctx context.Context, pagination paginator.Query, filters []models.Filter, status string, sort string, order string, uids []models.UID) ([]models.Device, int, error)
 	DeviceGet(ctx context.Context, uid models.UID) (*models.Device, error)
 	DeviceDelete(ctx context.Context, uid models.UID) error
+	DeviceDecommission(ctx context.Context, uid models.UID) error
+	// DeviceTransfer moves a device to another namespace under a new UID, moving its sessions and records too when
+	// sessions is true.
+	DeviceTransfer(ctx context.Context, transfer *models.DeviceTransfer, sessions bool) error
+	DeviceTransferGet(ctx context.Context, uid models.UID) (*models.DeviceTransfer, error)
 	DeviceCreate(ctx context.Context, d models.Device, hostname string) error
 	DeviceRename(ctx context.Context, uid models.UID, hostname string) error
 	DeviceLookup(ctx context.Context, namespace, hostname string) (*models.Device, error)
@@ -21,6 +27,10 @@ type DeviceStore interface {
 	DeviceUpdateStatus(ctx context.Context, uid models.UID, status string) error
 	DeviceGetByMac(ctx context.Context, mac string, tenantID string, status string) (*models.Device, error)
 	DeviceGetByName(ctx context.Context, name string, tenantID string) (*models.Device, error)
+	// DeviceNameNextSequence gets the next number of a sequence of device names of a namespace. The names are matched
+	// by pattern, a regular expression capturing their number on its first group. The sequence starts at start, or
+	// after the highest number already used, and each call gets a distinct number, even when they are concurrent.
+	DeviceNameNextSequence(ctx context.Context, tenantID, pattern string, start int) (int, error)
 	DeviceGetByUID(ctx context.Context, uid models.UID, tenantID string) (*models.Device, error)
 	DeviceSetPosition(ctx context.Context, uid models.UID, position models.DevicePosition) error
 	DeviceListByUsage(ctx context.Context, tenantID string) ([]models.UID, error)
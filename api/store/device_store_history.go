@@ -0,0 +1,15 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type DeviceHistoryStore interface {
+	DeviceHistoryCreate(ctx context.Context, event *models.DeviceConnectionEvent) error
+	DeviceHistoryList(ctx context.Context, uid models.UID, pagination paginator.Query, from, to time.Time) ([]models.DeviceConnectionEvent, int, error)
+	DeviceHistoryGetLast(ctx context.Context, uid models.UID, before time.Time) (*models.DeviceConnectionEvent, error)
+}
@@ -13,4 +13,8 @@ type FirewallStore interface {
 	FirewallRuleGet(ctx context.Context, id string) (*models.FirewallRule, error)
 	FirewallRuleUpdate(ctx context.Context, id string, rule models.FirewallRuleUpdate) (*models.FirewallRule, error)
 	FirewallRuleDelete(ctx context.Context, id string) error
+	// FirewallRuleListActive lists the active firewall rules of a namespace sorted by priority.
+	FirewallRuleListActive(ctx context.Context, tenant string) ([]models.FirewallRule, error)
+	// FirewallRuleListByTenant lists every firewall rule of a namespace sorted by priority.
+	FirewallRuleListByTenant(ctx context.Context, tenant string) ([]models.FirewallRule, error)
 }
@@ -0,0 +1,20 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type InvitationStore interface {
+	InvitationList(ctx context.Context, pagination paginator.Query) ([]models.Invitation, int, error)
+	InvitationGet(ctx context.Context, tenant string, id string) (*models.Invitation, error)
+	InvitationGetByToken(ctx context.Context, token string) (*models.Invitation, error)
+	// InvitationGetPending gets the pending invitation of an email to a namespace.
+	InvitationGetPending(ctx context.Context, tenant string, email string) (*models.Invitation, error)
+	InvitationCreate(ctx context.Context, invitation *models.Invitation) error
+	// InvitationAnswer sets the status of a pending invitation, returning ErrNoDocuments when it is not pending.
+	InvitationAnswer(ctx context.Context, id string, status string) (*models.Invitation, error)
+	InvitationDelete(ctx context.Context, tenant string, id string) error
+}
@@ -0,0 +1,17 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type IPSetStore interface {
+	IPSetList(ctx context.Context, pagination paginator.Query) ([]models.IPSet, int, error)
+	IPSetGet(ctx context.Context, tenant string, id string) (*models.IPSet, error)
+	IPSetGetByName(ctx context.Context, tenant string, name string) (*models.IPSet, error)
+	IPSetCreate(ctx context.Context, set *models.IPSet) error
+	IPSetUpdate(ctx context.Context, tenant string, id string, set *models.IPSetUpdate) (*models.IPSet, error)
+	IPSetDelete(ctx context.Context, tenant string, id string) error
+}
@@ -18,6 +18,50 @@ type Store struct {
 	mock.Mock
 }
 
+// AuditCreate provides a mock function with given fields: ctx, log
+func (_m *Store) AuditCreate(ctx context.Context, log *models.AuditLog) error {
+	ret := _m.Called(ctx, log)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AuditLog) error); ok {
+		r0 = rf(ctx, log)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuditList provides a mock function with given fields: ctx, pagination, filter
+func (_m *Store) AuditList(ctx context.Context, pagination paginator.Query, filter *models.AuditLogFilter) ([]models.AuditLog, int, error) {
+	ret := _m.Called(ctx, pagination, filter)
+
+	var r0 []models.AuditLog
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query, *models.AuditLogFilter) []models.AuditLog); ok {
+		r0 = rf(ctx, pagination, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AuditLog)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(context.Context, paginator.Query, *models.AuditLogFilter) int); ok {
+		r1 = rf(ctx, pagination, filter)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, paginator.Query, *models.AuditLogFilter) error); ok {
+		r2 = rf(ctx, pagination, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // BillingActiveInstances provides a mock function with given fields: ctx
 func (_m *Store) BillingActiveInstances(ctx context.Context) ([]models.Namespace, int, error) {
 	ret := _m.Called(ctx)
@@ -178,6 +222,20 @@ func (_m *Store) DeviceCreateTag(ctx context.Context, uid models.UID, tag string
 	return r0
 }
 
+// DeviceDecommission provides a mock function with given fields: ctx, uid
+func (_m *Store) DeviceDecommission(ctx context.Context, uid models.UID) error {
+	ret := _m.Called(ctx, uid)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) error); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeviceDelete provides a mock function with given fields: ctx, uid
 func (_m *Store) DeviceDelete(ctx context.Context, uid models.UID) error {
 	ret := _m.Called(ctx, uid)
@@ -284,13 +342,207 @@ func (_m *Store) DeviceGetByUID(ctx context.Context, uid models.UID, tenantID st
 	return r0, r1
 }
 
-// DeviceList provides a mock function with given fields: ctx, pagination, filters, status, sort, order
-func (_m *Store) DeviceList(ctx context.Context, pagination paginator.Query, filters []models.Filter, status string, sort string, order string) ([]models.Device, int, error) {
-	ret := _m.Called(ctx, pagination, filters, status, sort, order)
+// DeviceGroupCreate provides a mock function with given fields: ctx, group
+func (_m *Store) DeviceGroupCreate(ctx context.Context, group *models.DeviceGroup) error {
+	ret := _m.Called(ctx, group)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DeviceGroup) error); ok {
+		r0 = rf(ctx, group)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceGroupDelete provides a mock function with given fields: ctx, tenant, id
+func (_m *Store) DeviceGroupDelete(ctx context.Context, tenant string, id string) error {
+	ret := _m.Called(ctx, tenant, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceGroupGet provides a mock function with given fields: ctx, tenant, id
+func (_m *Store) DeviceGroupGet(ctx context.Context, tenant string, id string) (*models.DeviceGroup, error) {
+	ret := _m.Called(ctx, tenant, id)
+
+	var r0 *models.DeviceGroup
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.DeviceGroup); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeviceGroup)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceGroupList provides a mock function with given fields: ctx, pagination
+func (_m *Store) DeviceGroupList(ctx context.Context, pagination paginator.Query) ([]models.DeviceGroup, int, error) {
+	ret := _m.Called(ctx, pagination)
+
+	var r0 []models.DeviceGroup
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) []models.DeviceGroup); ok {
+		r0 = rf(ctx, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceGroup)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(context.Context, paginator.Query) int); ok {
+		r1 = rf(ctx, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, paginator.Query) error); ok {
+		r2 = rf(ctx, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// DeviceGroupMembers provides a mock function with given fields: ctx, group
+func (_m *Store) DeviceGroupMembers(ctx context.Context, group *models.DeviceGroup) ([]models.UID, error) {
+	ret := _m.Called(ctx, group)
+
+	var r0 []models.UID
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DeviceGroup) []models.UID); ok {
+		r0 = rf(ctx, group)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.UID)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.DeviceGroup) error); ok {
+		r1 = rf(ctx, group)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceGroupUpdate provides a mock function with given fields: ctx, tenant, id, group
+func (_m *Store) DeviceGroupUpdate(ctx context.Context, tenant string, id string, group *models.DeviceGroupUpdate) (*models.DeviceGroup, error) {
+	ret := _m.Called(ctx, tenant, id, group)
+
+	var r0 *models.DeviceGroup
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.DeviceGroupUpdate) *models.DeviceGroup); ok {
+		r0 = rf(ctx, tenant, id, group)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeviceGroup)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *models.DeviceGroupUpdate) error); ok {
+		r1 = rf(ctx, tenant, id, group)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceHistoryCreate provides a mock function with given fields: ctx, event
+func (_m *Store) DeviceHistoryCreate(ctx context.Context, event *models.DeviceConnectionEvent) error {
+	ret := _m.Called(ctx, event)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DeviceConnectionEvent) error); ok {
+		r0 = rf(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceHistoryGetLast provides a mock function with given fields: ctx, uid, before
+func (_m *Store) DeviceHistoryGetLast(ctx context.Context, uid models.UID, before time.Time) (*models.DeviceConnectionEvent, error) {
+	ret := _m.Called(ctx, uid, before)
+
+	var r0 *models.DeviceConnectionEvent
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, time.Time) *models.DeviceConnectionEvent); ok {
+		r0 = rf(ctx, uid, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeviceConnectionEvent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.UID, time.Time) error); ok {
+		r1 = rf(ctx, uid, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceHistoryList provides a mock function with given fields: ctx, uid, pagination, from, to
+func (_m *Store) DeviceHistoryList(ctx context.Context, uid models.UID, pagination paginator.Query, from time.Time, to time.Time) ([]models.DeviceConnectionEvent, int, error) {
+	ret := _m.Called(ctx, uid, pagination, from, to)
+
+	var r0 []models.DeviceConnectionEvent
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, paginator.Query, time.Time, time.Time) []models.DeviceConnectionEvent); ok {
+		r0 = rf(ctx, uid, pagination, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceConnectionEvent)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(context.Context, models.UID, paginator.Query, time.Time, time.Time) int); ok {
+		r1 = rf(ctx, uid, pagination, from, to)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, models.UID, paginator.Query, time.Time, time.Time) error); ok {
+		r2 = rf(ctx, uid, pagination, from, to)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// DeviceList provides a mock function with given fields: ctx, pagination, filters, status, sort, order, uids
+func (_m *Store) DeviceList(ctx context.Context, pagination paginator.Query, filters []models.Filter, status string, sort string, order string, uids []models.UID) ([]models.Device, int, error) {
+	ret := _m.Called(ctx, pagination, filters, status, sort, order, uids)
 
 	var r0 []models.Device
-	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query, []models.Filter, string, string, string) []models.Device); ok {
-		r0 = rf(ctx, pagination, filters, status, sort, order)
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query, []models.Filter, string, string, string, []models.UID) []models.Device); ok {
+		r0 = rf(ctx, pagination, filters, status, sort, order, uids)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]models.Device)
@@ -298,15 +550,15 @@ func (_m *Store) DeviceList(ctx context.Context, pagination paginator.Query, fil
 	}
 
 	var r1 int
-	if rf, ok := ret.Get(1).(func(context.Context, paginator.Query, []models.Filter, string, string, string) int); ok {
-		r1 = rf(ctx, pagination, filters, status, sort, order)
+	if rf, ok := ret.Get(1).(func(context.Context, paginator.Query, []models.Filter, string, string, string, []models.UID) int); ok {
+		r1 = rf(ctx, pagination, filters, status, sort, order, uids)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
 
 	var r2 error
-	if rf, ok := ret.Get(2).(func(context.Context, paginator.Query, []models.Filter, string, string, string) error); ok {
-		r2 = rf(ctx, pagination, filters, status, sort, order)
+	if rf, ok := ret.Get(2).(func(context.Context, paginator.Query, []models.Filter, string, string, string, []models.UID) error); ok {
+		r2 = rf(ctx, pagination, filters, status, sort, order, uids)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -360,6 +612,27 @@ func (_m *Store) DeviceLookup(ctx context.Context, namespace string, hostname st
 	return r0, r1
 }
 
+// DeviceNameNextSequence provides a mock function with given fields: ctx, tenantID, pattern, start
+func (_m *Store) DeviceNameNextSequence(ctx context.Context, tenantID string, pattern string, start int) (int, error) {
+	ret := _m.Called(ctx, tenantID, pattern, start)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) int); ok {
+		r0 = rf(ctx, tenantID, pattern, start)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int) error); ok {
+		r1 = rf(ctx, tenantID, pattern, start)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeviceRemoveTag provides a mock function with given fields: ctx, uid, tag
 func (_m *Store) DeviceRemoveTag(ctx context.Context, uid models.UID, tag string) error {
 	ret := _m.Called(ctx, uid, tag)
@@ -416,6 +689,43 @@ func (_m *Store) DeviceSetPosition(ctx context.Context, uid models.UID, position
 	return r0
 }
 
+// DeviceTransfer provides a mock function with given fields: ctx, transfer, sessions
+func (_m *Store) DeviceTransfer(ctx context.Context, transfer *models.DeviceTransfer, sessions bool) error {
+	ret := _m.Called(ctx, transfer, sessions)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DeviceTransfer, bool) error); ok {
+		r0 = rf(ctx, transfer, sessions)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceTransferGet provides a mock function with given fields: ctx, uid
+func (_m *Store) DeviceTransferGet(ctx context.Context, uid models.UID) (*models.DeviceTransfer, error) {
+	ret := _m.Called(ctx, uid)
+
+	var r0 *models.DeviceTransfer
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) *models.DeviceTransfer); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeviceTransfer)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.UID) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeviceUpdateLastSeen provides a mock function with given fields: ctx, uid, ts
 func (_m *Store) DeviceUpdateLastSeen(ctx context.Context, uid models.UID, ts time.Time) error {
 	ret := _m.Called(ctx, uid, ts)
@@ -611,6 +921,52 @@ func (_m *Store) FirewallRuleList(ctx context.Context, pagination paginator.Quer
 	return r0, r1, r2
 }
 
+// FirewallRuleListActive provides a mock function with given fields: ctx, tenant
+func (_m *Store) FirewallRuleListActive(ctx context.Context, tenant string) ([]models.FirewallRule, error) {
+	ret := _m.Called(ctx, tenant)
+
+	var r0 []models.FirewallRule
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.FirewallRule); ok {
+		r0 = rf(ctx, tenant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.FirewallRule)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenant)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FirewallRuleListByTenant provides a mock function with given fields: ctx, tenant
+func (_m *Store) FirewallRuleListByTenant(ctx context.Context, tenant string) ([]models.FirewallRule, error) {
+	ret := _m.Called(ctx, tenant)
+
+	var r0 []models.FirewallRule
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.FirewallRule); ok {
+		r0 = rf(ctx, tenant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.FirewallRule)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenant)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FirewallRuleRemoveTag provides a mock function with given fields: ctx, id, tag
 func (_m *Store) FirewallRuleRemoveTag(ctx context.Context, id string, tag string) error {
 	ret := _m.Called(ctx, id, tag)
@@ -699,13 +1055,290 @@ func (_m *Store) GetStats(ctx context.Context) (*models.Stats, error) {
 	return r0, r1
 }
 
-// LicenseLoad provides a mock function with given fields: ctx
-func (_m *Store) LicenseLoad(ctx context.Context) (*models.License, error) {
-	ret := _m.Called(ctx)
+// IPSetCreate provides a mock function with given fields: ctx, set
+func (_m *Store) IPSetCreate(ctx context.Context, set *models.IPSet) error {
+	ret := _m.Called(ctx, set)
 
-	var r0 *models.License
-	if rf, ok := ret.Get(0).(func(context.Context) *models.License); ok {
-		r0 = rf(ctx)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.IPSet) error); ok {
+		r0 = rf(ctx, set)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IPSetDelete provides a mock function with given fields: ctx, tenant, id
+func (_m *Store) IPSetDelete(ctx context.Context, tenant string, id string) error {
+	ret := _m.Called(ctx, tenant, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IPSetGet provides a mock function with given fields: ctx, tenant, id
+func (_m *Store) IPSetGet(ctx context.Context, tenant string, id string) (*models.IPSet, error) {
+	ret := _m.Called(ctx, tenant, id)
+
+	var r0 *models.IPSet
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.IPSet); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.IPSet)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IPSetGetByName provides a mock function with given fields: ctx, tenant, name
+func (_m *Store) IPSetGetByName(ctx context.Context, tenant string, name string) (*models.IPSet, error) {
+	ret := _m.Called(ctx, tenant, name)
+
+	var r0 *models.IPSet
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.IPSet); ok {
+		r0 = rf(ctx, tenant, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.IPSet)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IPSetList provides a mock function with given fields: ctx, pagination
+func (_m *Store) IPSetList(ctx context.Context, pagination paginator.Query) ([]models.IPSet, int, error) {
+	ret := _m.Called(ctx, pagination)
+
+	var r0 []models.IPSet
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) []models.IPSet); ok {
+		r0 = rf(ctx, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.IPSet)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(context.Context, paginator.Query) int); ok {
+		r1 = rf(ctx, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, paginator.Query) error); ok {
+		r2 = rf(ctx, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// IPSetUpdate provides a mock function with given fields: ctx, tenant, id, set
+func (_m *Store) IPSetUpdate(ctx context.Context, tenant string, id string, set *models.IPSetUpdate) (*models.IPSet, error) {
+	ret := _m.Called(ctx, tenant, id, set)
+
+	var r0 *models.IPSet
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.IPSetUpdate) *models.IPSet); ok {
+		r0 = rf(ctx, tenant, id, set)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.IPSet)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *models.IPSetUpdate) error); ok {
+		r1 = rf(ctx, tenant, id, set)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InvitationAnswer provides a mock function with given fields: ctx, id, status
+func (_m *Store) InvitationAnswer(ctx context.Context, id string, status string) (*models.Invitation, error) {
+	ret := _m.Called(ctx, id, status)
+
+	var r0 *models.Invitation
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Invitation); ok {
+		r0 = rf(ctx, id, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Invitation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InvitationCreate provides a mock function with given fields: ctx, invitation
+func (_m *Store) InvitationCreate(ctx context.Context, invitation *models.Invitation) error {
+	ret := _m.Called(ctx, invitation)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Invitation) error); ok {
+		r0 = rf(ctx, invitation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InvitationDelete provides a mock function with given fields: ctx, tenant, id
+func (_m *Store) InvitationDelete(ctx context.Context, tenant string, id string) error {
+	ret := _m.Called(ctx, tenant, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InvitationGet provides a mock function with given fields: ctx, tenant, id
+func (_m *Store) InvitationGet(ctx context.Context, tenant string, id string) (*models.Invitation, error) {
+	ret := _m.Called(ctx, tenant, id)
+
+	var r0 *models.Invitation
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Invitation); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Invitation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InvitationGetByToken provides a mock function with given fields: ctx, token
+func (_m *Store) InvitationGetByToken(ctx context.Context, token string) (*models.Invitation, error) {
+	ret := _m.Called(ctx, token)
+
+	var r0 *models.Invitation
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Invitation); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Invitation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InvitationGetPending provides a mock function with given fields: ctx, tenant, email
+func (_m *Store) InvitationGetPending(ctx context.Context, tenant string, email string) (*models.Invitation, error) {
+	ret := _m.Called(ctx, tenant, email)
+
+	var r0 *models.Invitation
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Invitation); ok {
+		r0 = rf(ctx, tenant, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Invitation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InvitationList provides a mock function with given fields: ctx, pagination
+func (_m *Store) InvitationList(ctx context.Context, pagination paginator.Query) ([]models.Invitation, int, error) {
+	ret := _m.Called(ctx, pagination)
+
+	var r0 []models.Invitation
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) []models.Invitation); ok {
+		r0 = rf(ctx, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Invitation)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(context.Context, paginator.Query) int); ok {
+		r1 = rf(ctx, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, paginator.Query) error); ok {
+		r2 = rf(ctx, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// LicenseLoad provides a mock function with given fields: ctx
+func (_m *Store) LicenseLoad(ctx context.Context) (*models.License, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *models.License
+	if rf, ok := ret.Get(0).(func(context.Context) *models.License); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.License)
@@ -930,6 +1563,29 @@ func (_m *Store) NamespaceList(ctx context.Context, pagination paginator.Query,
 	return r0, r1, r2
 }
 
+// NamespaceListByMember provides a mock function with given fields: ctx, id
+func (_m *Store) NamespaceListByMember(ctx context.Context, id string) ([]models.Namespace, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 []models.Namespace
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.Namespace); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Namespace)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NamespaceRemoveMember provides a mock function with given fields: ctx, tenantID, memberID
 func (_m *Store) NamespaceRemoveMember(ctx context.Context, tenantID string, memberID string) (*models.Namespace, error) {
 	ret := _m.Called(ctx, tenantID, memberID)
@@ -976,6 +1632,48 @@ func (_m *Store) NamespaceRename(ctx context.Context, tenantID string, name stri
 	return r0, r1
 }
 
+// NamespaceSetDeviceNaming provides a mock function with given fields: ctx, tenantID, naming
+func (_m *Store) NamespaceSetDeviceNaming(ctx context.Context, tenantID string, naming *models.DeviceNaming) error {
+	ret := _m.Called(ctx, tenantID, naming)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.DeviceNaming) error); ok {
+		r0 = rf(ctx, tenantID, naming)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NamespaceSetGeoAccess provides a mock function with given fields: ctx, tenantID, geo
+func (_m *Store) NamespaceSetGeoAccess(ctx context.Context, tenantID string, geo *models.GeoAccess) error {
+	ret := _m.Called(ctx, tenantID, geo)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.GeoAccess) error); ok {
+		r0 = rf(ctx, tenantID, geo)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NamespaceSetMemberScope provides a mock function with given fields: ctx, tenantID, memberID, scope
+func (_m *Store) NamespaceSetMemberScope(ctx context.Context, tenantID string, memberID string, scope *models.MemberScope) error {
+	ret := _m.Called(ctx, tenantID, memberID, scope)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.MemberScope) error); ok {
+		r0 = rf(ctx, tenantID, memberID, scope)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NamespaceSetSessionRecord provides a mock function with given fields: ctx, sessionRecord, tenantID
 func (_m *Store) NamespaceSetSessionRecord(ctx context.Context, sessionRecord bool, tenantID string) error {
 	ret := _m.Called(ctx, sessionRecord, tenantID)
@@ -990,6 +1688,34 @@ func (_m *Store) NamespaceSetSessionRecord(ctx context.Context, sessionRecord bo
 	return r0
 }
 
+// NamespaceSetSessionTypes provides a mock function with given fields: ctx, tenantID, types
+func (_m *Store) NamespaceSetSessionTypes(ctx context.Context, tenantID string, types *models.SessionTypes) error {
+	ret := _m.Called(ctx, tenantID, types)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.SessionTypes) error); ok {
+		r0 = rf(ctx, tenantID, types)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NamespaceTransferOwnership provides a mock function with given fields: ctx, tenantID, transfer
+func (_m *Store) NamespaceTransferOwnership(ctx context.Context, tenantID string, transfer *models.OwnershipTransfer) error {
+	ret := _m.Called(ctx, tenantID, transfer)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.OwnershipTransfer) error); ok {
+		r0 = rf(ctx, tenantID, transfer)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NamespaceUpdate provides a mock function with given fields: ctx, tenantID, namespace
 func (_m *Store) NamespaceUpdate(ctx context.Context, tenantID string, namespace *models.Namespace) error {
 	ret := _m.Called(ctx, tenantID, namespace)
@@ -1049,19 +1775,218 @@ func (_m *Store) PublicKeyAddTag(ctx context.Context, tenant string, fingerprint
 	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
 		r0 = rf(ctx, tenant, fingerprint, tag)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PublicKeyCreate provides a mock function with given fields: ctx, key
+func (_m *Store) PublicKeyCreate(ctx context.Context, key *models.PublicKey) error {
+	ret := _m.Called(ctx, key)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PublicKey) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PublicKeyDelete provides a mock function with given fields: ctx, fingerprint, tenantID
+func (_m *Store) PublicKeyDelete(ctx context.Context, fingerprint string, tenantID string) error {
+	ret := _m.Called(ctx, fingerprint, tenantID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, fingerprint, tenantID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PublicKeyDeleteTag provides a mock function with given fields: ctx, tenant, name
+func (_m *Store) PublicKeyDeleteTag(ctx context.Context, tenant string, name string) error {
+	ret := _m.Called(ctx, tenant, name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PublicKeyGet provides a mock function with given fields: ctx, fingerprint, tenantID
+func (_m *Store) PublicKeyGet(ctx context.Context, fingerprint string, tenantID string) (*models.PublicKey, error) {
+	ret := _m.Called(ctx, fingerprint, tenantID)
+
+	var r0 *models.PublicKey
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.PublicKey); ok {
+		r0 = rf(ctx, fingerprint, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PublicKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, fingerprint, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PublicKeyGetTags provides a mock function with given fields: ctx, tenant
+func (_m *Store) PublicKeyGetTags(ctx context.Context, tenant string) ([]string, int, error) {
+	ret := _m.Called(ctx, tenant)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, tenant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(context.Context, string) int); ok {
+		r1 = rf(ctx, tenant)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, tenant)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// PublicKeyList provides a mock function with given fields: ctx, pagination
+func (_m *Store) PublicKeyList(ctx context.Context, pagination paginator.Query) ([]models.PublicKey, int, error) {
+	ret := _m.Called(ctx, pagination)
+
+	var r0 []models.PublicKey
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) []models.PublicKey); ok {
+		r0 = rf(ctx, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PublicKey)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(context.Context, paginator.Query) int); ok {
+		r1 = rf(ctx, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, paginator.Query) error); ok {
+		r2 = rf(ctx, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// PublicKeyListByTenant provides a mock function with given fields: ctx, tenantID
+func (_m *Store) PublicKeyListByTenant(ctx context.Context, tenantID string) ([]models.PublicKey, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	var r0 []models.PublicKey
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.PublicKey); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PublicKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PublicKeyRemoveTag provides a mock function with given fields: ctx, tenant, fingerprint, tag
+func (_m *Store) PublicKeyRemoveTag(ctx context.Context, tenant string, fingerprint string, tag string) error {
+	ret := _m.Called(ctx, tenant, fingerprint, tag)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, tenant, fingerprint, tag)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PublicKeyRenameTag provides a mock function with given fields: ctx, tenant, old, neo
+func (_m *Store) PublicKeyRenameTag(ctx context.Context, tenant string, old string, neo string) error {
+	ret := _m.Called(ctx, tenant, old, neo)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, tenant, old, neo)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PublicKeyUpdate provides a mock function with given fields: ctx, fingerprint, tenantID, key
+func (_m *Store) PublicKeyUpdate(ctx context.Context, fingerprint string, tenantID string, key *models.PublicKeyUpdate) (*models.PublicKey, error) {
+	ret := _m.Called(ctx, fingerprint, tenantID, key)
+
+	var r0 *models.PublicKey
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.PublicKeyUpdate) *models.PublicKey); ok {
+		r0 = rf(ctx, fingerprint, tenantID, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PublicKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *models.PublicKeyUpdate) error); ok {
+		r1 = rf(ctx, fingerprint, tenantID, key)
+	} else {
+		r1 = ret.Error(1)
 	}
 
-	return r0
+	return r0, r1
 }
 
-// PublicKeyCreate provides a mock function with given fields: ctx, key
-func (_m *Store) PublicKeyCreate(ctx context.Context, key *models.PublicKey) error {
-	ret := _m.Called(ctx, key)
+// PublicKeyUpdateTags provides a mock function with given fields: ctx, tenant, fingerprint, tags
+func (_m *Store) PublicKeyUpdateTags(ctx context.Context, tenant string, fingerprint string, tags []string) error {
+	ret := _m.Called(ctx, tenant, fingerprint, tags)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *models.PublicKey) error); ok {
-		r0 = rf(ctx, key)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string) error); ok {
+		r0 = rf(ctx, tenant, fingerprint, tags)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1069,13 +1994,13 @@ func (_m *Store) PublicKeyCreate(ctx context.Context, key *models.PublicKey) err
 	return r0
 }
 
-// PublicKeyDelete provides a mock function with given fields: ctx, fingerprint, tenantID
-func (_m *Store) PublicKeyDelete(ctx context.Context, fingerprint string, tenantID string) error {
-	ret := _m.Called(ctx, fingerprint, tenantID)
+// RoleCreate provides a mock function with given fields: ctx, role
+func (_m *Store) RoleCreate(ctx context.Context, role *models.Role) error {
+	ret := _m.Called(ctx, role)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(ctx, fingerprint, tenantID)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Role) error); ok {
+		r0 = rf(ctx, role)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1083,13 +2008,13 @@ func (_m *Store) PublicKeyDelete(ctx context.Context, fingerprint string, tenant
 	return r0
 }
 
-// PublicKeyDeleteTag provides a mock function with given fields: ctx, tenant, name
-func (_m *Store) PublicKeyDeleteTag(ctx context.Context, tenant string, name string) error {
-	ret := _m.Called(ctx, tenant, name)
+// RoleDelete provides a mock function with given fields: ctx, tenant, id
+func (_m *Store) RoleDelete(ctx context.Context, tenant string, id string) error {
+	ret := _m.Called(ctx, tenant, id)
 
 	var r0 error
 	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(ctx, tenant, name)
+		r0 = rf(ctx, tenant, id)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1097,22 +2022,22 @@ func (_m *Store) PublicKeyDeleteTag(ctx context.Context, tenant string, name str
 	return r0
 }
 
-// PublicKeyGet provides a mock function with given fields: ctx, fingerprint, tenantID
-func (_m *Store) PublicKeyGet(ctx context.Context, fingerprint string, tenantID string) (*models.PublicKey, error) {
-	ret := _m.Called(ctx, fingerprint, tenantID)
+// RoleGet provides a mock function with given fields: ctx, tenant, id
+func (_m *Store) RoleGet(ctx context.Context, tenant string, id string) (*models.Role, error) {
+	ret := _m.Called(ctx, tenant, id)
 
-	var r0 *models.PublicKey
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.PublicKey); ok {
-		r0 = rf(ctx, fingerprint, tenantID)
+	var r0 *models.Role
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Role); ok {
+		r0 = rf(ctx, tenant, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.PublicKey)
+			r0 = ret.Get(0).(*models.Role)
 		}
 	}
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, fingerprint, tenantID)
+		r1 = rf(ctx, tenant, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1120,46 +2045,39 @@ func (_m *Store) PublicKeyGet(ctx context.Context, fingerprint string, tenantID
 	return r0, r1
 }
 
-// PublicKeyGetTags provides a mock function with given fields: ctx, tenant
-func (_m *Store) PublicKeyGetTags(ctx context.Context, tenant string) ([]string, int, error) {
-	ret := _m.Called(ctx, tenant)
+// RoleGetByName provides a mock function with given fields: ctx, tenant, name
+func (_m *Store) RoleGetByName(ctx context.Context, tenant string, name string) (*models.Role, error) {
+	ret := _m.Called(ctx, tenant, name)
 
-	var r0 []string
-	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
-		r0 = rf(ctx, tenant)
+	var r0 *models.Role
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Role); ok {
+		r0 = rf(ctx, tenant, name)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
+			r0 = ret.Get(0).(*models.Role)
 		}
 	}
 
-	var r1 int
-	if rf, ok := ret.Get(1).(func(context.Context, string) int); ok {
-		r1 = rf(ctx, tenant)
-	} else {
-		r1 = ret.Get(1).(int)
-	}
-
-	var r2 error
-	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
-		r2 = rf(ctx, tenant)
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, name)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2
+	return r0, r1
 }
 
-// PublicKeyList provides a mock function with given fields: ctx, pagination
-func (_m *Store) PublicKeyList(ctx context.Context, pagination paginator.Query) ([]models.PublicKey, int, error) {
+// RoleList provides a mock function with given fields: ctx, pagination
+func (_m *Store) RoleList(ctx context.Context, pagination paginator.Query) ([]models.Role, int, error) {
 	ret := _m.Called(ctx, pagination)
 
-	var r0 []models.PublicKey
-	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) []models.PublicKey); ok {
+	var r0 []models.Role
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) []models.Role); ok {
 		r0 = rf(ctx, pagination)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.PublicKey)
+			r0 = ret.Get(0).([]models.Role)
 		}
 	}
 
@@ -1180,27 +2098,36 @@ func (_m *Store) PublicKeyList(ctx context.Context, pagination paginator.Query)
 	return r0, r1, r2
 }
 
-// PublicKeyRemoveTag provides a mock function with given fields: ctx, tenant, fingerprint, tag
-func (_m *Store) PublicKeyRemoveTag(ctx context.Context, tenant string, fingerprint string, tag string) error {
-	ret := _m.Called(ctx, tenant, fingerprint, tag)
+// RoleUpdate provides a mock function with given fields: ctx, tenant, id, role
+func (_m *Store) RoleUpdate(ctx context.Context, tenant string, id string, role *models.RoleUpdate) (*models.Role, error) {
+	ret := _m.Called(ctx, tenant, id, role)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
-		r0 = rf(ctx, tenant, fingerprint, tag)
+	var r0 *models.Role
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.RoleUpdate) *models.Role); ok {
+		r0 = rf(ctx, tenant, id, role)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Role)
+		}
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *models.RoleUpdate) error); ok {
+		r1 = rf(ctx, tenant, id, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// PublicKeyRenameTag provides a mock function with given fields: ctx, tenant, old, neo
-func (_m *Store) PublicKeyRenameTag(ctx context.Context, tenant string, old string, neo string) error {
-	ret := _m.Called(ctx, tenant, old, neo)
+// SessionApprovalCreate provides a mock function with given fields: ctx, approval
+func (_m *Store) SessionApprovalCreate(ctx context.Context, approval *models.SessionApproval) error {
+	ret := _m.Called(ctx, approval)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
-		r0 = rf(ctx, tenant, old, neo)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.SessionApproval) error); ok {
+		r0 = rf(ctx, approval)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1208,22 +2135,22 @@ func (_m *Store) PublicKeyRenameTag(ctx context.Context, tenant string, old stri
 	return r0
 }
 
-// PublicKeyUpdate provides a mock function with given fields: ctx, fingerprint, tenantID, key
-func (_m *Store) PublicKeyUpdate(ctx context.Context, fingerprint string, tenantID string, key *models.PublicKeyUpdate) (*models.PublicKey, error) {
-	ret := _m.Called(ctx, fingerprint, tenantID, key)
+// SessionApprovalDecide provides a mock function with given fields: ctx, uid, status, userID, decidedAt
+func (_m *Store) SessionApprovalDecide(ctx context.Context, uid models.UID, status string, userID string, decidedAt time.Time) (*models.SessionApproval, error) {
+	ret := _m.Called(ctx, uid, status, userID, decidedAt)
 
-	var r0 *models.PublicKey
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.PublicKeyUpdate) *models.PublicKey); ok {
-		r0 = rf(ctx, fingerprint, tenantID, key)
+	var r0 *models.SessionApproval
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string, string, time.Time) *models.SessionApproval); ok {
+		r0 = rf(ctx, uid, status, userID, decidedAt)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.PublicKey)
+			r0 = ret.Get(0).(*models.SessionApproval)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, *models.PublicKeyUpdate) error); ok {
-		r1 = rf(ctx, fingerprint, tenantID, key)
+	if rf, ok := ret.Get(1).(func(context.Context, models.UID, string, string, time.Time) error); ok {
+		r1 = rf(ctx, uid, status, userID, decidedAt)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1231,18 +2158,57 @@ func (_m *Store) PublicKeyUpdate(ctx context.Context, fingerprint string, tenant
 	return r0, r1
 }
 
-// PublicKeyUpdateTags provides a mock function with given fields: ctx, tenant, fingerprint, tags
-func (_m *Store) PublicKeyUpdateTags(ctx context.Context, tenant string, fingerprint string, tags []string) error {
-	ret := _m.Called(ctx, tenant, fingerprint, tags)
+// SessionApprovalGet provides a mock function with given fields: ctx, uid
+func (_m *Store) SessionApprovalGet(ctx context.Context, uid models.UID) (*models.SessionApproval, error) {
+	ret := _m.Called(ctx, uid)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string) error); ok {
-		r0 = rf(ctx, tenant, fingerprint, tags)
+	var r0 *models.SessionApproval
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) *models.SessionApproval); ok {
+		r0 = rf(ctx, uid)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SessionApproval)
+		}
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.UID) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SessionApprovalList provides a mock function with given fields: ctx, pagination
+func (_m *Store) SessionApprovalList(ctx context.Context, pagination paginator.Query) ([]models.SessionApproval, int, error) {
+	ret := _m.Called(ctx, pagination)
+
+	var r0 []models.SessionApproval
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) []models.SessionApproval); ok {
+		r0 = rf(ctx, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.SessionApproval)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(context.Context, paginator.Query) int); ok {
+		r1 = rf(ctx, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, paginator.Query) error); ok {
+		r2 = rf(ctx, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
 // SessionCreate provides a mock function with given fields: ctx, session
@@ -1793,3 +2759,174 @@ func (_m *Store) UserUpdatePassword(ctx context.Context, newPassword string, id
 
 	return r0
 }
+
+// WebhookCreate provides a mock function with given fields: ctx, webhook
+func (_m *Store) WebhookCreate(ctx context.Context, webhook *models.Webhook) error {
+	ret := _m.Called(ctx, webhook)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Webhook) error); ok {
+		r0 = rf(ctx, webhook)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookDelete provides a mock function with given fields: ctx, tenant, id
+func (_m *Store) WebhookDelete(ctx context.Context, tenant string, id string) error {
+	ret := _m.Called(ctx, tenant, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookDeliveryCreate provides a mock function with given fields: ctx, delivery
+func (_m *Store) WebhookDeliveryCreate(ctx context.Context, delivery *models.WebhookDelivery) error {
+	ret := _m.Called(ctx, delivery)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.WebhookDelivery) error); ok {
+		r0 = rf(ctx, delivery)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookDeliveryList provides a mock function with given fields: ctx, tenant, id, pagination
+func (_m *Store) WebhookDeliveryList(ctx context.Context, tenant string, id string, pagination paginator.Query) ([]models.WebhookDelivery, int, error) {
+	ret := _m.Called(ctx, tenant, id, pagination)
+
+	var r0 []models.WebhookDelivery
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, paginator.Query) []models.WebhookDelivery); ok {
+		r0 = rf(ctx, tenant, id, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.WebhookDelivery)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, id, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, id, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// WebhookGet provides a mock function with given fields: ctx, tenant, id
+func (_m *Store) WebhookGet(ctx context.Context, tenant string, id string) (*models.Webhook, error) {
+	ret := _m.Called(ctx, tenant, id)
+
+	var r0 *models.Webhook
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Webhook); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Webhook)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookList provides a mock function with given fields: ctx, pagination
+func (_m *Store) WebhookList(ctx context.Context, pagination paginator.Query) ([]models.Webhook, int, error) {
+	ret := _m.Called(ctx, pagination)
+
+	var r0 []models.Webhook
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) []models.Webhook); ok {
+		r0 = rf(ctx, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Webhook)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(context.Context, paginator.Query) int); ok {
+		r1 = rf(ctx, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, paginator.Query) error); ok {
+		r2 = rf(ctx, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// WebhookListByEvent provides a mock function with given fields: ctx, tenant, event
+func (_m *Store) WebhookListByEvent(ctx context.Context, tenant string, event string) ([]models.Webhook, error) {
+	ret := _m.Called(ctx, tenant, event)
+
+	var r0 []models.Webhook
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []models.Webhook); ok {
+		r0 = rf(ctx, tenant, event)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Webhook)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, event)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookUpdate provides a mock function with given fields: ctx, tenant, id, webhook
+func (_m *Store) WebhookUpdate(ctx context.Context, tenant string, id string, webhook *models.WebhookUpdate) (*models.Webhook, error) {
+	ret := _m.Called(ctx, tenant, id, webhook)
+
+	var r0 *models.Webhook
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.WebhookUpdate) *models.Webhook); ok {
+		r0 = rf(ctx, tenant, id, webhook)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Webhook)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *models.WebhookUpdate) error); ok {
+		r1 = rf(ctx, tenant, id, webhook)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,96 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (s *Store) AuditList(ctx context.Context, pagination paginator.Query, filter *models.AuditLogFilter) ([]models.AuditLog, int, error) {
+	match := bson.M{}
+	if filter.Actor != "" {
+		match["actor"] = filter.Actor
+	}
+
+	if filter.Action != "" {
+		match["action"] = filter.Action
+	}
+
+	if filter.Target != "" {
+		match["target"] = filter.Target
+	}
+
+	period := bson.M{}
+	if !filter.Since.IsZero() {
+		period["$gte"] = filter.Since
+	}
+
+	if !filter.Until.IsZero() {
+		period["$lte"] = filter.Until
+	}
+
+	if len(period) > 0 {
+		match["created_at"] = period
+	}
+
+	// Only match for the respective tenant if requested
+	if tenant := gateway.TenantFromContext(ctx); tenant != nil {
+		match["tenant_id"] = tenant.ID
+	}
+
+	query := []bson.M{
+		{
+			"$match": match,
+		},
+		{
+			"$sort": bson.M{
+				"created_at": -1,
+			},
+		},
+	}
+
+	queryCount := query
+	queryCount = append(queryCount, bson.M{"$count": "count"})
+	count, err := aggregateCount(ctx, s.db.Collection("audit_logs"), queryCount)
+	if err != nil {
+		return nil, 0, fromMongoError(err)
+	}
+
+	query = append(query, queries.BuildPaginationQuery(pagination)...)
+
+	logs := make([]models.AuditLog, 0)
+	cursor, err := s.db.Collection("audit_logs").Aggregate(ctx, query)
+	if err != nil {
+		return logs, count, fromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		log := new(models.AuditLog)
+		if err := cursor.Decode(&log); err != nil {
+			return logs, count, fromMongoError(err)
+		}
+
+		logs = append(logs, *log)
+	}
+
+	return logs, count, nil
+}
+
+func (s *Store) AuditCreate(ctx context.Context, log *models.AuditLog) error {
+	result, err := s.db.Collection("audit_logs").InsertOne(ctx, log)
+	if err != nil {
+		return fromMongoError(err)
+	}
+
+	if objID, ok := result.InsertedID.(primitive.ObjectID); ok {
+		log.ID = objID.Hex()
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package mongo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/cache"
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAudit(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	accepted := &models.AuditLog{
+		TenantID:  "tenant",
+		Actor:     "owner",
+		Action:    models.AuditDeviceAccept,
+		Target:    "uid",
+		Before:    map[string]interface{}{"status": "pending"},
+		After:     map[string]interface{}{"status": "accepted"},
+		CreatedAt: now.Add(-time.Hour),
+	}
+
+	renamed := &models.AuditLog{
+		TenantID:  "tenant",
+		Actor:     "admin",
+		Action:    models.AuditDeviceRename,
+		Target:    "uid",
+		Before:    map[string]interface{}{"name": "device", "tags": []interface{}{"tag"}},
+		After:     map[string]interface{}{"name": "renamed", "tags": []interface{}{"tag"}},
+		CreatedAt: now,
+	}
+
+	for _, log := range []*models.AuditLog{accepted, renamed} {
+		err := mongostore.AuditCreate(data.Context, log)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, log.ID)
+	}
+
+	logs, count, err := mongostore.AuditList(data.Context, paginator.Query{Page: -1, PerPage: -1}, &models.AuditLogFilter{})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, []models.AuditLog{*renamed, *accepted}, logs)
+
+	logs, count, err = mongostore.AuditList(data.Context, paginator.Query{Page: -1, PerPage: -1},
+		&models.AuditLogFilter{Actor: "owner", Target: "uid"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, []models.AuditLog{*accepted}, logs)
+
+	_, count, err = mongostore.AuditList(data.Context, paginator.Query{Page: -1, PerPage: -1},
+		&models.AuditLogFilter{Action: models.AuditDeviceRename, Since: now.Add(-time.Minute)})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	_, count, err = mongostore.AuditList(data.Context, paginator.Query{Page: -1, PerPage: -1},
+		&models.AuditLogFilter{Until: now.Add(-2 * time.Hour)})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
@@ -0,0 +1,248 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// decodeDeviceGroupFilter converts the filter's params decoded from the database as generic documents to the
+// structures expected by queries.BuildFilterQuery.
+func decodeDeviceGroupFilter(group *models.DeviceGroup) error {
+	for i, filter := range group.Filter {
+		data, err := bson.Marshal(filter.Params)
+		if err != nil {
+			return err
+		}
+
+		switch filter.Type {
+		case "property":
+			params := new(models.PropertyParams)
+			if err := bson.Unmarshal(data, params); err != nil {
+				return err
+			}
+
+			if value, ok := params.Value.(primitive.A); ok {
+				params.Value = []interface{}(value)
+			}
+
+			group.Filter[i].Params = params
+		case "operator":
+			params := new(models.OperatorParams)
+			if err := bson.Unmarshal(data, params); err != nil {
+				return err
+			}
+
+			group.Filter[i].Params = params
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceGroupList(ctx context.Context, pagination paginator.Query) ([]models.DeviceGroup, int, error) {
+	query := []bson.M{
+		{
+			"$sort": bson.M{
+				"name": 1,
+			},
+		},
+	}
+
+	// Only match for the respective tenant if requested
+	if tenant := gateway.TenantFromContext(ctx); tenant != nil {
+		query = append(query, bson.M{
+			"$match": bson.M{
+				"tenant_id": tenant.ID,
+			},
+		})
+	}
+
+	queryCount := query
+	queryCount = append(queryCount, bson.M{"$count": "count"})
+	count, err := aggregateCount(ctx, s.db.Collection("device_groups"), queryCount)
+	if err != nil {
+		return nil, 0, fromMongoError(err)
+	}
+
+	query = append(query, queries.BuildPaginationQuery(pagination)...)
+
+	groups := make([]models.DeviceGroup, 0)
+	cursor, err := s.db.Collection("device_groups").Aggregate(ctx, query)
+	if err != nil {
+		return groups, count, fromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		group := new(models.DeviceGroup)
+		if err := cursor.Decode(&group); err != nil {
+			return groups, count, fromMongoError(err)
+		}
+
+		if err := decodeDeviceGroupFilter(group); err != nil {
+			return groups, count, err
+		}
+
+		groups = append(groups, *group)
+	}
+
+	return groups, count, nil
+}
+
+func (s *Store) DeviceGroupGet(ctx context.Context, tenant string, id string) (*models.DeviceGroup, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fromMongoError(err)
+	}
+
+	group := new(models.DeviceGroup)
+	if err := s.db.Collection("device_groups").FindOne(ctx, bson.M{"_id": objID, "tenant_id": tenant}).Decode(&group); err != nil {
+		return nil, fromMongoError(err)
+	}
+
+	if err := decodeDeviceGroupFilter(group); err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+func (s *Store) DeviceGroupCreate(ctx context.Context, group *models.DeviceGroup) error {
+	result, err := s.db.Collection("device_groups").InsertOne(ctx, group)
+	if err != nil {
+		return fromMongoError(err)
+	}
+
+	if objID, ok := result.InsertedID.(primitive.ObjectID); ok {
+		group.ID = objID.Hex()
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceGroupUpdate(ctx context.Context, tenant string, id string, group *models.DeviceGroupUpdate) (*models.DeviceGroup, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fromMongoError(err)
+	}
+
+	// As the static devices and the filter are mutually exclusive, the one not set on the update must be removed.
+	unset := bson.M{}
+	if group.Description == "" {
+		unset["description"] = ""
+	}
+
+	if group.Devices == nil {
+		unset["devices"] = ""
+	}
+
+	if group.Filter == nil {
+		unset["filter"] = ""
+	}
+
+	update := bson.M{"$set": group}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	result, err := s.db.Collection("device_groups").UpdateOne(ctx, bson.M{"_id": objID, "tenant_id": tenant}, update)
+	if err != nil {
+		return nil, fromMongoError(err)
+	}
+
+	if result.MatchedCount < 1 {
+		return nil, store.ErrNoDocuments
+	}
+
+	return s.DeviceGroupGet(ctx, tenant, id)
+}
+
+func (s *Store) DeviceGroupDelete(ctx context.Context, tenant string, id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fromMongoError(err)
+	}
+
+	result, err := s.db.Collection("device_groups").DeleteOne(ctx, bson.M{"_id": objID, "tenant_id": tenant})
+	if err != nil {
+		return fromMongoError(err)
+	}
+
+	if result.DeletedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceGroupMembers(ctx context.Context, group *models.DeviceGroup) ([]models.UID, error) {
+	query := []bson.M{
+		{
+			"$match": bson.M{
+				"tenant_id": group.TenantID,
+				"status":    bson.M{"$ne": "decommissioned"},
+			},
+		},
+	}
+
+	if group.Filter != nil {
+		queryMatch, err := queries.BuildFilterQuery(group.Filter)
+		if err != nil {
+			return nil, fromMongoError(err)
+		}
+
+		query = append(query, []bson.M{
+			{
+				"$lookup": bson.M{
+					"from":         "connected_devices",
+					"localField":   "uid",
+					"foreignField": "uid",
+					"as":           "online",
+				},
+			},
+			{
+				"$addFields": bson.M{
+					"online": bson.M{"$anyElementTrue": []interface{}{"$online"}},
+				},
+			},
+		}...)
+		query = append(query, queryMatch...)
+	} else {
+		query = append(query, bson.M{
+			"$match": bson.M{
+				"uid": bson.M{"$in": group.Devices},
+			},
+		})
+	}
+
+	query = append(query, bson.M{
+		"$project": bson.M{
+			"uid": 1,
+		},
+	})
+
+	cursor, err := s.db.Collection("devices").Aggregate(ctx, query)
+	if err != nil {
+		return nil, fromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	uids := make([]models.UID, 0)
+	for cursor.Next(ctx) {
+		device := new(models.Device)
+		if err := cursor.Decode(&device); err != nil {
+			return nil, fromMongoError(err)
+		}
+
+		uids = append(uids, models.UID(device.UID))
+	}
+
+	return uids, nil
+}
@@ -0,0 +1,107 @@
+package mongo
+
+import (
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/cache"
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeviceGroupCRUD(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	group := &models.DeviceGroup{
+		TenantID: data.Namespace.TenantID,
+		DeviceGroupFields: models.DeviceGroupFields{
+			Name:    "gateways",
+			Devices: []string{data.Device.UID},
+		},
+	}
+
+	err := mongostore.DeviceGroupCreate(data.Context, group)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, group.ID)
+
+	groups, count, err := mongostore.DeviceGroupList(data.Context, paginator.Query{Page: -1, PerPage: -1})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, "gateways", groups[0].Name)
+
+	updated, err := mongostore.DeviceGroupUpdate(data.Context, data.Namespace.TenantID, group.ID, &models.DeviceGroupUpdate{
+		DeviceGroupFields: models.DeviceGroupFields{
+			Name: "sitex",
+			Filter: []models.Filter{
+				{
+					Type:   "property",
+					Params: &models.PropertyParams{Name: "tags", Operator: "contains", Value: []interface{}{"sitex"}},
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "sitex", updated.Name)
+	assert.Nil(t, updated.Devices)
+	assert.Equal(t, &models.PropertyParams{Name: "tags", Operator: "contains", Value: []interface{}{"sitex"}}, updated.Filter[0].Params)
+
+	err = mongostore.DeviceGroupDelete(data.Context, data.Namespace.TenantID, group.ID)
+	assert.NoError(t, err)
+
+	_, err = mongostore.DeviceGroupGet(data.Context, data.Namespace.TenantID, group.ID)
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+}
+
+func TestDeviceGroupMembers(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	_, err := mongostore.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	err = mongostore.DeviceCreate(data.Context, data.Device, "hostname")
+	assert.NoError(t, err)
+
+	err = mongostore.DeviceCreateTag(data.Context, models.UID(data.Device.UID), "sitex")
+	assert.NoError(t, err)
+
+	static := &models.DeviceGroup{
+		TenantID: data.Namespace.TenantID,
+		DeviceGroupFields: models.DeviceGroupFields{
+			Name:    "static",
+			Devices: []string{data.Device.UID, "nonexistent"},
+		},
+	}
+
+	uids, err := mongostore.DeviceGroupMembers(data.Context, static)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.UID{models.UID(data.Device.UID)}, uids)
+
+	filtered := &models.DeviceGroup{
+		TenantID: data.Namespace.TenantID,
+		DeviceGroupFields: models.DeviceGroupFields{
+			Name: "filtered",
+			Filter: []models.Filter{
+				{
+					Type:   "property",
+					Params: &models.PropertyParams{Name: "tags", Operator: "contains", Value: []interface{}{"sitex"}},
+				},
+			},
+		},
+	}
+
+	uids, err = mongostore.DeviceGroupMembers(data.Context, filtered)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.UID{models.UID(data.Device.UID)}, uids)
+}
@@ -6,16 +6,18 @@ import (
 	"time"
 
 	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
 	"github.com/shellhub-io/shellhub/pkg/api/paginator"
 	"github.com/shellhub-io/shellhub/pkg/clock"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-func (s *Store) DeviceList(ctx context.Context, pagination paginator.Query, filters []models.Filter, status string, sort string, order string) ([]models.Device, int, error) {
+func (s *Store) DeviceList(ctx context.Context, pagination paginator.Query, filters []models.Filter, status string, sort string, order string, uids []models.UID) ([]models.Device, int, error) {
 	queryMatch, err := queries.BuildFilterQuery(filters)
 	if err != nil {
 		return nil, 0, fromMongoError(err)
@@ -53,12 +55,27 @@ func (s *Store) DeviceList(ctx context.Context, pagination paginator.Query, filt
 		},
 	}
 
+	// Decommissioned devices are only listed when explicitly requested.
 	if status != "" {
 		query = append([]bson.M{{
 			"$match": bson.M{
 				"status": status,
 			},
 		}}, query...)
+	} else {
+		query = append([]bson.M{{
+			"$match": bson.M{
+				"status": bson.M{"$ne": "decommissioned"},
+			},
+		}}, query...)
+	}
+
+	if uids != nil {
+		query = append([]bson.M{{
+			"$match": bson.M{
+				"uid": bson.M{"$in": uids},
+			},
+		}}, query...)
 	}
 
 	orderVal := map[string]int{
@@ -171,7 +188,14 @@ func (s *Store) DeviceGet(ctx context.Context, uid models.UID) (*models.Device,
 		return nil, fromMongoError(err)
 	}
 	defer cursor.Close(ctx)
-	cursor.Next(ctx)
+
+	if !cursor.Next(ctx) {
+		if err := cursor.Err(); err != nil {
+			return nil, fromMongoError(err)
+		}
+
+		return nil, store.ErrNoDocuments
+	}
 
 	err = cursor.Decode(&device)
 	if err != nil {
@@ -199,6 +223,104 @@ func (s *Store) DeviceDelete(ctx context.Context, uid models.UID) error {
 	return fromMongoError(err)
 }
 
+func (s *Store) DeviceDecommission(ctx context.Context, uid models.UID) error {
+	update := bson.M{
+		"$set": bson.M{
+			"status":            "decommissioned",
+			"decommissioned_at": clock.Now(),
+		},
+	}
+
+	result, err := s.db.Collection("devices").UpdateOne(ctx, bson.M{"uid": uid}, update)
+	if err != nil {
+		return fromMongoError(err)
+	}
+
+	if result.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	if err := s.cache.Delete(ctx, string(uid)); err != nil {
+		logrus.Error(err)
+	}
+
+	_, err = s.db.Collection("connected_devices").DeleteMany(ctx, bson.M{"uid": uid})
+
+	return fromMongoError(err)
+}
+
+func (s *Store) DeviceTransfer(ctx context.Context, transfer *models.DeviceTransfer, sessions bool) error {
+	result, err := s.db.Collection("devices").UpdateOne(ctx,
+		bson.M{"uid": transfer.UID},
+		bson.M{"$set": bson.M{"uid": transfer.NewUID, "tenant_id": transfer.TenantID}},
+	)
+	if err != nil {
+		return fromMongoError(err)
+	}
+
+	if result.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	if err := s.cache.Delete(ctx, transfer.UID); err != nil {
+		logrus.Error(err)
+	}
+
+	if _, err := s.db.Collection("connected_devices").DeleteMany(ctx, bson.M{"uid": transfer.UID}); err != nil {
+		return fromMongoError(err)
+	}
+
+	if _, err := s.db.Collection("device_history").UpdateMany(ctx,
+		bson.M{"uid": transfer.UID},
+		bson.M{"$set": bson.M{"uid": transfer.NewUID, "tenant_id": transfer.TenantID}},
+	); err != nil {
+		return fromMongoError(err)
+	}
+
+	if sessions {
+		uids, err := s.db.Collection("sessions").Distinct(ctx, "uid", bson.M{"device_uid": transfer.UID})
+		if err != nil {
+			return fromMongoError(err)
+		}
+
+		if _, err := s.db.Collection("sessions").UpdateMany(ctx,
+			bson.M{"device_uid": transfer.UID},
+			bson.M{"$set": bson.M{"device_uid": transfer.NewUID, "tenant_id": transfer.TenantID}},
+		); err != nil {
+			return fromMongoError(err)
+		}
+
+		if _, err := s.db.Collection("recorded_sessions").UpdateMany(ctx,
+			bson.M{"uid": bson.M{"$in": uids}},
+			bson.M{"$set": bson.M{"tenant_id": transfer.TenantID}},
+		); err != nil {
+			return fromMongoError(err)
+		}
+	}
+
+	// A device transferred back to a namespace where it was before must not be redirected anymore.
+	if _, err := s.db.Collection("device_transfers").DeleteMany(ctx, bson.M{"uid": transfer.NewUID}); err != nil {
+		return fromMongoError(err)
+	}
+
+	_, err = s.db.Collection("device_transfers").UpdateOne(ctx,
+		bson.M{"uid": transfer.UID},
+		bson.M{"$set": transfer},
+		options.Update().SetUpsert(true),
+	)
+
+	return fromMongoError(err)
+}
+
+func (s *Store) DeviceTransferGet(ctx context.Context, uid models.UID) (*models.DeviceTransfer, error) {
+	transfer := new(models.DeviceTransfer)
+	if err := s.db.Collection("device_transfers").FindOne(ctx, bson.M{"uid": uid}).Decode(&transfer); err != nil {
+		return nil, fromMongoError(err)
+	}
+
+	return transfer, nil
+}
+
 func (s *Store) DeviceCreate(ctx context.Context, d models.Device, hostname string) error {
 	if hostname == "" {
 		hostname = strings.ReplaceAll(d.Identity.MAC, ":", "-")
@@ -209,6 +331,14 @@ func (s *Store) DeviceCreate(ctx context.Context, d models.Device, hostname stri
 		logrus.Error(err)
 	}
 
+	// A decommissioned device that authenticates again is registered back as a pending device.
+	if _, err := s.db.Collection("devices").UpdateOne(ctx,
+		bson.M{"uid": d.UID, "status": "decommissioned"},
+		bson.M{"$set": bson.M{"status": "pending"}, "$unset": bson.M{"decommissioned_at": ""}},
+	); err != nil {
+		return fromMongoError(err)
+	}
+
 	q := bson.M{
 		"$setOnInsert": bson.M{
 			"name":       hostname,
@@ -378,15 +508,100 @@ func (s *Store) DeviceGetByMac(ctx context.Context, mac string, tenantID string,
 	return device, nil
 }
 
+// DeviceGetByName gets a device of a namespace by its name. A decommissioned device is not returned, as it no longer
+// uses its name.
 func (s *Store) DeviceGetByName(ctx context.Context, name string, tenantID string) (*models.Device, error) {
 	device := new(models.Device)
-	if err := s.db.Collection("devices").FindOne(ctx, bson.M{"tenant_id": tenantID, "name": name}).Decode(&device); err != nil {
+	if err := s.db.Collection("devices").FindOne(ctx, bson.M{"tenant_id": tenantID, "name": name, "status": bson.M{"$ne": "decommissioned"}}).Decode(&device); err != nil {
 		return nil, fromMongoError(err)
 	}
 
 	return device, nil
 }
 
+func (s *Store) DeviceNameNextSequence(ctx context.Context, tenantID, pattern string, start int) (int, error) {
+	query := []bson.M{
+		{
+			"$match": bson.M{
+				"tenant_id": tenantID,
+				"name":      bson.M{"$regex": pattern},
+				"status":    bson.M{"$ne": "decommissioned"},
+			},
+		},
+		{
+			"$group": bson.M{
+				"_id": nil,
+				"seq": bson.M{
+					"$max": bson.M{
+						"$let": bson.M{
+							"vars": bson.M{"found": bson.M{"$regexFind": bson.M{"input": "$name", "regex": pattern}}},
+							"in":   bson.M{"$toLong": bson.M{"$arrayElemAt": bson.A{"$$found.captures", 0}}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	cursor, err := s.db.Collection("devices").Aggregate(ctx, query)
+	if err != nil {
+		return 0, fromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	highest := int64(start - 1)
+
+	var used struct {
+		Seq int64 `bson:"seq"`
+	}
+
+	if cursor.Next(ctx) {
+		if err := cursor.Decode(&used); err != nil {
+			return 0, fromMongoError(err)
+		}
+
+		if used.Seq > highest {
+			highest = used.Seq
+		}
+	}
+
+	// The sequence is moved past the highest number used and incremented on the same update, so it is atomic.
+	update := bson.A{
+		bson.M{
+			"$set": bson.M{
+				"seq": bson.M{"$add": bson.A{bson.M{"$max": bson.A{bson.M{"$ifNull": bson.A{"$seq", 0}}, highest}}, 1}},
+			},
+		},
+	}
+
+	next := func() (int64, error) {
+		var sequence struct {
+			Seq int64 `bson:"seq"`
+		}
+
+		err := s.db.Collection("device_name_sequences").FindOneAndUpdate(ctx,
+			bson.M{"tenant_id": tenantID, "pattern": pattern},
+			update,
+			options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+		).Decode(&sequence)
+
+		return sequence.Seq, err
+	}
+
+	seq, err := next()
+	if mongo.IsDuplicateKeyError(err) {
+		// Concurrent calls creating the same sequence conflict on its unique index, so the one that failed is retried
+		// on the sequence created by the other.
+		seq, err = next()
+	}
+
+	if err != nil {
+		return 0, fromMongoError(err)
+	}
+
+	return int(seq), nil
+}
+
 func (s *Store) DeviceGetByUID(ctx context.Context, uid models.UID, tenantID string) (*models.Device, error) {
 	var device *models.Device
 	if err := s.cache.Get(ctx, strings.Join([]string{"device", string(uid)}, "/"), &device); err != nil {
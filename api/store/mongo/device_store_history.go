@@ -0,0 +1,87 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Store) DeviceHistoryCreate(ctx context.Context, event *models.DeviceConnectionEvent) error {
+	if _, err := s.db.Collection("device_history").InsertOne(ctx, event); err != nil {
+		return fromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceHistoryList(ctx context.Context, uid models.UID, pagination paginator.Query, from, to time.Time) ([]models.DeviceConnectionEvent, int, error) {
+	query := []bson.M{
+		{
+			"$match": bson.M{
+				"uid": uid,
+				"timestamp": bson.M{
+					"$gte": from,
+					"$lte": to,
+				},
+			},
+		},
+		{
+			"$sort": bson.M{
+				"timestamp": -1,
+			},
+		},
+	}
+
+	// Only match for the respective tenant if requested
+	if tenant := gateway.TenantFromContext(ctx); tenant != nil {
+		query = append(query, bson.M{
+			"$match": bson.M{
+				"tenant_id": tenant.ID,
+			},
+		})
+	}
+
+	queryCount := query
+	queryCount = append(queryCount, bson.M{"$count": "count"})
+	count, err := aggregateCount(ctx, s.db.Collection("device_history"), queryCount)
+	if err != nil {
+		return nil, 0, fromMongoError(err)
+	}
+
+	query = append(query, queries.BuildPaginationQuery(pagination)...)
+
+	events := make([]models.DeviceConnectionEvent, 0)
+	cursor, err := s.db.Collection("device_history").Aggregate(ctx, query)
+	if err != nil {
+		return events, count, fromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		event := new(models.DeviceConnectionEvent)
+		if err := cursor.Decode(&event); err != nil {
+			return events, count, fromMongoError(err)
+		}
+
+		events = append(events, *event)
+	}
+
+	return events, count, nil
+}
+
+func (s *Store) DeviceHistoryGetLast(ctx context.Context, uid models.UID, before time.Time) (*models.DeviceConnectionEvent, error) {
+	event := new(models.DeviceConnectionEvent)
+
+	opts := options.FindOne().SetSort(bson.M{"timestamp": -1})
+	if err := s.db.Collection("device_history").FindOne(ctx, bson.M{"uid": uid, "timestamp": bson.M{"$lt": before}}, opts).Decode(&event); err != nil {
+		return nil, fromMongoError(err)
+	}
+
+	return event, nil
+}
@@ -0,0 +1,81 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/cache"
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestDeviceHistoryList(t *testing.T) {
+	ctx := context.TODO()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	events := []models.DeviceConnectionEvent{
+		{UID: "uid", TenantID: "tenant", Online: true, Timestamp: now.Add(-3 * time.Hour)},
+		{UID: "uid", TenantID: "tenant", Online: false, Timestamp: now.Add(-2 * time.Hour)},
+		{UID: "uid", TenantID: "tenant", Online: true, Timestamp: now.Add(-1 * time.Hour)},
+		{UID: "other", TenantID: "tenant", Online: true, Timestamp: now.Add(-1 * time.Hour)},
+	}
+
+	for i := range events {
+		err := mongostore.DeviceHistoryCreate(ctx, &events[i])
+		assert.NoError(t, err)
+	}
+
+	list, count, err := mongostore.DeviceHistoryList(ctx, models.UID("uid"), paginator.Query{Page: -1, PerPage: -1}, now.Add(-150*time.Minute), now)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, []models.DeviceConnectionEvent{events[2], events[1]}, list)
+
+	last, err := mongostore.DeviceHistoryGetLast(ctx, models.UID("uid"), now.Add(-150*time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, &events[0], last)
+
+	_, err = mongostore.DeviceHistoryGetLast(ctx, models.UID("uid"), now.Add(-4*time.Hour))
+	assert.Error(t, err)
+}
+
+func TestApplyDeviceHistoryRetention(t *testing.T) {
+	ctx := context.TODO()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	database := db.Client().Database("test")
+
+	_, err := database.Collection("device_history").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{"timestamp", 1}},
+		Options: options.Index().SetName("ttl").SetExpireAfterSeconds(2592000),
+	})
+	assert.NoError(t, err)
+
+	err = ApplyDeviceHistoryRetention(database, 0)
+	assert.Error(t, err)
+
+	err = ApplyDeviceHistoryRetention(database, 7)
+	assert.NoError(t, err)
+
+	cursor, err := database.Collection("device_history").Indexes().List(ctx)
+	assert.NoError(t, err)
+
+	var results []bson.M
+	err = cursor.All(ctx, &results)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "ttl", results[1]["name"])
+	assert.EqualValues(t, 604800, results[1]["expireAfterSeconds"])
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/shellhub-io/shellhub/api/cache"
 	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/pkg/api/paginator"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/stretchr/testify/assert"
@@ -187,6 +188,54 @@ func TestDeviceGetByName(t *testing.T) {
 	assert.NotEmpty(t, d)
 }
 
+func TestDeviceGetByNameDecommissioned(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	_, err := mongostore.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	err = mongostore.DeviceCreate(data.Context, data.Device, "hostname")
+	assert.NoError(t, err)
+
+	err = mongostore.DeviceDecommission(data.Context, models.UID(data.Device.UID))
+	assert.NoError(t, err)
+
+	_, err = mongostore.DeviceGetByName(data.Context, "hostname", "00000000-0000-4000-0000-000000000000")
+	assert.Equal(t, store.ErrNoDocuments, err)
+}
+
+func TestDeviceNameNextSequence(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	_, err := mongostore.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	err = mongostore.DeviceCreate(data.Context, data.Device, "ubuntu-7")
+	assert.NoError(t, err)
+
+	seq, err := mongostore.DeviceNameNextSequence(data.Context, "00000000-0000-4000-0000-000000000000", "^ubuntu-([0-9]+)$", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 8, seq)
+
+	seq, err = mongostore.DeviceNameNextSequence(data.Context, "00000000-0000-4000-0000-000000000000", "^ubuntu-([0-9]+)$", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 9, seq)
+
+	seq, err = mongostore.DeviceNameNextSequence(data.Context, "00000000-0000-4000-0000-000000000000", "^debian-([0-9]+)$", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, seq)
+}
+
 func TestDeviceGetByUID(t *testing.T) {
 	data := initData()
 
@@ -220,10 +269,93 @@ func TestDevicesList(t *testing.T) {
 	err = mongostore.DeviceCreate(data.Context, data.Device, "hostname")
 	assert.NoError(t, err)
 
-	devices, count, err := mongostore.DeviceList(data.Context, paginator.Query{Page: -1, PerPage: -1}, nil, "", "last_seen", "asc")
+	devices, count, err := mongostore.DeviceList(data.Context, paginator.Query{Page: -1, PerPage: -1}, nil, "", "last_seen", "asc", nil)
 	assert.NoError(t, err)
 	assert.Equal(t, 1, count)
 	assert.NotEmpty(t, devices)
+
+	devices, count, err = mongostore.DeviceList(data.Context, paginator.Query{Page: -1, PerPage: -1}, nil, "", "last_seen", "asc", []models.UID{})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+	assert.Empty(t, devices)
+}
+
+func TestDeviceDecommission(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	_, err := mongostore.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	err = mongostore.DeviceCreate(data.Context, data.Device, "hostname")
+	assert.NoError(t, err)
+
+	err = mongostore.DeviceDecommission(data.Context, models.UID(data.Device.UID))
+	assert.NoError(t, err)
+
+	_, count, err := mongostore.DeviceList(data.Context, paginator.Query{Page: -1, PerPage: -1}, nil, "", "last_seen", "asc", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	devices, count, err := mongostore.DeviceList(data.Context, paginator.Query{Page: -1, PerPage: -1}, nil, "decommissioned", "last_seen", "asc", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, "hostname", devices[0].Name)
+	assert.NotNil(t, devices[0].DecommissionedAt)
+
+	// Authenticating again registers the device back as pending.
+	err = mongostore.DeviceCreate(data.Context, data.Device, "hostname")
+	assert.NoError(t, err)
+
+	d, err := mongostore.DeviceGet(data.Context, models.UID(data.Device.UID))
+	assert.NoError(t, err)
+	assert.Equal(t, "pending", d.Status)
+	assert.Nil(t, d.DecommissionedAt)
+}
+
+func TestDeviceTransfer(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	_, err := mongostore.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	err = mongostore.DeviceCreate(data.Context, data.Device, "hostname")
+	assert.NoError(t, err)
+
+	transfer := &models.DeviceTransfer{
+		UID:       data.Device.UID,
+		NewUID:    "new_uid",
+		From:      data.Device.TenantID,
+		TenantID:  "tenant2",
+		CreatedAt: time.Now(),
+	}
+
+	err = mongostore.DeviceTransfer(data.Context, transfer, true)
+	assert.NoError(t, err)
+
+	d, err := mongostore.DeviceGetByUID(data.Context, "new_uid", "tenant2")
+	assert.NoError(t, err)
+	assert.Equal(t, "hostname", d.Name)
+
+	_, err = mongostore.DeviceGetByUID(data.Context, models.UID(data.Device.UID), data.Device.TenantID)
+	assert.Error(t, err)
+
+	got, err := mongostore.DeviceTransferGet(data.Context, models.UID(data.Device.UID))
+	assert.NoError(t, err)
+	assert.Equal(t, "new_uid", got.NewUID)
+	assert.Equal(t, "tenant2", got.TenantID)
+
+	err = mongostore.DeviceTransfer(data.Context, transfer, false)
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
 }
 
 func TestDeviceListByUsage(t *testing.T) {
@@ -305,7 +437,7 @@ func TestDeviceChooser(t *testing.T) {
 	err = mongostore.DeviceChooser(data.Context, data.Namespace.TenantID, []string{"uid1", "uid2", "uid5"})
 	assert.NoError(t, err)
 
-	devices, _, err = mongostore.DeviceList(data.Context, paginator.Query{Page: -1, PerPage: -1}, nil, "", "last_seen", "asc")
+	devices, _, err = mongostore.DeviceList(data.Context, paginator.Query{Page: -1, PerPage: -1}, nil, "", "last_seen", "asc", nil)
 	assert.NoError(t, err)
 
 	pending := make([]string, 0)
@@ -9,6 +9,7 @@ import (
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 func (s *Store) FirewallRuleList(ctx context.Context, pagination paginator.Query) ([]models.FirewallRule, int, error) {
@@ -94,7 +95,12 @@ func (s *Store) FirewallRuleUpdate(ctx context.Context, id string, rule models.F
 		return nil, fromMongoError(err)
 	}
 
-	if _, err := s.db.Collection("firewall_rules").UpdateOne(ctx, bson.M{"_id": objID}, bson.M{"$set": rule}); err != nil {
+	update, err := firewallRuleUpdate(rule)
+	if err != nil {
+		return nil, fromMongoError(err)
+	}
+
+	if _, err := s.db.Collection("firewall_rules").UpdateOne(ctx, bson.M{"_id": objID}, update); err != nil {
 		return nil, fromMongoError(err)
 	}
 
@@ -103,6 +109,37 @@ func (s *Store) FirewallRuleUpdate(ctx context.Context, id string, rule models.F
 	return r, fromMongoError(err)
 }
 
+// firewallRuleOptionalFields are the fields of a firewall rule omitted when they are empty.
+var firewallRuleOptionalFields = []string{"source_ip_regexp", "schedule", "session_types", "commands", "countries"}
+
+// firewallRuleUpdate builds the update of a firewall rule's fields, unsetting the optional fields left empty, as they
+// are omitted from the rule and a $set alone would keep their previous values.
+func firewallRuleUpdate(rule models.FirewallRuleUpdate) (bson.M, error) {
+	data, err := bson.Marshal(rule)
+	if err != nil {
+		return nil, err
+	}
+
+	set := bson.M{}
+	if err := bson.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+
+	unset := bson.M{}
+	for _, field := range firewallRuleOptionalFields {
+		if _, ok := set[field]; !ok {
+			unset[field] = ""
+		}
+	}
+
+	update := bson.M{"$set": set}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	return update, nil
+}
+
 func (s *Store) FirewallRuleDelete(ctx context.Context, id string) error {
 	objID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
@@ -115,3 +152,37 @@ func (s *Store) FirewallRuleDelete(ctx context.Context, id string) error {
 
 	return nil
 }
+
+func (s *Store) FirewallRuleListActive(ctx context.Context, tenant string) ([]models.FirewallRule, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "priority", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := s.db.Collection("firewall_rules").Find(ctx, bson.M{"tenant_id": tenant, "active": true}, opts)
+	if err != nil {
+		return nil, fromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	rules := make([]models.FirewallRule, 0)
+	if err := cursor.All(ctx, &rules); err != nil {
+		return nil, fromMongoError(err)
+	}
+
+	return rules, nil
+}
+
+func (s *Store) FirewallRuleListByTenant(ctx context.Context, tenant string) ([]models.FirewallRule, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "priority", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := s.db.Collection("firewall_rules").Find(ctx, bson.M{"tenant_id": tenant}, opts)
+	if err != nil {
+		return nil, fromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	rules := make([]models.FirewallRule, 0)
+	if err := cursor.All(ctx, &rules); err != nil {
+		return nil, fromMongoError(err)
+	}
+
+	return rules, nil
+}
@@ -72,6 +72,84 @@ func TestFirewallRuleUpdate(t *testing.T) {
 	assert.NotEmpty(t, rule)
 }
 
+func TestFirewallRuleUpdateClearsOptionalFields(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	fields := models.FirewallRuleFields{
+		Priority:       1,
+		Action:         "allow",
+		Active:         true,
+		SourceIP:       "*",
+		SourceIPRegexp: ".*",
+		Username:       ".*",
+		Filter:         models.FirewallFilter{Hostname: ".*"},
+		Schedule:       &models.FirewallSchedule{Weekdays: []string{"monday"}},
+		SessionTypes:   []string{models.SessionTypeExec},
+		Commands:       []string{"uptime"},
+		Countries:      []string{"BR"},
+	}
+
+	rule := models.FirewallRule{TenantID: data.Namespace.TenantID, FirewallRuleFields: fields}
+
+	err := mongostore.FirewallRuleCreate(data.Context, &rule)
+	assert.NoError(t, err)
+
+	rules, _, err := mongostore.FirewallRuleList(data.Context, paginator.Query{Page: -1, PerPage: -1})
+	assert.NoError(t, err)
+	assert.Len(t, rules, 1)
+
+	cases := []struct {
+		description string
+		clear       func(fields *models.FirewallRuleFields)
+		cleared     func(rule *models.FirewallRule) bool
+	}{
+		{
+			description: "clears the source IP regexp",
+			clear:       func(fields *models.FirewallRuleFields) { fields.SourceIPRegexp = "" },
+			cleared:     func(rule *models.FirewallRule) bool { return rule.SourceIPRegexp == "" },
+		},
+		{
+			description: "clears the schedule",
+			clear:       func(fields *models.FirewallRuleFields) { fields.Schedule = nil },
+			cleared:     func(rule *models.FirewallRule) bool { return rule.Schedule == nil },
+		},
+		{
+			description: "clears the session types",
+			clear:       func(fields *models.FirewallRuleFields) { fields.SessionTypes = nil },
+			cleared:     func(rule *models.FirewallRule) bool { return len(rule.SessionTypes) == 0 },
+		},
+		{
+			description: "clears the commands",
+			clear:       func(fields *models.FirewallRuleFields) { fields.Commands = nil },
+			cleared:     func(rule *models.FirewallRule) bool { return len(rule.Commands) == 0 },
+		},
+		{
+			description: "clears the countries",
+			clear:       func(fields *models.FirewallRuleFields) { fields.Countries = []string{} },
+			cleared:     func(rule *models.FirewallRule) bool { return len(rule.Countries) == 0 },
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			_, err := mongostore.FirewallRuleUpdate(data.Context, rules[0].ID, models.FirewallRuleUpdate{FirewallRuleFields: fields})
+			assert.NoError(t, err)
+
+			update := fields
+			tc.clear(&update)
+
+			updated, err := mongostore.FirewallRuleUpdate(data.Context, rules[0].ID, models.FirewallRuleUpdate{FirewallRuleFields: update})
+			assert.NoError(t, err)
+			assert.True(t, tc.cleared(updated))
+		})
+	}
+}
+
 func TestFirewallRuleDelete(t *testing.T) {
 	data := initData()
 
@@ -108,3 +186,63 @@ func TestFirewallRulesList(t *testing.T) {
 	assert.Equal(t, 1, count)
 	assert.NotEmpty(t, rules)
 }
+
+func TestFirewallRuleListActive(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	for _, priority := range []int{3, 1, 2} {
+		rule := data.FirewallRule
+		rule.TenantID = "tenant"
+		rule.Priority = priority
+		rule.Active = priority != 2
+
+		err := mongostore.FirewallRuleCreate(data.Context, &rule)
+		assert.NoError(t, err)
+	}
+
+	other := data.FirewallRule
+	other.TenantID = "other"
+	err := mongostore.FirewallRuleCreate(data.Context, &other)
+	assert.NoError(t, err)
+
+	rules, err := mongostore.FirewallRuleListActive(data.Context, "tenant")
+	assert.NoError(t, err)
+	assert.Len(t, rules, 2)
+	assert.Equal(t, 1, rules[0].Priority)
+	assert.Equal(t, 3, rules[1].Priority)
+}
+
+func TestFirewallRuleListByTenant(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	for _, priority := range []int{2, 1} {
+		rule := data.FirewallRule
+		rule.TenantID = "tenant"
+		rule.Priority = priority
+		rule.Active = priority != 2
+
+		err := mongostore.FirewallRuleCreate(data.Context, &rule)
+		assert.NoError(t, err)
+	}
+
+	other := data.FirewallRule
+	other.TenantID = "other"
+	err := mongostore.FirewallRuleCreate(data.Context, &other)
+	assert.NoError(t, err)
+
+	rules, err := mongostore.FirewallRuleListByTenant(data.Context, "tenant")
+	assert.NoError(t, err)
+	assert.Len(t, rules, 2)
+	assert.Equal(t, 1, rules[0].Priority)
+	assert.Equal(t, 2, rules[1].Priority)
+}
@@ -0,0 +1,141 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Store) InvitationList(ctx context.Context, pagination paginator.Query) ([]models.Invitation, int, error) {
+	query := []bson.M{
+		{
+			"$sort": bson.M{
+				"created_at": -1,
+			},
+		},
+	}
+
+	// Only match for the respective tenant if requested
+	if tenant := gateway.TenantFromContext(ctx); tenant != nil {
+		query = append(query, bson.M{
+			"$match": bson.M{
+				"tenant_id": tenant.ID,
+			},
+		})
+	}
+
+	queryCount := query
+	queryCount = append(queryCount, bson.M{"$count": "count"})
+	count, err := aggregateCount(ctx, s.db.Collection("invitations"), queryCount)
+	if err != nil {
+		return nil, 0, fromMongoError(err)
+	}
+
+	query = append(query, queries.BuildPaginationQuery(pagination)...)
+
+	invitations := make([]models.Invitation, 0)
+	cursor, err := s.db.Collection("invitations").Aggregate(ctx, query)
+	if err != nil {
+		return invitations, count, fromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		invitation := new(models.Invitation)
+		if err := cursor.Decode(&invitation); err != nil {
+			return invitations, count, fromMongoError(err)
+		}
+
+		invitations = append(invitations, *invitation)
+	}
+
+	return invitations, count, nil
+}
+
+func (s *Store) InvitationGet(ctx context.Context, tenant string, id string) (*models.Invitation, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fromMongoError(err)
+	}
+
+	invitation := new(models.Invitation)
+	if err := s.db.Collection("invitations").FindOne(ctx, bson.M{"_id": objID, "tenant_id": tenant}).Decode(&invitation); err != nil {
+		return nil, fromMongoError(err)
+	}
+
+	return invitation, nil
+}
+
+func (s *Store) InvitationGetByToken(ctx context.Context, token string) (*models.Invitation, error) {
+	invitation := new(models.Invitation)
+	if err := s.db.Collection("invitations").FindOne(ctx, bson.M{"token": token}).Decode(&invitation); err != nil {
+		return nil, fromMongoError(err)
+	}
+
+	return invitation, nil
+}
+
+func (s *Store) InvitationGetPending(ctx context.Context, tenant string, email string) (*models.Invitation, error) {
+	invitation := new(models.Invitation)
+	if err := s.db.Collection("invitations").FindOne(ctx, bson.M{"tenant_id": tenant, "email": email, "status": models.InvitationPending}).Decode(&invitation); err != nil {
+		return nil, fromMongoError(err)
+	}
+
+	return invitation, nil
+}
+
+func (s *Store) InvitationCreate(ctx context.Context, invitation *models.Invitation) error {
+	result, err := s.db.Collection("invitations").InsertOne(ctx, invitation)
+	if err != nil {
+		return fromMongoError(err)
+	}
+
+	if objID, ok := result.InsertedID.(primitive.ObjectID); ok {
+		invitation.ID = objID.Hex()
+	}
+
+	return nil
+}
+
+func (s *Store) InvitationAnswer(ctx context.Context, id string, status string) (*models.Invitation, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fromMongoError(err)
+	}
+
+	invitation := new(models.Invitation)
+	if err := s.db.Collection("invitations").FindOneAndUpdate(ctx,
+		bson.M{"_id": objID, "status": models.InvitationPending},
+		bson.M{"$set": bson.M{"status": status}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&invitation); err != nil {
+		return nil, fromMongoError(err)
+	}
+
+	return invitation, nil
+}
+
+func (s *Store) InvitationDelete(ctx context.Context, tenant string, id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fromMongoError(err)
+	}
+
+	result, err := s.db.Collection("invitations").DeleteOne(ctx, bson.M{"_id": objID, "tenant_id": tenant})
+	if err != nil {
+		return fromMongoError(err)
+	}
+
+	if result.DeletedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
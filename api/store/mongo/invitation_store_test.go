@@ -0,0 +1,68 @@
+package mongo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/cache"
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvitation(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	invitation := &models.Invitation{
+		TenantID:         "tenant",
+		Token:            "token",
+		Status:           models.InvitationPending,
+		InvitedBy:        "owner",
+		CreatedAt:        time.Now().UTC().Truncate(time.Millisecond),
+		ExpiresAt:        time.Now().UTC().Truncate(time.Millisecond).Add(time.Hour),
+		InvitationFields: models.InvitationFields{Email: "john@example.com", Role: "operator"},
+	}
+
+	err := mongostore.InvitationCreate(data.Context, invitation)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, invitation.ID)
+
+	invitations, count, err := mongostore.InvitationList(data.Context, paginator.Query{Page: -1, PerPage: -1})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, *invitation, invitations[0])
+
+	got, err := mongostore.InvitationGetByToken(data.Context, "token")
+	assert.NoError(t, err)
+	assert.Equal(t, invitation.ID, got.ID)
+
+	got, err = mongostore.InvitationGetPending(data.Context, "tenant", "john@example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, invitation.ID, got.ID)
+
+	answered, err := mongostore.InvitationAnswer(data.Context, invitation.ID, models.InvitationAccepted)
+	assert.NoError(t, err)
+	assert.Equal(t, models.InvitationAccepted, answered.Status)
+
+	_, err = mongostore.InvitationAnswer(data.Context, invitation.ID, models.InvitationDeclined)
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+
+	_, err = mongostore.InvitationGetPending(data.Context, "tenant", "john@example.com")
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+
+	_, err = mongostore.InvitationGet(data.Context, "other", invitation.ID)
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+
+	err = mongostore.InvitationDelete(data.Context, "tenant", invitation.ID)
+	assert.NoError(t, err)
+
+	err = mongostore.InvitationDelete(data.Context, "tenant", invitation.ID)
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+}
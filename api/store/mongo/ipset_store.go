@@ -0,0 +1,136 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (s *Store) IPSetList(ctx context.Context, pagination paginator.Query) ([]models.IPSet, int, error) {
+	query := []bson.M{
+		{
+			"$sort": bson.M{
+				"name": 1,
+			},
+		},
+	}
+
+	// Only match for the respective tenant if requested
+	if tenant := gateway.TenantFromContext(ctx); tenant != nil {
+		query = append(query, bson.M{
+			"$match": bson.M{
+				"tenant_id": tenant.ID,
+			},
+		})
+	}
+
+	queryCount := query
+	queryCount = append(queryCount, bson.M{"$count": "count"})
+	count, err := aggregateCount(ctx, s.db.Collection("ip_sets"), queryCount)
+	if err != nil {
+		return nil, 0, fromMongoError(err)
+	}
+
+	query = append(query, queries.BuildPaginationQuery(pagination)...)
+
+	sets := make([]models.IPSet, 0)
+	cursor, err := s.db.Collection("ip_sets").Aggregate(ctx, query)
+	if err != nil {
+		return sets, count, fromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		set := new(models.IPSet)
+		if err := cursor.Decode(&set); err != nil {
+			return sets, count, fromMongoError(err)
+		}
+
+		sets = append(sets, *set)
+	}
+
+	return sets, count, nil
+}
+
+func (s *Store) IPSetGet(ctx context.Context, tenant string, id string) (*models.IPSet, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fromMongoError(err)
+	}
+
+	set := new(models.IPSet)
+	if err := s.db.Collection("ip_sets").FindOne(ctx, bson.M{"_id": objID, "tenant_id": tenant}).Decode(&set); err != nil {
+		return nil, fromMongoError(err)
+	}
+
+	return set, nil
+}
+
+func (s *Store) IPSetGetByName(ctx context.Context, tenant string, name string) (*models.IPSet, error) {
+	set := new(models.IPSet)
+	if err := s.db.Collection("ip_sets").FindOne(ctx, bson.M{"tenant_id": tenant, "name": name}).Decode(&set); err != nil {
+		return nil, fromMongoError(err)
+	}
+
+	return set, nil
+}
+
+func (s *Store) IPSetCreate(ctx context.Context, set *models.IPSet) error {
+	result, err := s.db.Collection("ip_sets").InsertOne(ctx, set)
+	if err != nil {
+		return fromMongoError(err)
+	}
+
+	if objID, ok := result.InsertedID.(primitive.ObjectID); ok {
+		set.ID = objID.Hex()
+	}
+
+	return nil
+}
+
+func (s *Store) IPSetUpdate(ctx context.Context, tenant string, id string, set *models.IPSetUpdate) (*models.IPSet, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fromMongoError(err)
+	}
+
+	update := bson.M{"$set": set}
+	if set.Description == "" {
+		update["$unset"] = bson.M{"description": ""}
+	}
+
+	result, err := s.db.Collection("ip_sets").UpdateOne(ctx, bson.M{"_id": objID, "tenant_id": tenant}, update)
+	if err != nil {
+		return nil, fromMongoError(err)
+	}
+
+	if result.MatchedCount < 1 {
+		return nil, store.ErrNoDocuments
+	}
+
+	return s.IPSetGet(ctx, tenant, id)
+}
+
+func (s *Store) IPSetDelete(ctx context.Context, tenant string, id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fromMongoError(err)
+	}
+
+	result, err := s.db.Collection("ip_sets").DeleteOne(ctx, bson.M{"_id": objID, "tenant_id": tenant})
+	if err != nil {
+		return fromMongoError(err)
+	}
+
+	if result.DeletedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package mongo
+
+import (
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/cache"
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIPSet(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	set := &models.IPSet{
+		TenantID:    "tenant",
+		IPSetFields: models.IPSetFields{Name: "office", Description: "office", Prefixes: []string{"10.0.0.0/8"}},
+	}
+
+	err := mongostore.IPSetCreate(data.Context, set)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, set.ID)
+
+	sets, count, err := mongostore.IPSetList(data.Context, paginator.Query{Page: -1, PerPage: -1})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, "office", sets[0].Name)
+
+	got, err := mongostore.IPSetGetByName(data.Context, "tenant", "office")
+	assert.NoError(t, err)
+	assert.Equal(t, set.ID, got.ID)
+
+	updated, err := mongostore.IPSetUpdate(data.Context, "tenant", set.ID, &models.IPSetUpdate{
+		IPSetFields: models.IPSetFields{Name: "office", Prefixes: []string{"10.0.0.0/8", "2001:db8::/32"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.0/8", "2001:db8::/32"}, updated.Prefixes)
+	assert.Empty(t, updated.Description)
+
+	_, err = mongostore.IPSetGet(data.Context, "other", set.ID)
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+
+	err = mongostore.IPSetDelete(data.Context, "tenant", set.ID)
+	assert.NoError(t, err)
+
+	err = mongostore.IPSetDelete(data.Context, "tenant", set.ID)
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+}
@@ -66,6 +66,24 @@ func ApplyMigrations(db *mongo.Database) error {
 	return migration.Up(migrate.AllAvailable)
 }
 
+// ApplyDeviceHistoryRetention sets the time, in days, the device connection events are kept by the ttl index of the
+// device_history collection.
+func ApplyDeviceHistoryRetention(db *mongo.Database, retention int) error {
+	if retention <= 0 {
+		return errors.New("the device history retention must be greater than zero")
+	}
+
+	command := bson.D{
+		{"collMod", "device_history"},
+		{"index", bson.D{
+			{"name", "ttl"},
+			{"expireAfterSeconds", int64(retention) * 24 * 60 * 60},
+		}},
+	}
+
+	return db.RunCommand(context.TODO(), command).Err()
+}
+
 // This function is necessary due the lock bug on v0.7.2.
 func fixMigrations072(db *mongo.Database) error {
 	// Search for lock in migrations collection.
@@ -55,6 +55,17 @@ func GenerateMigrations() []migrate.Migration {
 		migration43,
 		migration44,
 		migration45,
+		migration46,
+		migration47,
+		migration48,
+		migration49,
+		migration50,
+		migration51,
+		migration52,
+		migration53,
+		migration54,
+		migration55,
+		migration56,
 	}
 }
 
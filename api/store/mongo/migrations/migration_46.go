@@ -0,0 +1,56 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration46 = migrate.Migration{
+	Version:     46,
+	Description: "Create the device_history collection with a ttl for connection events",
+	Up: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   46,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		indexModel := mongo.IndexModel{
+			Keys:    bson.D{{"timestamp", 1}},
+			Options: options.Index().SetName("ttl").SetExpireAfterSeconds(2592000),
+		}
+		if _, err := db.Collection("device_history").Indexes().CreateOne(context.TODO(), indexModel); err != nil {
+			return err
+		}
+
+		indexModel = mongo.IndexModel{
+			Keys:    bson.D{{"uid", 1}, {"timestamp", -1}},
+			Options: options.Index().SetName("uid_timestamp").SetUnique(false),
+		}
+		if _, err := db.Collection("device_history").Indexes().CreateOne(context.TODO(), indexModel); err != nil {
+			return err
+		}
+
+		return nil
+	},
+	Down: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   46,
+			"action":    "Down",
+		}).Info("Applying migration")
+
+		if _, err := db.Collection("device_history").Indexes().DropOne(context.TODO(), "ttl"); err != nil {
+			return err
+		}
+
+		_, err := db.Collection("device_history").Indexes().DropOne(context.TODO(), "uid_timestamp")
+
+		return err
+	},
+}
@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMigration46(t *testing.T) {
+	logrus.Info("Testing Migration 46")
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	migrations := GenerateMigrations()[:46]
+
+	migrates := migrate.NewMigrate(db.Client().Database("test"), migrations...)
+	err := migrates.Up(migrate.AllAvailable)
+	assert.NoError(t, err)
+
+	version, _, err := migrates.Version()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(46), version)
+
+	cursor, err := db.Client().Database("test").Collection("device_history").Indexes().List(context.TODO())
+	assert.NoError(t, err)
+
+	var results []bson.M
+	err = cursor.All(context.TODO(), &results)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "ttl", results[1]["name"])
+	assert.Equal(t, int32(2592000), results[1]["expireAfterSeconds"])
+	assert.Equal(t, "uid_timestamp", results[2]["name"])
+
+	err = migrates.Down(45)
+	assert.NoError(t, err)
+}
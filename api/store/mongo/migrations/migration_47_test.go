@@ -0,0 +1,37 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMigration47(t *testing.T) {
+	logrus.Info("Testing Migration 47")
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	migrations := GenerateMigrations()[:47]
+
+	migrates := migrate.NewMigrate(db.Client().Database("test"), migrations...)
+	err := migrates.Up(migrate.AllAvailable)
+	assert.NoError(t, err)
+
+	_, err = db.Client().Database("test").Collection("device_groups").InsertOne(context.TODO(), bson.M{"tenant_id": "tenant", "name": "group"})
+	assert.NoError(t, err)
+
+	_, err = db.Client().Database("test").Collection("device_groups").InsertOne(context.TODO(), bson.M{"tenant_id": "tenant", "name": "group"})
+	assert.Error(t, err)
+
+	_, err = db.Client().Database("test").Collection("device_groups").InsertOne(context.TODO(), bson.M{"tenant_id": "other", "name": "group"})
+	assert.NoError(t, err)
+
+	err = migrates.Down(46)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration48 = migrate.Migration{
+	Version:     48,
+	Description: "Create a unique index for the uid of device transfers",
+	Up: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   48,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		indexModel := mongo.IndexModel{
+			Keys:    bson.D{{"uid", 1}},
+			Options: options.Index().SetName("uid").SetUnique(true),
+		}
+		_, err := db.Collection("device_transfers").Indexes().CreateOne(context.TODO(), indexModel)
+
+		return err
+	},
+	Down: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   48,
+			"action":    "Down",
+		}).Info("Applying migration")
+
+		_, err := db.Collection("device_transfers").Indexes().DropOne(context.TODO(), "uid")
+
+		return err
+	},
+}
@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMigration48(t *testing.T) {
+	logrus.Info("Testing Migration 48")
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	migrations := GenerateMigrations()[:48]
+
+	migrates := migrate.NewMigrate(db.Client().Database("test"), migrations...)
+	err := migrates.Up(migrate.AllAvailable)
+	assert.NoError(t, err)
+
+	_, err = db.Client().Database("test").Collection("device_transfers").InsertOne(context.TODO(), bson.M{"uid": "uid", "tenant_id": "tenant"})
+	assert.NoError(t, err)
+
+	_, err = db.Client().Database("test").Collection("device_transfers").InsertOne(context.TODO(), bson.M{"uid": "uid", "tenant_id": "other"})
+	assert.Error(t, err)
+
+	err = migrates.Down(47)
+	assert.NoError(t, err)
+}
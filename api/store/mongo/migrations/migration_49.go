@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration49 = migrate.Migration{
+	Version:     49,
+	Description: "Create a unique index for the name of IP sets on each namespace",
+	Up: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   49,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		indexModel := mongo.IndexModel{
+			Keys:    bson.D{{"tenant_id", 1}, {"name", 1}},
+			Options: options.Index().SetName("tenant_id_name").SetUnique(true),
+		}
+		_, err := db.Collection("ip_sets").Indexes().CreateOne(context.TODO(), indexModel)
+
+		return err
+	},
+	Down: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   49,
+			"action":    "Down",
+		}).Info("Applying migration")
+
+		_, err := db.Collection("ip_sets").Indexes().DropOne(context.TODO(), "tenant_id_name")
+
+		return err
+	},
+}
@@ -0,0 +1,37 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMigration49(t *testing.T) {
+	logrus.Info("Testing Migration 49")
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	migrations := GenerateMigrations()[:49]
+
+	migrates := migrate.NewMigrate(db.Client().Database("test"), migrations...)
+	err := migrates.Up(migrate.AllAvailable)
+	assert.NoError(t, err)
+
+	_, err = db.Client().Database("test").Collection("ip_sets").InsertOne(context.TODO(), bson.M{"tenant_id": "tenant", "name": "office"})
+	assert.NoError(t, err)
+
+	_, err = db.Client().Database("test").Collection("ip_sets").InsertOne(context.TODO(), bson.M{"tenant_id": "tenant", "name": "office"})
+	assert.Error(t, err)
+
+	_, err = db.Client().Database("test").Collection("ip_sets").InsertOne(context.TODO(), bson.M{"tenant_id": "other", "name": "office"})
+	assert.NoError(t, err)
+
+	err = migrates.Down(48)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,95 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var migration50 = migrate.Migration{
+	Version:     50,
+	Description: "Move the source IP regular expression of firewall rules to source_ip_regexp",
+	Up: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   50,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		if _, err := db.Collection("firewall_rules").UpdateMany(context.TODO(),
+			bson.M{"source_ip": bson.M{"$in": bson.A{".*", "^.*$", ""}}},
+			bson.M{"$set": bson.M{"source_ip": "*"}},
+		); err != nil {
+			return err
+		}
+
+		cursor, err := db.Collection("firewall_rules").Find(context.TODO(), bson.M{"source_ip": bson.M{"$ne": "*"}})
+		if err != nil {
+			return err
+		}
+		defer cursor.Close(context.TODO())
+
+		for cursor.Next(context.TODO()) {
+			rule := new(struct {
+				ID       interface{} `bson:"_id"`
+				SourceIP string      `bson:"source_ip"`
+			})
+			if err := cursor.Decode(rule); err != nil {
+				return err
+			}
+
+			if _, err := db.Collection("firewall_rules").UpdateOne(context.TODO(),
+				bson.M{"_id": rule.ID},
+				bson.M{"$set": bson.M{"source_ip": "*", "source_ip_regexp": rule.SourceIP}},
+			); err != nil {
+				return err
+			}
+		}
+
+		return cursor.Err()
+	},
+	Down: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   50,
+			"action":    "Down",
+		}).Info("Applying migration")
+
+		cursor, err := db.Collection("firewall_rules").Find(context.TODO(), bson.M{"source_ip_regexp": bson.M{"$exists": true}})
+		if err != nil {
+			return err
+		}
+		defer cursor.Close(context.TODO())
+
+		for cursor.Next(context.TODO()) {
+			rule := new(struct {
+				ID             interface{} `bson:"_id"`
+				SourceIPRegexp string      `bson:"source_ip_regexp"`
+			})
+			if err := cursor.Decode(rule); err != nil {
+				return err
+			}
+
+			if _, err := db.Collection("firewall_rules").UpdateOne(context.TODO(),
+				bson.M{"_id": rule.ID},
+				bson.M{"$set": bson.M{"source_ip": rule.SourceIPRegexp}, "$unset": bson.M{"source_ip_regexp": ""}},
+			); err != nil {
+				return err
+			}
+		}
+
+		if err := cursor.Err(); err != nil {
+			return err
+		}
+
+		_, err = db.Collection("firewall_rules").UpdateMany(context.TODO(),
+			bson.M{"source_ip": "*"},
+			bson.M{"$set": bson.M{"source_ip": ".*"}},
+		)
+
+		return err
+	},
+}
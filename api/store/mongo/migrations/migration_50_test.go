@@ -0,0 +1,54 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMigration50(t *testing.T) {
+	logrus.Info("Testing Migration 50")
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	type rule struct {
+		ID             string `bson:"_id"`
+		SourceIP       string `bson:"source_ip"`
+		SourceIPRegexp string `bson:"source_ip_regexp,omitempty"`
+	}
+
+	_, err := db.Client().Database("test").Collection("firewall_rules").InsertMany(context.TODO(), []interface{}{
+		rule{ID: "any", SourceIP: ".*"},
+		rule{ID: "regexp", SourceIP: "^10\\.0\\."},
+	})
+	assert.NoError(t, err)
+
+	migrations := GenerateMigrations()[:50]
+
+	migrates := migrate.NewMigrate(db.Client().Database("test"), migrations...)
+	err = migrates.Up(migrate.AllAvailable)
+	assert.NoError(t, err)
+
+	get := func(id string) *rule {
+		r := new(rule)
+		err := db.Client().Database("test").Collection("firewall_rules").FindOne(context.TODO(), bson.M{"_id": id}).Decode(r)
+		assert.NoError(t, err)
+
+		return r
+	}
+
+	assert.Equal(t, &rule{ID: "any", SourceIP: "*"}, get("any"))
+	assert.Equal(t, &rule{ID: "regexp", SourceIP: "*", SourceIPRegexp: "^10\\.0\\."}, get("regexp"))
+
+	err = migrates.Down(49)
+	assert.NoError(t, err)
+
+	assert.Equal(t, &rule{ID: "any", SourceIP: ".*"}, get("any"))
+	assert.Equal(t, &rule{ID: "regexp", SourceIP: "^10\\.0\\."}, get("regexp"))
+}
@@ -0,0 +1,69 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// webhookDeliveryRetention is how long, in seconds, the deliveries of the webhooks are kept.
+const webhookDeliveryRetention = 30 * 24 * 60 * 60
+
+var migration51 = migrate.Migration{
+	Version:     51,
+	Description: "Create the indexes of the webhooks and of their deliveries",
+	Up: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   51,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		indexModel := mongo.IndexModel{
+			Keys:    bson.D{{"tenant_id", 1}, {"events", 1}},
+			Options: options.Index().SetName("tenant_id_events"),
+		}
+		if _, err := db.Collection("webhooks").Indexes().CreateOne(context.TODO(), indexModel); err != nil {
+			return err
+		}
+
+		indexModel = mongo.IndexModel{
+			Keys:    bson.D{{"webhook_id", 1}, {"created_at", -1}},
+			Options: options.Index().SetName("webhook_id_created_at"),
+		}
+		if _, err := db.Collection("webhook_deliveries").Indexes().CreateOne(context.TODO(), indexModel); err != nil {
+			return err
+		}
+
+		indexModel = mongo.IndexModel{
+			Keys:    bson.D{{"created_at", 1}},
+			Options: options.Index().SetName("ttl").SetExpireAfterSeconds(webhookDeliveryRetention),
+		}
+		_, err := db.Collection("webhook_deliveries").Indexes().CreateOne(context.TODO(), indexModel)
+
+		return err
+	},
+	Down: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   51,
+			"action":    "Down",
+		}).Info("Applying migration")
+
+		if _, err := db.Collection("webhooks").Indexes().DropOne(context.TODO(), "tenant_id_events"); err != nil {
+			return err
+		}
+
+		if _, err := db.Collection("webhook_deliveries").Indexes().DropOne(context.TODO(), "webhook_id_created_at"); err != nil {
+			return err
+		}
+
+		_, err := db.Collection("webhook_deliveries").Indexes().DropOne(context.TODO(), "ttl")
+
+		return err
+	},
+}
@@ -0,0 +1,41 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	migrate "github.com/xakep666/mongo-migrate"
+)
+
+func TestMigration51(t *testing.T) {
+	logrus.Info("Testing Migration 51")
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	migrations := GenerateMigrations()[:51]
+
+	migrates := migrate.NewMigrate(db.Client().Database("test"), migrations...)
+	err := migrates.Up(migrate.AllAvailable)
+	assert.NoError(t, err)
+
+	indexes, err := db.Client().Database("test").Collection("webhook_deliveries").Indexes().List(context.TODO())
+	assert.NoError(t, err)
+
+	var names []string
+	for indexes.Next(context.TODO()) {
+		var index struct {
+			Name string `bson:"name"`
+		}
+		assert.NoError(t, indexes.Decode(&index))
+
+		names = append(names, index.Name)
+	}
+	assert.ElementsMatch(t, []string{"_id_", "webhook_id_created_at", "ttl"}, names)
+
+	err = migrates.Down(50)
+	assert.NoError(t, err)
+}
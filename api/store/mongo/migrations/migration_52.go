@@ -0,0 +1,57 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// sessionApprovalRetention is how long, in seconds, the session approvals are kept after they expire.
+const sessionApprovalRetention = 7 * 24 * 60 * 60
+
+var migration52 = migrate.Migration{
+	Version:     52,
+	Description: "Create the indexes of the session approvals",
+	Up: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   52,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		indexModel := mongo.IndexModel{
+			Keys:    bson.D{{"uid", 1}},
+			Options: options.Index().SetName("uid").SetUnique(true),
+		}
+		if _, err := db.Collection("session_approvals").Indexes().CreateOne(context.TODO(), indexModel); err != nil {
+			return err
+		}
+
+		indexModel = mongo.IndexModel{
+			Keys:    bson.D{{"expires_at", 1}},
+			Options: options.Index().SetName("ttl").SetExpireAfterSeconds(sessionApprovalRetention),
+		}
+		_, err := db.Collection("session_approvals").Indexes().CreateOne(context.TODO(), indexModel)
+
+		return err
+	},
+	Down: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   52,
+			"action":    "Down",
+		}).Info("Applying migration")
+
+		if _, err := db.Collection("session_approvals").Indexes().DropOne(context.TODO(), "uid"); err != nil {
+			return err
+		}
+
+		_, err := db.Collection("session_approvals").Indexes().DropOne(context.TODO(), "ttl")
+
+		return err
+	},
+}
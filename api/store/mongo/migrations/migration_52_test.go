@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMigration52(t *testing.T) {
+	logrus.Info("Testing Migration 52")
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	migrations := GenerateMigrations()[:52]
+
+	migrates := migrate.NewMigrate(db.Client().Database("test"), migrations...)
+	err := migrates.Up(migrate.AllAvailable)
+	assert.NoError(t, err)
+
+	_, err = db.Client().Database("test").Collection("session_approvals").InsertOne(context.TODO(), bson.M{"uid": "uid"})
+	assert.NoError(t, err)
+
+	_, err = db.Client().Database("test").Collection("session_approvals").InsertOne(context.TODO(), bson.M{"uid": "uid"})
+	assert.Error(t, err)
+
+	err = migrates.Down(51)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,57 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// invitationRetention is how long, in seconds, the invitations are kept after they expire.
+const invitationRetention = 30 * 24 * 60 * 60
+
+var migration54 = migrate.Migration{
+	Version:     54,
+	Description: "Create the indexes of the invitations",
+	Up: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   54,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		indexModel := mongo.IndexModel{
+			Keys:    bson.D{{"token", 1}},
+			Options: options.Index().SetName("token").SetUnique(true),
+		}
+		if _, err := db.Collection("invitations").Indexes().CreateOne(context.TODO(), indexModel); err != nil {
+			return err
+		}
+
+		indexModel = mongo.IndexModel{
+			Keys:    bson.D{{"expires_at", 1}},
+			Options: options.Index().SetName("ttl").SetExpireAfterSeconds(invitationRetention),
+		}
+		_, err := db.Collection("invitations").Indexes().CreateOne(context.TODO(), indexModel)
+
+		return err
+	},
+	Down: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   54,
+			"action":    "Down",
+		}).Info("Applying migration")
+
+		if _, err := db.Collection("invitations").Indexes().DropOne(context.TODO(), "token"); err != nil {
+			return err
+		}
+
+		_, err := db.Collection("invitations").Indexes().DropOne(context.TODO(), "ttl")
+
+		return err
+	},
+}
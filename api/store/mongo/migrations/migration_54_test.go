@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMigration54(t *testing.T) {
+	logrus.Info("Testing Migration 54")
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	migrations := GenerateMigrations()[:54]
+
+	migrates := migrate.NewMigrate(db.Client().Database("test"), migrations...)
+	err := migrates.Up(migrate.AllAvailable)
+	assert.NoError(t, err)
+
+	_, err = db.Client().Database("test").Collection("invitations").InsertOne(context.TODO(), bson.M{"token": "token"})
+	assert.NoError(t, err)
+
+	_, err = db.Client().Database("test").Collection("invitations").InsertOne(context.TODO(), bson.M{"token": "token"})
+	assert.Error(t, err)
+
+	err = migrates.Down(53)
+	assert.NoError(t, err)
+}
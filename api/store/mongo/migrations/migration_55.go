@@ -0,0 +1,55 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration55 = migrate.Migration{
+	Version:     55,
+	Description: "Create the indexes of the audit logs",
+	Up: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   55,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		indexModel := mongo.IndexModel{
+			Keys:    bson.D{{"tenant_id", 1}, {"created_at", -1}},
+			Options: options.Index().SetName("tenant_id_created_at"),
+		}
+		if _, err := db.Collection("audit_logs").Indexes().CreateOne(context.TODO(), indexModel); err != nil {
+			return err
+		}
+
+		// The retention of the audit logs deletes them by their creation date.
+		indexModel = mongo.IndexModel{
+			Keys:    bson.D{{"created_at", 1}},
+			Options: options.Index().SetName("created_at"),
+		}
+		_, err := db.Collection("audit_logs").Indexes().CreateOne(context.TODO(), indexModel)
+
+		return err
+	},
+	Down: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   55,
+			"action":    "Down",
+		}).Info("Applying migration")
+
+		if _, err := db.Collection("audit_logs").Indexes().DropOne(context.TODO(), "tenant_id_created_at"); err != nil {
+			return err
+		}
+
+		_, err := db.Collection("audit_logs").Indexes().DropOne(context.TODO(), "created_at")
+
+		return err
+	},
+}
@@ -0,0 +1,41 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMigration55(t *testing.T) {
+	logrus.Info("Testing Migration 55")
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	migrations := GenerateMigrations()[:55]
+
+	migrates := migrate.NewMigrate(db.Client().Database("test"), migrations...)
+	err := migrates.Up(migrate.AllAvailable)
+	assert.NoError(t, err)
+
+	cursor, err := db.Client().Database("test").Collection("audit_logs").Indexes().List(context.TODO())
+	assert.NoError(t, err)
+
+	var indexes []bson.M
+	assert.NoError(t, cursor.All(context.TODO(), &indexes))
+
+	names := make([]string, 0, len(indexes))
+	for _, index := range indexes {
+		names = append(names, index["name"].(string))
+	}
+
+	assert.ElementsMatch(t, []string{"_id_", "tenant_id_created_at", "created_at"}, names)
+
+	err = migrates.Down(54)
+	assert.NoError(t, err)
+}
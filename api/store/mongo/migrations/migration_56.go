@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration56 = migrate.Migration{
+	Version:     56,
+	Description: "Create the unique index of the device name sequences",
+	Up: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   56,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		indexModel := mongo.IndexModel{
+			Keys:    bson.D{{"tenant_id", 1}, {"pattern", 1}},
+			Options: options.Index().SetName("tenant_id_pattern").SetUnique(true),
+		}
+		_, err := db.Collection("device_name_sequences").Indexes().CreateOne(context.TODO(), indexModel)
+
+		return err
+	},
+	Down: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   56,
+			"action":    "Down",
+		}).Info("Applying migration")
+
+		_, err := db.Collection("device_name_sequences").Indexes().DropOne(context.TODO(), "tenant_id_pattern")
+
+		return err
+	},
+}
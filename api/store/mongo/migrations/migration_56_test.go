@@ -0,0 +1,37 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMigration56(t *testing.T) {
+	logrus.Info("Testing Migration 56")
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	migrations := GenerateMigrations()[:56]
+
+	migrates := migrate.NewMigrate(db.Client().Database("test"), migrations...)
+	err := migrates.Up(migrate.AllAvailable)
+	assert.NoError(t, err)
+
+	cursor, err := db.Client().Database("test").Collection("device_name_sequences").Indexes().List(context.TODO())
+	assert.NoError(t, err)
+
+	var indexes []bson.M
+	assert.NoError(t, cursor.All(context.TODO(), &indexes))
+
+	assert.Equal(t, "tenant_id_pattern", indexes[1]["name"])
+	assert.Equal(t, true, indexes[1]["unique"])
+
+	err = migrates.Down(55)
+	assert.NoError(t, err)
+}
@@ -6,11 +6,14 @@ import (
 	"time"
 
 	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
 	"github.com/shellhub-io/shellhub/pkg/api/paginator"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 func (s *Store) NamespaceList(ctx context.Context, pagination paginator.Query, filters []models.Filter, export bool) ([]models.Namespace, int, error) {
@@ -178,7 +181,7 @@ func (s *Store) NamespaceDelete(ctx context.Context, tenantID string) error {
 		logrus.Error(err)
 	}
 
-	collections := []string{"devices", "sessions", "connected_devices", "firewall_rules", "public_keys", "recorded_sessions"}
+	collections := []string{"devices", "sessions", "connected_devices", "firewall_rules", "public_keys", "recorded_sessions", "roles", "invitations"}
 	for _, collection := range collections {
 		if _, err := s.db.Collection(collection).DeleteMany(ctx, bson.M{"tenant_id": tenantID}); err != nil {
 			return fromMongoError(err)
@@ -259,6 +262,62 @@ func (s *Store) NamespaceEditMember(ctx context.Context, tenantID string, member
 	return nil
 }
 
+func (s *Store) NamespaceSetMemberScope(ctx context.Context, tenantID string, memberID string, scope *models.MemberScope) error {
+	update := bson.M{"$set": bson.M{"members.$.scope": scope}}
+	if scope == nil {
+		update = bson.M{"$unset": bson.M{"members.$.scope": ""}}
+	}
+
+	result, err := s.db.Collection("namespaces").UpdateOne(ctx, bson.M{"tenant_id": tenantID, "members.id": memberID}, update)
+	if err != nil {
+		return fromMongoError(err)
+	}
+
+	if result.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	if err := s.cache.Delete(ctx, strings.Join([]string{"namespace", tenantID}, "/")); err != nil {
+		logrus.Error(err)
+	}
+
+	return nil
+}
+
+func (s *Store) NamespaceTransferOwnership(ctx context.Context, tenantID string, transfer *models.OwnershipTransfer) error {
+	// The owner and both members are changed in a single update, so the namespace never has zero or two owners. The
+	// new owner's scope is removed as the owner is never restricted.
+	filter := bson.M{"tenant_id": tenantID, "owner": transfer.From, "members.id": transfer.To}
+	update := bson.M{
+		"$set": bson.M{
+			"owner":                    transfer.To,
+			"members.$[previous].role": guard.RoleAdministrator,
+			"members.$[next].role":     guard.RoleOwner,
+		},
+		"$unset": bson.M{"members.$[next].scope": ""},
+		"$push":  bson.M{"transfers": transfer},
+	}
+
+	opts := options.Update().SetArrayFilters(options.ArrayFilters{
+		Filters: []interface{}{bson.M{"previous.id": transfer.From}, bson.M{"next.id": transfer.To}},
+	})
+
+	result, err := s.db.Collection("namespaces").UpdateOne(ctx, filter, update, opts)
+	if err != nil {
+		return fromMongoError(err)
+	}
+
+	if result.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	if err := s.cache.Delete(ctx, strings.Join([]string{"namespace", tenantID}, "/")); err != nil {
+		logrus.Error(err)
+	}
+
+	return nil
+}
+
 func (s *Store) NamespaceGetFirst(ctx context.Context, id string) (*models.Namespace, error) {
 	ns := new(models.Namespace)
 	if err := s.db.Collection("namespaces").FindOne(ctx, bson.M{"members": bson.M{"$elemMatch": bson.M{"id": id}}}).Decode(&ns); err != nil {
@@ -268,6 +327,24 @@ func (s *Store) NamespaceGetFirst(ctx context.Context, id string) (*models.Names
 	return ns, nil
 }
 
+func (s *Store) NamespaceListByMember(ctx context.Context, id string) ([]models.Namespace, error) {
+	cursor, err := s.db.Collection("namespaces").Find(ctx,
+		bson.M{"members": bson.M{"$elemMatch": bson.M{"id": id}}},
+		options.Find().SetSort(bson.M{"name": 1}),
+	)
+	if err != nil {
+		return nil, fromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	namespaces := make([]models.Namespace, 0)
+	if err := cursor.All(ctx, &namespaces); err != nil {
+		return nil, fromMongoError(err)
+	}
+
+	return namespaces, nil
+}
+
 func (s *Store) NamespaceSetSessionRecord(ctx context.Context, sessionRecord bool, tenantID string) error {
 	if _, err := s.db.Collection("namespaces").UpdateOne(ctx, bson.M{"tenant_id": tenantID}, bson.M{"$set": bson.M{"settings.session_record": sessionRecord}}); err != nil {
 		return fromMongoError(err)
@@ -276,6 +353,72 @@ func (s *Store) NamespaceSetSessionRecord(ctx context.Context, sessionRecord boo
 	return nil
 }
 
+func (s *Store) NamespaceSetDeviceNaming(ctx context.Context, tenantID string, naming *models.DeviceNaming) error {
+	update := bson.M{"$set": bson.M{"settings.device_naming": naming}}
+	if naming == nil {
+		update = bson.M{"$unset": bson.M{"settings.device_naming": ""}}
+	}
+
+	result, err := s.db.Collection("namespaces").UpdateOne(ctx, bson.M{"tenant_id": tenantID}, update)
+	if err != nil {
+		return fromMongoError(err)
+	}
+
+	if result.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	if err := s.cache.Delete(ctx, strings.Join([]string{"namespace", tenantID}, "/")); err != nil {
+		logrus.Error(err)
+	}
+
+	return nil
+}
+
+func (s *Store) NamespaceSetGeoAccess(ctx context.Context, tenantID string, geo *models.GeoAccess) error {
+	update := bson.M{"$set": bson.M{"settings.geo_access": geo}}
+	if geo == nil {
+		update = bson.M{"$unset": bson.M{"settings.geo_access": ""}}
+	}
+
+	result, err := s.db.Collection("namespaces").UpdateOne(ctx, bson.M{"tenant_id": tenantID}, update)
+	if err != nil {
+		return fromMongoError(err)
+	}
+
+	if result.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	if err := s.cache.Delete(ctx, strings.Join([]string{"namespace", tenantID}, "/")); err != nil {
+		logrus.Error(err)
+	}
+
+	return nil
+}
+
+func (s *Store) NamespaceSetSessionTypes(ctx context.Context, tenantID string, types *models.SessionTypes) error {
+	update := bson.M{"$set": bson.M{"settings.session_types": types}}
+	if types == nil {
+		update = bson.M{"$unset": bson.M{"settings.session_types": ""}}
+	}
+
+	result, err := s.db.Collection("namespaces").UpdateOne(ctx, bson.M{"tenant_id": tenantID}, update)
+	if err != nil {
+		return fromMongoError(err)
+	}
+
+	if result.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	if err := s.cache.Delete(ctx, strings.Join([]string{"namespace", tenantID}, "/")); err != nil {
+		logrus.Error(err)
+	}
+
+	return nil
+}
+
 func (s *Store) NamespaceGetSessionRecord(ctx context.Context, tenantID string) (bool, error) {
 	var settings struct {
 		Settings *models.NamespaceSettings `json:"settings" bson:"settings"`
@@ -6,6 +6,7 @@ import (
 	"github.com/shellhub-io/shellhub/api/cache"
 	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
 	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/pkg/api/paginator"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/stretchr/testify/assert"
@@ -54,6 +55,201 @@ func TestNamespaceUpdateDataUserSecurity(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestNamespaceSetDeviceNaming(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	_, err := mongostore.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	naming := &models.DeviceNaming{Template: "{os}-{seq}", Collision: models.DeviceNamingCollisionSuffix}
+
+	err = mongostore.NamespaceSetDeviceNaming(data.Context, data.Namespace.TenantID, naming)
+	assert.NoError(t, err)
+
+	ns, err := mongostore.NamespaceGet(data.Context, data.Namespace.TenantID)
+	assert.NoError(t, err)
+	assert.Equal(t, naming, ns.Settings.DeviceNaming)
+
+	err = mongostore.NamespaceSetDeviceNaming(data.Context, data.Namespace.TenantID, nil)
+	assert.NoError(t, err)
+
+	ns, err = mongostore.NamespaceGet(data.Context, data.Namespace.TenantID)
+	assert.NoError(t, err)
+	assert.Nil(t, ns.Settings.DeviceNaming)
+
+	err = mongostore.NamespaceSetDeviceNaming(data.Context, "unknown", naming)
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+}
+
+func TestNamespaceSetGeoAccess(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	_, err := mongostore.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	geo := &models.GeoAccess{Mode: models.GeoAccessAllow, Countries: []string{"BR", "PT"}}
+
+	err = mongostore.NamespaceSetGeoAccess(data.Context, data.Namespace.TenantID, geo)
+	assert.NoError(t, err)
+
+	ns, err := mongostore.NamespaceGet(data.Context, data.Namespace.TenantID)
+	assert.NoError(t, err)
+	assert.Equal(t, geo, ns.Settings.GeoAccess)
+
+	err = mongostore.NamespaceSetGeoAccess(data.Context, data.Namespace.TenantID, nil)
+	assert.NoError(t, err)
+
+	ns, err = mongostore.NamespaceGet(data.Context, data.Namespace.TenantID)
+	assert.NoError(t, err)
+	assert.Nil(t, ns.Settings.GeoAccess)
+
+	err = mongostore.NamespaceSetGeoAccess(data.Context, "unknown", geo)
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+}
+
+func TestNamespaceSetMemberScope(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	_, err := mongostore.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	scope := &models.MemberScope{Tags: []string{"project"}}
+
+	err = mongostore.NamespaceSetMemberScope(data.Context, data.Namespace.TenantID, "owner", scope)
+	assert.NoError(t, err)
+
+	ns, err := mongostore.NamespaceGet(data.Context, data.Namespace.TenantID)
+	assert.NoError(t, err)
+	assert.Equal(t, scope, ns.Members[0].Scope)
+
+	err = mongostore.NamespaceSetMemberScope(data.Context, data.Namespace.TenantID, "owner", nil)
+	assert.NoError(t, err)
+
+	ns, err = mongostore.NamespaceGet(data.Context, data.Namespace.TenantID)
+	assert.NoError(t, err)
+	assert.Nil(t, ns.Members[0].Scope)
+
+	err = mongostore.NamespaceSetMemberScope(data.Context, data.Namespace.TenantID, "unknown", scope)
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+}
+
+func TestNamespaceTransferOwnership(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	data.Namespace.Members = append(data.Namespace.Members, models.Member{
+		ID:    "member",
+		Role:  guard.RoleObserver,
+		Scope: &models.MemberScope{Tags: []string{"project"}},
+	})
+
+	_, err := mongostore.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	transfer := &models.OwnershipTransfer{From: "owner", To: "member"}
+
+	err = mongostore.NamespaceTransferOwnership(data.Context, data.Namespace.TenantID, transfer)
+	assert.NoError(t, err)
+
+	ns, err := mongostore.NamespaceGet(data.Context, data.Namespace.TenantID)
+	assert.NoError(t, err)
+	assert.Equal(t, "member", ns.Owner)
+	assert.Equal(t, []models.Member{
+		{ID: "owner", Role: guard.RoleAdministrator},
+		{ID: "member", Role: guard.RoleOwner},
+	}, ns.Members)
+	assert.Len(t, ns.Transfers, 1)
+	assert.Equal(t, "owner", ns.Transfers[0].From)
+	assert.Equal(t, "member", ns.Transfers[0].To)
+
+	// The previous owner cannot transfer the ownership again.
+	err = mongostore.NamespaceTransferOwnership(data.Context, data.Namespace.TenantID, transfer)
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+
+	err = mongostore.NamespaceTransferOwnership(data.Context, data.Namespace.TenantID,
+		&models.OwnershipTransfer{From: "member", To: "unknown"})
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+}
+
+func TestNamespaceListByMember(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	for _, ns := range []models.Namespace{
+		{Name: "zeta", TenantID: "tenant-1", Owner: "owner", Members: []models.Member{{ID: "owner", Role: guard.RoleOwner}}},
+		{Name: "alpha", TenantID: "tenant-2", Owner: "other", Members: []models.Member{{ID: "other", Role: guard.RoleOwner}, {ID: "owner", Role: guard.RoleObserver}}},
+		{Name: "beta", TenantID: "tenant-3", Owner: "other", Members: []models.Member{{ID: "other", Role: guard.RoleOwner}}},
+	} {
+		ns := ns
+		_, err := mongostore.NamespaceCreate(data.Context, &ns)
+		assert.NoError(t, err)
+	}
+
+	namespaces, err := mongostore.NamespaceListByMember(data.Context, "owner")
+	assert.NoError(t, err)
+	assert.Len(t, namespaces, 2)
+	assert.Equal(t, "tenant-2", namespaces[0].TenantID)
+	assert.Equal(t, "tenant-1", namespaces[1].TenantID)
+
+	namespaces, err = mongostore.NamespaceListByMember(data.Context, "unknown")
+	assert.NoError(t, err)
+	assert.Empty(t, namespaces)
+}
+
+func TestNamespaceSetSessionTypes(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	_, err := mongostore.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	types := &models.SessionTypes{Term: true, Exec: true, Web: true}
+
+	err = mongostore.NamespaceSetSessionTypes(data.Context, data.Namespace.TenantID, types)
+	assert.NoError(t, err)
+
+	ns, err := mongostore.NamespaceGet(data.Context, data.Namespace.TenantID)
+	assert.NoError(t, err)
+	assert.Equal(t, types, ns.Settings.SessionTypes)
+
+	err = mongostore.NamespaceSetSessionTypes(data.Context, data.Namespace.TenantID, nil)
+	assert.NoError(t, err)
+
+	ns, err = mongostore.NamespaceGet(data.Context, data.Namespace.TenantID)
+	assert.NoError(t, err)
+	assert.Nil(t, ns.Settings.SessionTypes)
+
+	err = mongostore.NamespaceSetSessionTypes(data.Context, "unknown", types)
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+}
+
 func TestNamespaceCreate(t *testing.T) {
 	data := initData()
 
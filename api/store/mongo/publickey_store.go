@@ -8,6 +8,7 @@ import (
 	"github.com/shellhub-io/shellhub/pkg/api/paginator"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 func (s *Store) PublicKeyGet(ctx context.Context, fingerprint string, tenantID string) (*models.PublicKey, error) {
@@ -89,3 +90,20 @@ func (s *Store) PublicKeyDelete(ctx context.Context, fingerprint string, tenantI
 
 	return err
 }
+
+func (s *Store) PublicKeyListByTenant(ctx context.Context, tenantID string) ([]models.PublicKey, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+
+	cursor, err := s.db.Collection("public_keys").Find(ctx, bson.M{"tenant_id": tenantID}, opts)
+	if err != nil {
+		return nil, fromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	keys := make([]models.PublicKey, 0)
+	if err := cursor.All(ctx, &keys); err != nil {
+		return nil, fromMongoError(err)
+	}
+
+	return keys, nil
+}
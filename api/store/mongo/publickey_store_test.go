@@ -43,6 +43,28 @@ func TestPublicKeysList(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestPublicKeyListByTenant(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	err := mongostore.PublicKeyCreate(data.Context, &data.PublicKey)
+	assert.NoError(t, err)
+
+	other := data.PublicKey
+	other.Fingerprint = "other"
+	other.TenantID = "other"
+	err = mongostore.PublicKeyCreate(data.Context, &other)
+	assert.NoError(t, err)
+
+	keys, err := mongostore.PublicKeyListByTenant(data.Context, data.PublicKey.TenantID)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.PublicKey{data.PublicKey}, keys)
+}
+
 func TestPublicKeyGet(t *testing.T) {
 	data := initData()
 
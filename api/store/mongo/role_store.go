@@ -0,0 +1,136 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (s *Store) RoleList(ctx context.Context, pagination paginator.Query) ([]models.Role, int, error) {
+	query := []bson.M{
+		{
+			"$sort": bson.M{
+				"name": 1,
+			},
+		},
+	}
+
+	// Only match for the respective tenant if requested
+	if tenant := gateway.TenantFromContext(ctx); tenant != nil {
+		query = append(query, bson.M{
+			"$match": bson.M{
+				"tenant_id": tenant.ID,
+			},
+		})
+	}
+
+	queryCount := query
+	queryCount = append(queryCount, bson.M{"$count": "count"})
+	count, err := aggregateCount(ctx, s.db.Collection("roles"), queryCount)
+	if err != nil {
+		return nil, 0, fromMongoError(err)
+	}
+
+	query = append(query, queries.BuildPaginationQuery(pagination)...)
+
+	roles := make([]models.Role, 0)
+	cursor, err := s.db.Collection("roles").Aggregate(ctx, query)
+	if err != nil {
+		return roles, count, fromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		role := new(models.Role)
+		if err := cursor.Decode(&role); err != nil {
+			return roles, count, fromMongoError(err)
+		}
+
+		roles = append(roles, *role)
+	}
+
+	return roles, count, nil
+}
+
+func (s *Store) RoleGet(ctx context.Context, tenant string, id string) (*models.Role, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fromMongoError(err)
+	}
+
+	role := new(models.Role)
+	if err := s.db.Collection("roles").FindOne(ctx, bson.M{"_id": objID, "tenant_id": tenant}).Decode(&role); err != nil {
+		return nil, fromMongoError(err)
+	}
+
+	return role, nil
+}
+
+func (s *Store) RoleGetByName(ctx context.Context, tenant string, name string) (*models.Role, error) {
+	role := new(models.Role)
+	if err := s.db.Collection("roles").FindOne(ctx, bson.M{"tenant_id": tenant, "name": name}).Decode(&role); err != nil {
+		return nil, fromMongoError(err)
+	}
+
+	return role, nil
+}
+
+func (s *Store) RoleCreate(ctx context.Context, role *models.Role) error {
+	result, err := s.db.Collection("roles").InsertOne(ctx, role)
+	if err != nil {
+		return fromMongoError(err)
+	}
+
+	if objID, ok := result.InsertedID.(primitive.ObjectID); ok {
+		role.ID = objID.Hex()
+	}
+
+	return nil
+}
+
+func (s *Store) RoleUpdate(ctx context.Context, tenant string, id string, role *models.RoleUpdate) (*models.Role, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fromMongoError(err)
+	}
+
+	update := bson.M{"$set": role}
+	if role.Description == "" {
+		update["$unset"] = bson.M{"description": ""}
+	}
+
+	result, err := s.db.Collection("roles").UpdateOne(ctx, bson.M{"_id": objID, "tenant_id": tenant}, update)
+	if err != nil {
+		return nil, fromMongoError(err)
+	}
+
+	if result.MatchedCount < 1 {
+		return nil, store.ErrNoDocuments
+	}
+
+	return s.RoleGet(ctx, tenant, id)
+}
+
+func (s *Store) RoleDelete(ctx context.Context, tenant string, id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fromMongoError(err)
+	}
+
+	result, err := s.db.Collection("roles").DeleteOne(ctx, bson.M{"_id": objID, "tenant_id": tenant})
+	if err != nil {
+		return fromMongoError(err)
+	}
+
+	if result.DeletedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
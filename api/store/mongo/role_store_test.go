@@ -0,0 +1,55 @@
+package mongo
+
+import (
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/cache"
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRole(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	role := &models.Role{
+		TenantID:   "tenant",
+		RoleFields: models.RoleFields{Name: "support", Description: "support", Permissions: []string{"session_play"}},
+	}
+
+	err := mongostore.RoleCreate(data.Context, role)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, role.ID)
+
+	roles, count, err := mongostore.RoleList(data.Context, paginator.Query{Page: -1, PerPage: -1})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, "support", roles[0].Name)
+
+	got, err := mongostore.RoleGetByName(data.Context, "tenant", "support")
+	assert.NoError(t, err)
+	assert.Equal(t, role.ID, got.ID)
+
+	updated, err := mongostore.RoleUpdate(data.Context, "tenant", role.ID, &models.RoleUpdate{
+		RoleFields: models.RoleFields{Name: "support", Permissions: []string{"session_play", "device_connect"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"session_play", "device_connect"}, updated.Permissions)
+	assert.Empty(t, updated.Description)
+
+	_, err = mongostore.RoleGet(data.Context, "other", role.ID)
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+
+	err = mongostore.RoleDelete(data.Context, "tenant", role.ID)
+	assert.NoError(t, err)
+
+	err = mongostore.RoleDelete(data.Context, "tenant", role.ID)
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+}
@@ -0,0 +1,89 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Store) SessionApprovalList(ctx context.Context, pagination paginator.Query) ([]models.SessionApproval, int, error) {
+	query := []bson.M{
+		{
+			"$sort": bson.M{
+				"created_at": -1,
+			},
+		},
+	}
+
+	// Only match for the respective tenant if requested
+	if tenant := gateway.TenantFromContext(ctx); tenant != nil {
+		query = append(query, bson.M{
+			"$match": bson.M{
+				"tenant_id": tenant.ID,
+			},
+		})
+	}
+
+	queryCount := query
+	queryCount = append(queryCount, bson.M{"$count": "count"})
+	count, err := aggregateCount(ctx, s.db.Collection("session_approvals"), queryCount)
+	if err != nil {
+		return nil, 0, fromMongoError(err)
+	}
+
+	query = append(query, queries.BuildPaginationQuery(pagination)...)
+
+	approvals := make([]models.SessionApproval, 0)
+	cursor, err := s.db.Collection("session_approvals").Aggregate(ctx, query)
+	if err != nil {
+		return approvals, count, fromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		approval := new(models.SessionApproval)
+		if err := cursor.Decode(&approval); err != nil {
+			return approvals, count, fromMongoError(err)
+		}
+
+		approvals = append(approvals, *approval)
+	}
+
+	return approvals, count, nil
+}
+
+func (s *Store) SessionApprovalGet(ctx context.Context, uid models.UID) (*models.SessionApproval, error) {
+	approval := new(models.SessionApproval)
+	if err := s.db.Collection("session_approvals").FindOne(ctx, bson.M{"uid": uid}).Decode(&approval); err != nil {
+		return nil, fromMongoError(err)
+	}
+
+	return approval, nil
+}
+
+func (s *Store) SessionApprovalCreate(ctx context.Context, approval *models.SessionApproval) error {
+	if _, err := s.db.Collection("session_approvals").InsertOne(ctx, approval); err != nil {
+		return fromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) SessionApprovalDecide(ctx context.Context, uid models.UID, status string, userID string, decidedAt time.Time) (*models.SessionApproval, error) {
+	approval := new(models.SessionApproval)
+	if err := s.db.Collection("session_approvals").FindOneAndUpdate(ctx,
+		bson.M{"uid": uid, "status": models.SessionApprovalPending},
+		bson.M{"$set": bson.M{"status": status, "decided_by": userID, "decided_at": decidedAt}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&approval); err != nil {
+		return nil, fromMongoError(err)
+	}
+
+	return approval, nil
+}
@@ -0,0 +1,55 @@
+package mongo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/cache"
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionApproval(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	approval := &models.SessionApproval{
+		UID:       "uid",
+		DeviceUID: "device",
+		TenantID:  "tenant",
+		Status:    models.SessionApprovalPending,
+	}
+
+	err := mongostore.SessionApprovalCreate(data.Context, approval)
+	assert.NoError(t, err)
+
+	approvals, count, err := mongostore.SessionApprovalList(data.Context, paginator.Query{Page: -1, PerPage: -1})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, "uid", approvals[0].UID)
+
+	decidedAt := time.Now().UTC().Truncate(time.Millisecond)
+
+	decided, err := mongostore.SessionApprovalDecide(data.Context, "uid", models.SessionApprovalApproved, "user", decidedAt)
+	assert.NoError(t, err)
+	assert.Equal(t, models.SessionApprovalApproved, decided.Status)
+	assert.Equal(t, "user", decided.DecidedBy)
+	assert.Equal(t, decidedAt, decided.DecidedAt.UTC())
+
+	_, err = mongostore.SessionApprovalDecide(data.Context, "uid", models.SessionApprovalDenied, "user", decidedAt)
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+
+	got, err := mongostore.SessionApprovalGet(data.Context, "uid")
+	assert.NoError(t, err)
+	assert.Equal(t, models.SessionApprovalApproved, got.Status)
+
+	_, err = mongostore.SessionApprovalGet(data.Context, "other")
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
 	"github.com/shellhub-io/shellhub/pkg/api/paginator"
 	"github.com/shellhub-io/shellhub/pkg/clock"
@@ -67,8 +68,9 @@ func (s *Store) SessionList(ctx context.Context, pagination paginator.Query) ([]
 			return sessions, count, err
 		}
 
+		// Sessions of purged devices are kept without the device's data.
 		device, err := s.DeviceGet(ctx, session.DeviceUID)
-		if err != nil {
+		if err != nil && err != store.ErrNoDocuments {
 			return sessions, count, err
 		}
 
@@ -123,7 +125,7 @@ func (s *Store) SessionGet(ctx context.Context, uid models.UID) (*models.Session
 	}
 
 	device, err := s.DeviceGet(ctx, session.DeviceUID)
-	if err != nil {
+	if err != nil && err != store.ErrNoDocuments {
 		return nil, fromMongoError(err)
 	}
 
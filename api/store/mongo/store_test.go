@@ -173,7 +173,7 @@ func initData() Data {
 				Priority: 1,
 				Action:   "allow",
 				Active:   true,
-				SourceIP: ".*",
+				SourceIP: "*",
 				Username: ".*",
 				Filter: models.FirewallFilter{
 					Tags: []string{},
@@ -0,0 +1,202 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (s *Store) WebhookList(ctx context.Context, pagination paginator.Query) ([]models.Webhook, int, error) {
+	query := []bson.M{
+		{
+			"$sort": bson.M{
+				"created_at": 1,
+			},
+		},
+	}
+
+	// Only match for the respective tenant if requested
+	if tenant := gateway.TenantFromContext(ctx); tenant != nil {
+		query = append(query, bson.M{
+			"$match": bson.M{
+				"tenant_id": tenant.ID,
+			},
+		})
+	}
+
+	queryCount := query
+	queryCount = append(queryCount, bson.M{"$count": "count"})
+	count, err := aggregateCount(ctx, s.db.Collection("webhooks"), queryCount)
+	if err != nil {
+		return nil, 0, fromMongoError(err)
+	}
+
+	query = append(query, queries.BuildPaginationQuery(pagination)...)
+
+	webhooks := make([]models.Webhook, 0)
+	cursor, err := s.db.Collection("webhooks").Aggregate(ctx, query)
+	if err != nil {
+		return webhooks, count, fromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		webhook := new(models.Webhook)
+		if err := cursor.Decode(&webhook); err != nil {
+			return webhooks, count, fromMongoError(err)
+		}
+
+		webhooks = append(webhooks, *webhook)
+	}
+
+	return webhooks, count, nil
+}
+
+func (s *Store) WebhookListByEvent(ctx context.Context, tenant string, event string) ([]models.Webhook, error) {
+	cursor, err := s.db.Collection("webhooks").Find(ctx, bson.M{"tenant_id": tenant, "events": event, "active": true})
+	if err != nil {
+		return nil, fromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	webhooks := make([]models.Webhook, 0)
+	for cursor.Next(ctx) {
+		webhook := new(models.Webhook)
+		if err := cursor.Decode(&webhook); err != nil {
+			return webhooks, fromMongoError(err)
+		}
+
+		webhooks = append(webhooks, *webhook)
+	}
+
+	return webhooks, nil
+}
+
+func (s *Store) WebhookGet(ctx context.Context, tenant string, id string) (*models.Webhook, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fromMongoError(err)
+	}
+
+	webhook := new(models.Webhook)
+	if err := s.db.Collection("webhooks").FindOne(ctx, bson.M{"_id": objID, "tenant_id": tenant}).Decode(&webhook); err != nil {
+		return nil, fromMongoError(err)
+	}
+
+	return webhook, nil
+}
+
+func (s *Store) WebhookCreate(ctx context.Context, webhook *models.Webhook) error {
+	result, err := s.db.Collection("webhooks").InsertOne(ctx, webhook)
+	if err != nil {
+		return fromMongoError(err)
+	}
+
+	if objID, ok := result.InsertedID.(primitive.ObjectID); ok {
+		webhook.ID = objID.Hex()
+	}
+
+	return nil
+}
+
+func (s *Store) WebhookUpdate(ctx context.Context, tenant string, id string, webhook *models.WebhookUpdate) (*models.Webhook, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fromMongoError(err)
+	}
+
+	result, err := s.db.Collection("webhooks").UpdateOne(ctx, bson.M{"_id": objID, "tenant_id": tenant}, bson.M{"$set": webhook})
+	if err != nil {
+		return nil, fromMongoError(err)
+	}
+
+	if result.MatchedCount < 1 {
+		return nil, store.ErrNoDocuments
+	}
+
+	return s.WebhookGet(ctx, tenant, id)
+}
+
+func (s *Store) WebhookDelete(ctx context.Context, tenant string, id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fromMongoError(err)
+	}
+
+	result, err := s.db.Collection("webhooks").DeleteOne(ctx, bson.M{"_id": objID, "tenant_id": tenant})
+	if err != nil {
+		return fromMongoError(err)
+	}
+
+	if result.DeletedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	if _, err := s.db.Collection("webhook_deliveries").DeleteMany(ctx, bson.M{"webhook_id": id}); err != nil {
+		return fromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) WebhookDeliveryCreate(ctx context.Context, delivery *models.WebhookDelivery) error {
+	result, err := s.db.Collection("webhook_deliveries").InsertOne(ctx, delivery)
+	if err != nil {
+		return fromMongoError(err)
+	}
+
+	if objID, ok := result.InsertedID.(primitive.ObjectID); ok {
+		delivery.ID = objID.Hex()
+	}
+
+	return nil
+}
+
+func (s *Store) WebhookDeliveryList(ctx context.Context, tenant string, id string, pagination paginator.Query) ([]models.WebhookDelivery, int, error) {
+	query := []bson.M{
+		{
+			"$match": bson.M{
+				"tenant_id":  tenant,
+				"webhook_id": id,
+			},
+		},
+		{
+			"$sort": bson.M{
+				"created_at": -1,
+			},
+		},
+	}
+
+	queryCount := query
+	queryCount = append(queryCount, bson.M{"$count": "count"})
+	count, err := aggregateCount(ctx, s.db.Collection("webhook_deliveries"), queryCount)
+	if err != nil {
+		return nil, 0, fromMongoError(err)
+	}
+
+	query = append(query, queries.BuildPaginationQuery(pagination)...)
+
+	deliveries := make([]models.WebhookDelivery, 0)
+	cursor, err := s.db.Collection("webhook_deliveries").Aggregate(ctx, query)
+	if err != nil {
+		return deliveries, count, fromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		delivery := new(models.WebhookDelivery)
+		if err := cursor.Decode(&delivery); err != nil {
+			return deliveries, count, fromMongoError(err)
+		}
+
+		deliveries = append(deliveries, *delivery)
+	}
+
+	return deliveries, count, nil
+}
@@ -0,0 +1,82 @@
+package mongo
+
+import (
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/cache"
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhook(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	webhook := &models.Webhook{
+		TenantID: "tenant",
+		Secret:   "secret",
+		WebhookFields: models.WebhookFields{
+			URL:    "https://example.com/hook",
+			Events: []string{models.WebhookEventDeviceAccepted},
+			Active: true,
+		},
+	}
+
+	err := mongostore.WebhookCreate(data.Context, webhook)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, webhook.ID)
+
+	webhooks, count, err := mongostore.WebhookList(data.Context, paginator.Query{Page: -1, PerPage: -1})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, "https://example.com/hook", webhooks[0].URL)
+
+	webhooks, err = mongostore.WebhookListByEvent(data.Context, "tenant", models.WebhookEventDeviceAccepted)
+	assert.NoError(t, err)
+	assert.Len(t, webhooks, 1)
+
+	webhooks, err = mongostore.WebhookListByEvent(data.Context, "tenant", models.WebhookEventDeviceOffline)
+	assert.NoError(t, err)
+	assert.Len(t, webhooks, 0)
+
+	updated, err := mongostore.WebhookUpdate(data.Context, "tenant", webhook.ID, &models.WebhookUpdate{
+		WebhookFields: models.WebhookFields{URL: "https://example.com/hook", Events: []string{models.WebhookEventDeviceAccepted}},
+	})
+	assert.NoError(t, err)
+	assert.False(t, updated.Active)
+	assert.Equal(t, "secret", updated.Secret)
+
+	webhooks, err = mongostore.WebhookListByEvent(data.Context, "tenant", models.WebhookEventDeviceAccepted)
+	assert.NoError(t, err)
+	assert.Len(t, webhooks, 0)
+
+	delivery := &models.WebhookDelivery{WebhookID: webhook.ID, TenantID: "tenant", EventID: "event", Attempt: 1, Success: true}
+	err = mongostore.WebhookDeliveryCreate(data.Context, delivery)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, delivery.ID)
+
+	deliveries, count, err := mongostore.WebhookDeliveryList(data.Context, "tenant", webhook.ID, paginator.Query{Page: -1, PerPage: -1})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, "event", deliveries[0].EventID)
+
+	_, err = mongostore.WebhookGet(data.Context, "other", webhook.ID)
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+
+	err = mongostore.WebhookDelete(data.Context, "tenant", webhook.ID)
+	assert.NoError(t, err)
+
+	_, count, err = mongostore.WebhookDeliveryList(data.Context, "tenant", webhook.ID, paginator.Query{Page: -1, PerPage: -1})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	err = mongostore.WebhookDelete(data.Context, "tenant", webhook.ID)
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+}
@@ -18,7 +18,22 @@ type NamespaceStore interface {
 	NamespaceAddMember(ctx context.Context, tenantID string, memberID string, memberRole string) (*models.Namespace, error)
 	NamespaceRemoveMember(ctx context.Context, tenantID string, memberID string) (*models.Namespace, error)
 	NamespaceEditMember(ctx context.Context, tenantID string, memberID string, memberNewRole string) error
+	// NamespaceSetMemberScope sets the devices a member of a namespace is restricted to. A nil scope removes the
+	// restriction.
+	NamespaceSetMemberScope(ctx context.Context, tenantID string, memberID string, scope *models.MemberScope) error
+	// NamespaceTransferOwnership makes a member the owner of a namespace, demoting the previous owner to administrator
+	// and recording the transfer. It returns ErrNoDocuments when the namespace is not owned by transfer.From or when
+	// transfer.To is not a member.
+	NamespaceTransferOwnership(ctx context.Context, tenantID string, transfer *models.OwnershipTransfer) error
 	NamespaceGetFirst(ctx context.Context, id string) (*models.Namespace, error)
+	// NamespaceListByMember lists the namespaces of which the user is a member, sorted by name.
+	NamespaceListByMember(ctx context.Context, id string) ([]models.Namespace, error)
 	NamespaceSetSessionRecord(ctx context.Context, sessionRecord bool, tenantID string) error
 	NamespaceGetSessionRecord(ctx context.Context, tenantID string) (bool, error)
+	// NamespaceSetDeviceNaming sets the device naming settings of a namespace. A nil naming removes the settings.
+	NamespaceSetDeviceNaming(ctx context.Context, tenantID string, naming *models.DeviceNaming) error
+	// NamespaceSetGeoAccess sets the country restrictions of a namespace. A nil geo access removes the restrictions.
+	NamespaceSetGeoAccess(ctx context.Context, tenantID string, geo *models.GeoAccess) error
+	// NamespaceSetSessionTypes sets the session types enabled on a namespace. Nil types enable every session type.
+	NamespaceSetSessionTypes(ctx context.Context, tenantID string, types *models.SessionTypes) error
 }
@@ -13,4 +13,6 @@ type PublicKeyStore interface {
 	PublicKeyCreate(ctx context.Context, key *models.PublicKey) error
 	PublicKeyUpdate(ctx context.Context, fingerprint string, tenantID string, key *models.PublicKeyUpdate) (*models.PublicKey, error)
 	PublicKeyDelete(ctx context.Context, fingerprint string, tenantID string) error
+	// PublicKeyListByTenant lists every public key of a namespace sorted by creation.
+	PublicKeyListByTenant(ctx context.Context, tenantID string) ([]models.PublicKey, error)
 }
@@ -0,0 +1,17 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type RoleStore interface {
+	RoleList(ctx context.Context, pagination paginator.Query) ([]models.Role, int, error)
+	RoleGet(ctx context.Context, tenant string, id string) (*models.Role, error)
+	RoleGetByName(ctx context.Context, tenant string, name string) (*models.Role, error)
+	RoleCreate(ctx context.Context, role *models.Role) error
+	RoleUpdate(ctx context.Context, tenant string, id string, role *models.RoleUpdate) (*models.Role, error)
+	RoleDelete(ctx context.Context, tenant string, id string) error
+}
@@ -0,0 +1,18 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type SessionApprovalStore interface {
+	SessionApprovalList(ctx context.Context, pagination paginator.Query) ([]models.SessionApproval, int, error)
+	SessionApprovalGet(ctx context.Context, uid models.UID) (*models.SessionApproval, error)
+	SessionApprovalCreate(ctx context.Context, approval *models.SessionApproval) error
+	// SessionApprovalDecide sets the status of a pending session approval, returning ErrNoDocuments when it is not
+	// pending.
+	SessionApprovalDecide(ctx context.Context, uid models.UID, status string, userID string, decidedAt time.Time) (*models.SessionApproval, error)
+}
@@ -4,9 +4,17 @@ type Store interface {
 	TagsStore
 	DeviceStore
 	DeviceTagsStore
+	DeviceHistoryStore
+	DeviceGroupStore
 	SessionStore
+	SessionApprovalStore
 	UserStore
 	FirewallStore
+	IPSetStore
+	RoleStore
+	InvitationStore
+	AuditStore
+	WebhookStore
 	FirewallTagsStore
 	NamespaceStore
 	PublicKeyStore
@@ -0,0 +1,20 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type WebhookStore interface {
+	WebhookList(ctx context.Context, pagination paginator.Query) ([]models.Webhook, int, error)
+	// WebhookListByEvent lists the active webhooks of a namespace subscribed to an event.
+	WebhookListByEvent(ctx context.Context, tenant string, event string) ([]models.Webhook, error)
+	WebhookGet(ctx context.Context, tenant string, id string) (*models.Webhook, error)
+	WebhookCreate(ctx context.Context, webhook *models.Webhook) error
+	WebhookUpdate(ctx context.Context, tenant string, id string, webhook *models.WebhookUpdate) (*models.Webhook, error)
+	WebhookDelete(ctx context.Context, tenant string, id string) error
+	WebhookDeliveryCreate(ctx context.Context, delivery *models.WebhookDelivery) error
+	WebhookDeliveryList(ctx context.Context, tenant string, id string, pagination paginator.Query) ([]models.WebhookDelivery, int, error)
+}
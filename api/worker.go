@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"net/url"
 	"runtime"
 	"time"
@@ -9,6 +10,8 @@ import (
 	"github.com/hibiken/asynq"
 	"github.com/kelseyhightower/envconfig"
 	"github.com/pkg/errors"
+	"github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -104,7 +107,181 @@ func sessionRecordCleanup() error {
 	return nil
 }
 
-func startWorker(cfg *config) error {
+// deviceDecommissionedCleanup purges decommissioned devices older than days defined by SHELLHUB_DEVICE_RETENTION,
+// deleting the device with its sessions, records and connection history.
+// When SHELLHUB_DEVICE_RETENTION is equals to zero, decommissioned devices will never be purged.
+// When SHELLHUB_DEVICE_RETENTION is less than zero, nothing happen.
+func deviceDecommissionedCleanup() error {
+	logrus.Info("Running worker to purge decommissioned devices...")
+
+	type config struct {
+		MongoURI        string `envconfig:"mongo_uri" default:"mongodb://mongo:27017/main"`
+		DeviceRetention int    `envconfig:"device_retention" default:"0"`
+	}
+
+	// Loading env variables.
+	var envs config
+	if err := envconfig.Process("api", &envs); err != nil {
+		return errors.Wrap(err, "Failed to load environment variables")
+	}
+
+	// Decommissioned device retention time was not defined.
+	if envs.DeviceRetention == 0 {
+		logrus.Warn("A time to purge the decommissioned devices was not defined. Skipping...")
+
+		return nil
+	}
+
+	if envs.DeviceRetention < 0 {
+		return errors.New("Invalid time interval")
+	}
+
+	// Devices decommissioned before that date will be purged.
+	dateLimit := time.Now().UTC().AddDate(0, 0, envs.DeviceRetention*-1)
+
+	logrus.Debug("Connecting to MongoDB...")
+
+	connStr, err := connstring.ParseAndValidate(envs.MongoURI)
+	if err != nil {
+		return errors.Wrap(err, "Invalid Mongo URI format")
+	}
+
+	client, err := mongo.Connect(context.TODO(), options.Client().ApplyURI(envs.MongoURI))
+	if err != nil {
+		return errors.Wrap(err, "Failed to connect to MongoDB")
+	}
+
+	if err = client.Ping(context.TODO(), nil); err != nil {
+		return errors.Wrap(err, "Failed to ping MongoDB")
+	}
+
+	db := client.Database(connStr.Database)
+
+	uids, err := db.Collection("devices").Distinct(context.Background(), "uid",
+		bson.M{"status": "decommissioned", "decommissioned_at": bson.M{"$lte": dateLimit}},
+	)
+	if err != nil {
+		return errors.Wrap(err, "Failed to list the decommissioned devices from MongoDB")
+	}
+
+	if len(uids) == 0 {
+		logrus.Info("No decommissioned devices to purge")
+
+		return nil
+	}
+
+	sessions, err := db.Collection("sessions").Distinct(context.Background(), "uid", bson.M{"device_uid": bson.M{"$in": uids}})
+	if err != nil {
+		return errors.Wrap(err, "Failed to list the sessions of decommissioned devices from MongoDB")
+	}
+
+	if _, err := db.Collection("recorded_sessions").DeleteMany(context.Background(), bson.M{"uid": bson.M{"$in": sessions}}); err != nil {
+		return errors.Wrap(err, "Failed to delete the session's records of decommissioned devices from MongoDB")
+	}
+
+	if _, err := db.Collection("sessions").DeleteMany(context.Background(), bson.M{"device_uid": bson.M{"$in": uids}}); err != nil {
+		return errors.Wrap(err, "Failed to delete the sessions of decommissioned devices from MongoDB")
+	}
+
+	if _, err := db.Collection("device_history").DeleteMany(context.Background(), bson.M{"uid": bson.M{"$in": uids}}); err != nil {
+		return errors.Wrap(err, "Failed to delete the history of decommissioned devices from MongoDB")
+	}
+
+	deleted, err := db.Collection("devices").DeleteMany(context.Background(), bson.M{"uid": bson.M{"$in": uids}})
+	if err != nil {
+		return errors.Wrap(err, "Failed to delete the decommissioned devices from MongoDB")
+	}
+
+	logrus.Info(deleted.DeletedCount, " decommissioned devices purged")
+
+	return nil
+}
+
+// auditLogCleanup deletes the audit logs older than days defined by SHELLHUB_AUDIT_RETENTION.
+// When SHELLHUB_AUDIT_RETENTION is equals to zero, audit logs will never be deleted.
+// When SHELLHUB_AUDIT_RETENTION is less than zero, nothing happen.
+func auditLogCleanup() error {
+	logrus.Info("Running worker to delete audit logs...")
+
+	type config struct {
+		MongoURI       string `envconfig:"mongo_uri" default:"mongodb://mongo:27017/main"`
+		AuditRetention int    `envconfig:"audit_retention" default:"0"`
+	}
+
+	// Loading env variables.
+	var envs config
+	if err := envconfig.Process("api", &envs); err != nil {
+		return errors.Wrap(err, "Failed to load environment variables")
+	}
+
+	// Audit log retention time was not defined.
+	if envs.AuditRetention == 0 {
+		logrus.Warn("A time to clean the audit logs was not defined. Skipping...")
+
+		return nil
+	}
+
+	if envs.AuditRetention < 0 {
+		return errors.New("Invalid time interval")
+	}
+
+	// Audit logs older than that date will be deleted.
+	dateLimit := time.Now().UTC().AddDate(0, 0, envs.AuditRetention*-1)
+
+	logrus.Debug("Connecting to MongoDB...")
+
+	connStr, err := connstring.ParseAndValidate(envs.MongoURI)
+	if err != nil {
+		return errors.Wrap(err, "Invalid Mongo URI format")
+	}
+
+	client, err := mongo.Connect(context.TODO(), options.Client().ApplyURI(envs.MongoURI))
+	if err != nil {
+		return errors.Wrap(err, "Failed to connect to MongoDB")
+	}
+
+	if err = client.Ping(context.TODO(), nil); err != nil {
+		return errors.Wrap(err, "Failed to ping MongoDB")
+	}
+
+	deleted, err := client.Database(connStr.Database).Collection("audit_logs").DeleteMany(context.Background(),
+		bson.M{"created_at": bson.M{"$lte": dateLimit}},
+	)
+	if err != nil {
+		return errors.Wrap(err, "Failed to delete the audit logs from MongoDB")
+	}
+
+	logrus.Info(deleted.DeletedCount, " audit logs deleted")
+
+	return nil
+}
+
+// webhookQueue enqueues the deliveries to the webhooks as tasks of the worker.
+type webhookQueue struct {
+	client *asynq.Client
+}
+
+func newWebhookQueue(cfg *config) (*webhookQueue, error) {
+	addr, err := url.Parse(cfg.RedisURI)
+	if err != nil {
+		return nil, err
+	}
+
+	return &webhookQueue{client: asynq.NewClient(asynq.RedisClientOpt{Addr: addr.Host})}, nil
+}
+
+func (q *webhookQueue) EnqueueWebhookDelivery(ctx context.Context, task *models.WebhookDeliveryTask) error {
+	payload, err := json.Marshal(task)
+	if err != nil {
+		return err
+	}
+
+	_, err = q.client.EnqueueContext(ctx, asynq.NewTask("webhook:deliver", payload), asynq.MaxRetry(services.WebhookMaxRetries))
+
+	return err
+}
+
+func startWorker(cfg *config, service services.Service) error {
 	addr, err := url.Parse(cfg.RedisURI)
 	if err != nil {
 		return err
@@ -128,6 +305,38 @@ func startWorker(cfg *config) error {
 		return nil
 	})
 
+	// Handle device:cleanup task
+	mux.HandleFunc("device:cleanup", func(ctx context.Context, task *asynq.Task) error {
+		if err := deviceDecommissionedCleanup(); err != nil {
+			logrus.Error(err)
+		}
+
+		return nil
+	})
+
+	// Handle audit:cleanup task
+	mux.HandleFunc("audit:cleanup", func(ctx context.Context, task *asynq.Task) error {
+		if err := auditLogCleanup(); err != nil {
+			logrus.Error(err)
+		}
+
+		return nil
+	})
+
+	// Handle webhook:deliver task, retried by the worker while the delivery fails
+	mux.HandleFunc("webhook:deliver", func(ctx context.Context, task *asynq.Task) error {
+		var delivery models.WebhookDeliveryTask
+		if err := json.Unmarshal(task.Payload(), &delivery); err != nil {
+			logrus.Error(err)
+
+			return nil
+		}
+
+		retried, _ := asynq.GetRetryCount(ctx)
+
+		return service.DeliverWebhook(ctx, &delivery, retried+1)
+	})
+
 	go func() {
 		if err := srv.Run(mux); err != nil {
 			logrus.Fatal(err)
@@ -142,5 +351,17 @@ func startWorker(cfg *config) error {
 		logrus.Error(err)
 	}
 
+	// Schedule device:cleanup to run once a day
+	if _, err := scheduler.Register(cfg.DeviceCleanupSchedule,
+		asynq.NewTask("device:cleanup", nil, asynq.TaskID("device:cleanup"))); err != nil {
+		logrus.Error(err)
+	}
+
+	// Schedule audit:cleanup to run once a day
+	if _, err := scheduler.Register(cfg.AuditCleanupSchedule,
+		asynq.NewTask("audit:cleanup", nil, asynq.TaskID("audit:cleanup"))); err != nil {
+		logrus.Error(err)
+	}
+
 	return scheduler.Run()
 }
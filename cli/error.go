@@ -13,6 +13,7 @@ var (
 	ErrFailedDeleteUser            = errors.New("failed to delete the user")
 	ErrFailedDeleteNamespace       = errors.New("failed to delete the namespace")
 	ErrFailedUpdateUser            = errors.New("failed to reset the password for the user")
+	ErrFailedUnlockUser            = errors.New("failed to unlock the user")
 	ErrFailedNamespaceRemoveMember = errors.New("failed to remove member from the namespace")
 	ErrUserDataInvalid             = errors.New("user data is invalid")
 	ErrUserPasswordInvalid         = errors.New("user password is invalid")
@@ -21,4 +22,8 @@ var (
 	ErrUserNameAndEmailExists      = errors.New("user name and email already exists")
 	ErrNamespaceInvalid            = errors.New("namespace is invalid")
 	ErrFailedNamespaceAddMember    = errors.New("could not add this member to this namespace")
+	ErrRoleNotFound                = errors.New("role not found in the namespace")
+	ErrNamespaceMemberNotFound     = errors.New("user is not a member of the namespace")
+	ErrNamespaceAlreadyOwner       = errors.New("user is already the namespace owner")
+	ErrFailedNamespaceTransfer     = errors.New("failed to transfer the namespace")
 )
@@ -45,7 +45,7 @@ func main() {
 		cache = storecache.NewNullCache()
 	}
 
-	services := NewService(mongo.NewStore(client.Database(connStr.Database), cache))
+	services := NewService(mongo.NewStore(client.Database(connStr.Database), cache), cache)
 
 	rootCmd := &cobra.Command{Use: "cli"}
 	rootCmd.AddCommand(&cobra.Command{
@@ -93,6 +93,20 @@ func main() {
 				return nil
 			},
 		},
+		&cobra.Command{
+			Use:   "unlock-user",
+			Short: "Usage: <username>",
+			Args:  cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				if err := services.UserUnlock(args[0]); err != nil {
+					return err
+				}
+
+				rootCmd.Println("User unlocked")
+
+				return nil
+			},
+		},
 		&cobra.Command{
 			Use:   "add-namespace",
 			Short: "Usage: <namespace> <owner>",
@@ -148,6 +162,23 @@ func main() {
 				return nil
 			},
 		},
+		&cobra.Command{
+			Use:   "transfer-namespace",
+			Short: "Usage: <namespace> <username>",
+			Args:  cobra.ExactArgs(2),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				ns, err := services.NamespaceTransfer(args[0], args[1])
+				if err != nil {
+					return err
+				}
+
+				rootCmd.Println("Namespace:", ns.Name)
+				rootCmd.Println("transferred to:", args[1])
+				rootCmd.Println("Owner:", ns.Owner)
+
+				return nil
+			},
+		},
 		&cobra.Command{
 			Use:   "del-namespace",
 			Short: "Usage: <namespace>",
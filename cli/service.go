@@ -2,8 +2,11 @@ package main
 
 import (
 	"context"
+	"strings"
 
+	"github.com/shellhub-io/shellhub/api/cache"
 	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/services"
 	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/pkg/api/paginator"
 	"github.com/shellhub-io/shellhub/pkg/clock"
@@ -16,18 +19,21 @@ type Service interface {
 	UserCreate(username, password, email string) (*models.User, error)
 	UserDelete(username string) error
 	UserUpdate(username, password string) error
+	UserUnlock(username string) error
 	NamespaceCreate(namespace, username, tenant string) (*models.Namespace, error)
 	NamespaceAddMember(username, namespace, role string) (*models.Namespace, error)
 	NamespaceRemoveMember(username, namespace string) (*models.Namespace, error)
+	NamespaceTransfer(namespace, username string) (*models.Namespace, error)
 	NamespaceDelete(namespace string) error
 }
 
 type service struct {
 	store store.Store
+	cache cache.Cache
 }
 
-func NewService(store store.Store) Service {
-	return &service{store}
+func NewService(store store.Store, cache cache.Cache) Service {
+	return &service{store, cache}
 }
 
 func (s *service) UserCreate(username, password, email string) (*models.User, error) {
@@ -167,6 +173,25 @@ func (s *service) UserUpdate(username, password string) error {
 	return nil
 }
 
+// UserUnlock clears the failed logins and the lockout of a user.
+func (s *service) UserUnlock(username string) error {
+	ctx := context.Background()
+
+	user, err := s.store.UserGetByUsername(ctx, strings.ToLower(username))
+	if err != nil {
+		return ErrUserNotFound
+	}
+
+	lockout := services.LoginLockoutUsernameKey + user.Username
+	for _, key := range []string{lockout + services.LoginLockoutFailuresKey, lockout} {
+		if err := s.cache.Delete(ctx, key); err != nil {
+			return ErrFailedUnlockUser
+		}
+	}
+
+	return nil
+}
+
 func (s *service) NamespaceCreate(namespace, username, tenant string) (*models.Namespace, error) {
 	ctx := context.Background()
 
@@ -226,6 +251,13 @@ func (s *service) NamespaceAddMember(username, namespace, role string) (*models.
 		return nil, ErrNamespaceNotFound
 	}
 
+	// A role other than the built-in ones must be a custom role of the namespace.
+	if _, ok := guard.Roles[role]; !ok {
+		if _, err := s.store.RoleGetByName(ctx, ns.TenantID, role); err != nil {
+			return nil, ErrRoleNotFound
+		}
+	}
+
 	ns, err = s.store.NamespaceAddMember(ctx, ns.TenantID, user.ID, role)
 	if err != nil {
 		return nil, ErrFailedNamespaceAddMember
@@ -259,6 +291,40 @@ func (s *service) NamespaceRemoveMember(username, namespace string) (*models.Nam
 	return ns, nil
 }
 
+// NamespaceTransfer makes a member the owner of the namespace, demoting the current owner to administrator.
+func (s *service) NamespaceTransfer(namespace, username string) (*models.Namespace, error) {
+	ctx := context.Background()
+
+	if !validator.ValidateFieldUsername(username) {
+		return nil, ErrInvalidFormat
+	}
+
+	user, err := s.store.UserGetByUsername(ctx, username)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	ns, err := s.store.NamespaceGetByName(ctx, namespace)
+	if err != nil {
+		return nil, ErrNamespaceNotFound
+	}
+
+	if _, ok := guard.CheckMember(ns, user.ID); !ok {
+		return nil, ErrNamespaceMemberNotFound
+	}
+
+	if ns.Owner == user.ID {
+		return nil, ErrNamespaceAlreadyOwner
+	}
+
+	transfer := &models.OwnershipTransfer{From: ns.Owner, To: user.ID, TransferredAt: clock.Now()}
+	if err := s.store.NamespaceTransferOwnership(ctx, ns.TenantID, transfer); err != nil {
+		return nil, ErrFailedNamespaceTransfer
+	}
+
+	return s.store.NamespaceGetByName(ctx, namespace)
+}
+
 func (s *service) NamespaceDelete(namespace string) error {
 	ctx := context.Background()
 
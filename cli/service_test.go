@@ -6,6 +6,8 @@ import (
 	"testing"
 	"time"
 
+	storecache "github.com/shellhub-io/shellhub/api/cache"
+	cachemocks "github.com/shellhub-io/shellhub/api/cache/mocks"
 	"github.com/shellhub-io/shellhub/api/pkg/guard"
 	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/api/store/mocks"
@@ -17,7 +19,7 @@ import (
 
 func TestDelUser(t *testing.T) {
 	mock := &mocks.Store{}
-	s := NewService(store.Store(mock))
+	s := NewService(store.Store(mock), storecache.NewNullCache())
 
 	ctx := context.TODO()
 
@@ -140,7 +142,7 @@ func TestDelUser(t *testing.T) {
 
 func TestResetUserPassword(t *testing.T) {
 	mock := &mocks.Store{}
-	s := NewService(store.Store(mock))
+	s := NewService(store.Store(mock), storecache.NewNullCache())
 
 	ctx := context.TODO()
 
@@ -236,7 +238,7 @@ func TestNamespaceCreate(t *testing.T) {
 	mockClock.On("Now").Return(now)
 
 	mock := &mocks.Store{}
-	s := NewService(store.Store(mock))
+	s := NewService(store.Store(mock), storecache.NewNullCache())
 
 	ctx := context.TODO()
 
@@ -343,7 +345,7 @@ func TestNamespaceCreate(t *testing.T) {
 
 func TestAddUserNamespace(t *testing.T) {
 	mock := &mocks.Store{}
-	s := NewService(store.Store(mock))
+	s := NewService(store.Store(mock), storecache.NewNullCache())
 
 	ctx := context.TODO()
 
@@ -447,7 +449,7 @@ func TestAddUserNamespace(t *testing.T) {
 
 func TestDelUserNamespace(t *testing.T) {
 	mock := &mocks.Store{}
-	s := NewService(store.Store(mock))
+	s := NewService(store.Store(mock), storecache.NewNullCache())
 
 	ctx := context.TODO()
 
@@ -555,9 +557,125 @@ func TestDelUserNamespace(t *testing.T) {
 	mock.AssertExpectations(t)
 }
 
+func TestTransferNamespace(t *testing.T) {
+	mockClock := &clockmock.Clock{}
+
+	clock.DefaultBackend = mockClock
+
+	now := time.Now()
+
+	mockClock.On("Now").Return(now)
+
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), storecache.NewNullCache())
+
+	ctx := context.Background()
+
+	Err := errors.New("error")
+
+	owner := &models.User{ID: "ownerID", UserData: models.UserData{Username: "owner"}}
+	member := &models.User{ID: "memberID", UserData: models.UserData{Username: "member"}}
+	user := &models.User{ID: "userID", UserData: models.UserData{Username: "user"}}
+
+	namespace := &models.Namespace{
+		Name:     "namespace",
+		Owner:    owner.ID,
+		TenantID: "tenantID",
+		Members:  []models.Member{{ID: owner.ID, Role: guard.RoleOwner}, {ID: member.ID, Role: guard.RoleObserver}},
+	}
+	transferred := &models.Namespace{
+		Name:      "namespace",
+		Owner:     member.ID,
+		TenantID:  "tenantID",
+		Members:   []models.Member{{ID: owner.ID, Role: guard.RoleAdministrator}, {ID: member.ID, Role: guard.RoleOwner}},
+		Transfers: []models.OwnershipTransfer{{From: owner.ID, To: member.ID, TransferredAt: now}},
+	}
+	transfer := &models.OwnershipTransfer{From: owner.ID, To: member.ID, TransferredAt: now}
+
+	type Expected struct {
+		namespace *models.Namespace
+		err       error
+	}
+
+	tests := []struct {
+		description   string
+		username      string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "Fails to find the user",
+			username:    member.Username,
+			requiredMocks: func() {
+				mock.On("UserGetByUsername", ctx, member.Username).Return(nil, Err).Once()
+			},
+			expected: Expected{nil, ErrUserNotFound},
+		},
+		{
+			description: "Fails to find the namespace",
+			username:    member.Username,
+			requiredMocks: func() {
+				mock.On("UserGetByUsername", ctx, member.Username).Return(member, nil).Once()
+				mock.On("NamespaceGetByName", ctx, namespace.Name).Return(nil, Err).Once()
+			},
+			expected: Expected{nil, ErrNamespaceNotFound},
+		},
+		{
+			description: "Fails when the user is not a member",
+			username:    user.Username,
+			requiredMocks: func() {
+				mock.On("UserGetByUsername", ctx, user.Username).Return(user, nil).Once()
+				mock.On("NamespaceGetByName", ctx, namespace.Name).Return(namespace, nil).Once()
+			},
+			expected: Expected{nil, ErrNamespaceMemberNotFound},
+		},
+		{
+			description: "Fails when the user is the owner",
+			username:    owner.Username,
+			requiredMocks: func() {
+				mock.On("UserGetByUsername", ctx, owner.Username).Return(owner, nil).Once()
+				mock.On("NamespaceGetByName", ctx, namespace.Name).Return(namespace, nil).Once()
+			},
+			expected: Expected{nil, ErrNamespaceAlreadyOwner},
+		},
+		{
+			description: "Fails to transfer the namespace",
+			username:    member.Username,
+			requiredMocks: func() {
+				mock.On("UserGetByUsername", ctx, member.Username).Return(member, nil).Once()
+				mock.On("NamespaceGetByName", ctx, namespace.Name).Return(namespace, nil).Once()
+				mock.On("NamespaceTransferOwnership", ctx, namespace.TenantID, transfer).Return(Err).Once()
+			},
+			expected: Expected{nil, ErrFailedNamespaceTransfer},
+		},
+		{
+			description: "Successfully transfer the namespace",
+			username:    member.Username,
+			requiredMocks: func() {
+				mock.On("UserGetByUsername", ctx, member.Username).Return(member, nil).Once()
+				mock.On("NamespaceGetByName", ctx, namespace.Name).Return(namespace, nil).Once()
+				mock.On("NamespaceTransferOwnership", ctx, namespace.TenantID, transfer).Return(nil).Once()
+				mock.On("NamespaceGetByName", ctx, namespace.Name).Return(transferred, nil).Once()
+			},
+			expected: Expected{transferred, nil},
+		},
+	}
+
+	for _, ts := range tests {
+		test := ts
+		t.Run(test.description, func(t *testing.T) {
+			test.requiredMocks()
+			ns, err := s.NamespaceTransfer(namespace.Name, test.username)
+			assert.Equal(t, test.expected, Expected{ns, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
 func TestDelNamespace(t *testing.T) {
 	mock := &mocks.Store{}
-	s := NewService(store.Store(mock))
+	s := NewService(store.Store(mock), storecache.NewNullCache())
 
 	ctx := context.TODO()
 
@@ -636,3 +754,61 @@ func TestDelNamespace(t *testing.T) {
 
 	mock.AssertExpectations(t)
 }
+
+func TestUnlockUser(t *testing.T) {
+	mock := &mocks.Store{}
+	cache := &cachemocks.Cache{}
+	s := NewService(store.Store(mock), cache)
+
+	ctx := context.Background()
+
+	Err := errors.New("error")
+	user := &models.User{ID: "userID", UserData: models.UserData{Username: "username"}}
+
+	tests := []struct {
+		description   string
+		username      string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "Fails to find the user",
+			username:    "username",
+			requiredMocks: func() {
+				mock.On("UserGetByUsername", ctx, "username").Return(nil, Err).Once()
+			},
+			expected: ErrUserNotFound,
+		},
+		{
+			description: "Fails to clear the failed logins",
+			username:    "Username",
+			requiredMocks: func() {
+				mock.On("UserGetByUsername", ctx, "username").Return(user, nil).Once()
+				cache.On("Delete", ctx, "login_lockout/username/username/failures").Return(Err).Once()
+			},
+			expected: ErrFailedUnlockUser,
+		},
+		{
+			description: "Successfully unlock the user",
+			username:    "username",
+			requiredMocks: func() {
+				mock.On("UserGetByUsername", ctx, "username").Return(user, nil).Once()
+				cache.On("Delete", ctx, "login_lockout/username/username/failures").Return(nil).Once()
+				cache.On("Delete", ctx, "login_lockout/username/username").Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, ts := range tests {
+		test := ts
+		t.Run(test.description, func(t *testing.T) {
+			test.requiredMocks()
+			err := s.UserUnlock(test.username)
+			assert.Equal(t, test.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+	cache.AssertExpectations(t)
+}
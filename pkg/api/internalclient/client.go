@@ -22,6 +22,8 @@ var (
 	ErrConnectionFailed = errors.New("connection failed")
 	ErrNotFound         = errors.New("not found")
 	ErrUnknown          = errors.New("unknown error")
+	ErrForbidden        = errors.New("forbidden")
+	ErrTooManyRequests  = errors.New("too many requests")
 )
 
 type Opt func(*client) error
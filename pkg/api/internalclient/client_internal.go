@@ -27,11 +27,16 @@ type internalAPI interface {
 	EvaluateKey(fingerprint string, dev *models.Device, username string) (bool, error)
 	DevicesOffline(id string) error
 	DevicesHeartbeat(id string) error
-	FirewallEvaluate(lookup map[string]string) error
+	FirewallEvaluate(lookup map[string]string) (*models.FirewallDecision, error)
+	CheckSSHAttempt(ip, device string) error
+	EvaluateSessionType(device, kind string) error
+	RecordSSHAttempt(attempt *models.SSHAttempt) error
 	PatchSessions(uid string) []error
 	FinishSession(uid string) []error
 	KeepAliveSession(uid string) []error
 	RecordSession(session *models.SessionRecorded, recordURL string)
+	CreateSessionApproval(approval *models.SessionApproval) error
+	GetSessionApproval(uid string) (*models.SessionApproval, error)
 	BillingEvaluate(tenantID string) (*models.Namespace, int, error)
 	Lookup(lookup map[string]string) (string, []error)
 	DeviceLookup(lookup map[string]string) (*models.Device, []error)
@@ -145,16 +150,81 @@ func (c *client) DevicesHeartbeat(id string) error {
 	return nil
 }
 
-func (c *client) FirewallEvaluate(lookup map[string]string) error {
+func (c *client) FirewallEvaluate(lookup map[string]string) (*models.FirewallDecision, error) {
+	var decision *models.FirewallDecision
 	resp, err := c.http.R().
 		SetQueryParams(lookup).
-		Get("http://cloud-api:8080/internal/firewall/rules/evaluate")
+		SetResult(&decision).
+		SetError(&decision).
+		Get(buildURL(c, "/internal/firewall/rules/evaluate"))
 	if err != nil {
-		return fmt.Errorf("failed to make the request to evaluate the firewall: %v with error %v", lookup, err)
+		return nil, fmt.Errorf("failed to make the request to evaluate the firewall: %v with error %v", lookup, err)
+	}
+
+	switch resp.StatusCode() {
+	case http.StatusOK, http.StatusForbidden:
+		return decision, nil
+	default:
+		return nil, errors.New("failed to evaluate the firewall")
+	}
+}
+
+// CheckSSHAttempt checks if an SSH attempt from a source IP address to a device is allowed, returning
+// ErrTooManyRequests when it is blocked. An empty device checks only the source IP address.
+func (c *client) CheckSSHAttempt(ip, device string) error {
+	resp, err := c.http.R().
+		SetQueryParams(map[string]string{
+			"ip_address": ip,
+			"device":     device,
+		}).
+		Get(buildURL(c, "/internal/ssh/attempts"))
+	if err != nil {
+		return err
+	}
+
+	switch resp.StatusCode() {
+	case http.StatusOK:
+		return nil
+	case http.StatusTooManyRequests:
+		return ErrTooManyRequests
+	default:
+		return errors.New("failed to check the ssh attempt")
+	}
+}
+
+// EvaluateSessionType checks if a session type, or a forwarding, is enabled on the namespace of a device, returning
+// ErrForbidden when the namespace disables it.
+func (c *client) EvaluateSessionType(device, kind string) error {
+	resp, err := c.http.R().
+		SetQueryParams(map[string]string{
+			"device": device,
+			"type":   kind,
+		}).
+		Get(buildURL(c, "/internal/ssh/session-types"))
+	if err != nil {
+		return err
+	}
+
+	switch resp.StatusCode() {
+	case http.StatusOK:
+		return nil
+	case http.StatusForbidden:
+		return ErrForbidden
+	default:
+		return errors.New("failed to evaluate the session type")
+	}
+}
+
+func (c *client) RecordSSHAttempt(attempt *models.SSHAttempt) error {
+	resp, err := c.http.R().
+		SetBody(attempt).
+		Post(buildURL(c, "/internal/ssh/attempts"))
+	if err != nil {
+		return err
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return errors.New("a firewall rule prohibit this connection")
+		return errors.New("failed to record the ssh attempt")
 	}
 
 	return nil
@@ -202,6 +272,41 @@ func (c *client) RecordSession(session *models.SessionRecorded, recordURL string
 		Post(fmt.Sprintf("http://"+recordURL+"/internal/sessions/%s/record", session.UID))
 }
 
+func (c *client) CreateSessionApproval(approval *models.SessionApproval) error {
+	resp, err := c.http.R().
+		SetBody(approval).
+		Post(buildURL(c, fmt.Sprintf("/internal/sessions/%s/approval", approval.UID)))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return errors.New("failed to create the session approval")
+	}
+
+	return nil
+}
+
+func (c *client) GetSessionApproval(uid string) (*models.SessionApproval, error) {
+	var approval *models.SessionApproval
+	resp, err := c.http.R().
+		SetResult(&approval).
+		Get(buildURL(c, fmt.Sprintf("/internal/sessions/%s/approval", uid)))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode() == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, errors.New("failed to get the session approval")
+	}
+
+	return approval, nil
+}
+
 func (c *client) Lookup(lookup map[string]string) (string, []error) {
 	var device struct {
 		UID string `json:"uid"`
@@ -212,6 +317,10 @@ func (c *client) Lookup(lookup map[string]string) (string, []error) {
 		SetResult(&device).
 		Get(buildURL(c, "/internal/lookup"))
 
+	if resp.StatusCode() == http.StatusForbidden {
+		return "", []error{ErrForbidden}
+	}
+
 	if resp.StatusCode() != http.StatusOK {
 		return "", []error{errors.New("lookup failed")}
 	}
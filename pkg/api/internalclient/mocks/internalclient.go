@@ -42,6 +42,20 @@ func (_m *Client) BillingEvaluate(tenantID string) (*models.Namespace, int, erro
 	return r0, r1, r2
 }
 
+// CheckSSHAttempt provides a mock function with given fields: ip, device
+func (_m *Client) CheckSSHAttempt(ip string, device string) error {
+	ret := _m.Called(ip, device)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(ip, device)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreatePrivateKey provides a mock function with given fields:
 func (_m *Client) CreatePrivateKey() (*models.PrivateKey, error) {
 	ret := _m.Called()
@@ -65,6 +79,20 @@ func (_m *Client) CreatePrivateKey() (*models.PrivateKey, error) {
 	return r0, r1
 }
 
+// CreateSessionApproval provides a mock function with given fields: approval
+func (_m *Client) CreateSessionApproval(approval *models.SessionApproval) error {
+	ret := _m.Called(approval)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.SessionApproval) error); ok {
+		r0 = rf(approval)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeviceLookup provides a mock function with given fields: lookup
 func (_m *Client) DeviceLookup(lookup map[string]string) (*models.Device, []error) {
 	ret := _m.Called(lookup)
@@ -139,6 +167,20 @@ func (_m *Client) EvaluateKey(fingerprint string, dev *models.Device, username s
 	return r0, r1
 }
 
+// EvaluateSessionType provides a mock function with given fields: device, kind
+func (_m *Client) EvaluateSessionType(device string, kind string) error {
+	ret := _m.Called(device, kind)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(device, kind)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // FinishSession provides a mock function with given fields: uid
 func (_m *Client) FinishSession(uid string) []error {
 	ret := _m.Called(uid)
@@ -156,17 +198,26 @@ func (_m *Client) FinishSession(uid string) []error {
 }
 
 // FirewallEvaluate provides a mock function with given fields: lookup
-func (_m *Client) FirewallEvaluate(lookup map[string]string) error {
+func (_m *Client) FirewallEvaluate(lookup map[string]string) (*models.FirewallDecision, error) {
 	ret := _m.Called(lookup)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(map[string]string) error); ok {
+	var r0 *models.FirewallDecision
+	if rf, ok := ret.Get(0).(func(map[string]string) *models.FirewallDecision); ok {
 		r0 = rf(lookup)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.FirewallDecision)
+		}
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(map[string]string) error); ok {
+		r1 = rf(lookup)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // GetDevice provides a mock function with given fields: uid
@@ -215,6 +266,29 @@ func (_m *Client) GetPublicKey(fingerprint string, tenant string) (*models.Publi
 	return r0, r1
 }
 
+// GetSessionApproval provides a mock function with given fields: uid
+func (_m *Client) GetSessionApproval(uid string) (*models.SessionApproval, error) {
+	ret := _m.Called(uid)
+
+	var r0 *models.SessionApproval
+	if rf, ok := ret.Get(0).(func(string) *models.SessionApproval); ok {
+		r0 = rf(uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SessionApproval)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // KeepAliveSession provides a mock function with given fields: uid
 func (_m *Client) KeepAliveSession(uid string) []error {
 	ret := _m.Called(uid)
@@ -298,6 +372,20 @@ func (_m *Client) PatchSessions(uid string) []error {
 	return r0
 }
 
+// RecordSSHAttempt provides a mock function with given fields: attempt
+func (_m *Client) RecordSSHAttempt(attempt *models.SSHAttempt) error {
+	ret := _m.Called(attempt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.SSHAttempt) error); ok {
+		r0 = rf(attempt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RecordSession provides a mock function with given fields: session, recordURL
 func (_m *Client) RecordSession(session *models.SessionRecorded, recordURL string) {
 	_m.Called(session, recordURL)
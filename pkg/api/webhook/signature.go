@@ -0,0 +1,79 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/clock"
+)
+
+// DefaultTolerance is the maximum difference between the timestamp of a webhook request and the time it is verified.
+const DefaultTolerance = 5 * time.Minute
+
+var (
+	ErrSignatureMissing = errors.New("webhook signature missing")
+	ErrSignatureInvalid = errors.New("webhook signature invalid")
+	ErrTimestampInvalid = errors.New("webhook timestamp invalid")
+	ErrTimestampExpired = errors.New("webhook timestamp outside of the tolerance")
+)
+
+// Sign returns the signature of a webhook request, the hex encoded HMAC-SHA256, keyed by the webhook secret, of the
+// Unix timestamp sent on the WebhookTimestampHeader, a dot and the exact request body.
+//
+// As the timestamp is signed with the body, a receiver rejecting old timestamps also rejects the replayed requests.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10))) // nolint:errcheck
+	mac.Write([]byte("."))                              // nolint:errcheck
+	mac.Write(body)                                     // nolint:errcheck
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature of the body of a webhook request sent with the header, rejecting it when its timestamp
+// differs from now by more than the tolerance.
+func Verify(secret string, header http.Header, body []byte, tolerance time.Duration) error {
+	signature := header.Get(WebhookSignatureHeader)
+	if signature == "" || header.Get(WebhookTimestampHeader) == "" {
+		return ErrSignatureMissing
+	}
+
+	timestamp, err := strconv.ParseInt(header.Get(WebhookTimestampHeader), 10, 64)
+	if err != nil {
+		return ErrTimestampInvalid
+	}
+
+	if diff := clock.Now().Sub(time.Unix(timestamp, 0)); diff > tolerance || diff < -tolerance {
+		return ErrTimestampExpired
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(Sign(secret, timestamp, body))) {
+		return ErrSignatureInvalid
+	}
+
+	return nil
+}
+
+// VerifyRequest reads the body of a webhook request and checks it with Verify, returning the body. The request body
+// can still be read after it.
+func VerifyRequest(req *http.Request, secret string, tolerance time.Duration) ([]byte, error) {
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	if err := Verify(secret, req.Header, body, tolerance); err != nil {
+		return nil, err
+	}
+
+	return body, nil
+}
@@ -0,0 +1,110 @@
+package webhook
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	clockmocks "github.com/shellhub-io/shellhub/pkg/clock/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerify(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+
+	clockMock := &clockmocks.Clock{}
+	clock.DefaultBackend = clockMock
+	clockMock.On("Now").Return(now)
+
+	body := []byte(`{"username":"user"}`)
+
+	header := func(timestamp string, signature string) http.Header {
+		header := http.Header{}
+		header.Set(WebhookTimestampHeader, timestamp)
+		header.Set(WebhookSignatureHeader, signature)
+
+		return header
+	}
+
+	// signed returns the headers of a request sent at a time and signed with a secret.
+	signed := func(t time.Time, secret string) http.Header {
+		return header(strconv.FormatInt(t.Unix(), 10), Sign(secret, t.Unix(), body))
+	}
+
+	cases := []struct {
+		description string
+		header      http.Header
+		body        []byte
+		expected    error
+	}{
+		{
+			description: "fails when the signature is missing",
+			header:      http.Header{},
+			body:        body,
+			expected:    ErrSignatureMissing,
+		},
+		{
+			description: "fails when the timestamp is invalid",
+			header:      header("now", "signature"),
+			body:        body,
+			expected:    ErrTimestampInvalid,
+		},
+		{
+			description: "fails when the request is older than the tolerance",
+			header:      signed(now.Add(-DefaultTolerance-time.Second), "secret"),
+			body:        body,
+			expected:    ErrTimestampExpired,
+		},
+		{
+			description: "fails when the body was changed",
+			header:      signed(now, "secret"),
+			body:        []byte(`{"username":"root"}`),
+			expected:    ErrSignatureInvalid,
+		},
+		{
+			description: "fails when the timestamp was changed",
+			header:      header(strconv.FormatInt(now.Unix(), 10), Sign("secret", now.Add(-time.Second).Unix(), body)),
+			body:        body,
+			expected:    ErrSignatureInvalid,
+		},
+		{
+			description: "fails when the secret is wrong",
+			header:      signed(now, "other"),
+			body:        body,
+			expected:    ErrSignatureInvalid,
+		},
+		{
+			description: "succeeds",
+			header:      signed(now.Add(-time.Minute), "secret"),
+			body:        body,
+			expected:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Verify("secret", tc.header, tc.body, DefaultTolerance))
+		})
+	}
+}
+
+func TestVerifyRequest(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+
+	clockMock := &clockmocks.Clock{}
+	clock.DefaultBackend = clockMock
+	clockMock.On("Now").Return(now)
+
+	body := `{"username":"user"}`
+
+	req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set(WebhookTimestampHeader, strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set(WebhookSignatureHeader, Sign("secret", now.Unix(), []byte(body)))
+
+	verified, err := VerifyRequest(req, "secret", DefaultTolerance)
+	assert.NoError(t, err)
+	assert.Equal(t, body, string(verified))
+}
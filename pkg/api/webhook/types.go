@@ -6,8 +6,10 @@ const (
 	WebhookIDHeader = "X-SHELLHUB-WEBHOOK-ID"
 	// Name of the event that has been triggered.
 	WebhookEventHeader = "X-SHELLHUB-WEBHOOK-EVENT"
-	// A signature created using the webhook secret key.
+	// A signature of the timestamp and of the body created using the webhook secret key.
 	WebhookSignatureHeader = "X-SHELLHUB-WEBHOOK-SIGNATURE"
+	// Unix time when the webhook was sent.
+	WebhookTimestampHeader = "X-SHELLHUB-WEBHOOK-TIMESTAMP"
 )
 
 // Webhook event types.
@@ -16,16 +18,36 @@ const (
 	WebhookIncomingConnectionEvent = "incoming_connection"
 )
 
+// Decisions of the webhook about an incoming connection.
+const (
+	// The connection is allowed. It is the decision when the response does not set one.
+	WebhookDecisionAllow = "allow"
+	// The connection is rejected, showing the message of the response to the user, if any.
+	WebhookDecisionDeny = "deny"
+	// The connection is held until a member of the namespace approves it through the API.
+	WebhookDecisionApproval = "approval"
+)
+
 // IncomingConnectionWebhookRequest is the body payload.
 type IncomingConnectionWebhookRequest struct {
 	Username  string `json:"username"`
 	Hostname  string `json:"hostname"`
 	Namespace string `json:"namespace"`
 	SourceIP  string `json:"source_ip"`
+	// UID of the session, used to approve it when the webhook requires an approval.
+	Session string `json:"session"`
 }
 
 // IncommingConnectionWebhookResponse is the expected response body.
 type IncomingConnectionWebhookResponse struct {
 	// Timeout to wait for connection to be established
 	Timeout int `json:"timeout"`
+	// Decision about the connection, being WebhookDecisionAllow when empty.
+	Decision string `json:"decision"`
+	// Message shown to the user when the connection is denied or waits for an approval.
+	Message string `json:"message"`
+	// Username replaces the username used to log in on the device, if set.
+	Username string `json:"username"`
+	// ApprovalTimeout is the number of seconds to wait for the approval before the connection is closed.
+	ApprovalTimeout int `json:"approval_timeout"`
 }
@@ -1,18 +1,18 @@
 package webhook
 
 import (
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"path"
+	"strconv"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/kelseyhightower/envconfig"
 	client "github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/clock"
 	"github.com/shellhub-io/shellhub/pkg/uuid"
 	"github.com/sirupsen/logrus"
 )
@@ -31,6 +31,8 @@ type Options struct {
 	WebhookURL    string `envconfig:"webhook_url"`
 	WebhookPort   int    `envconfig:"webhook_port"`
 	WebhookScheme string `envconfig:"webhook_scheme"`
+	// Key used to sign the requests to the webhook.
+	WebhookSecret string `envconfig:"webhook_secret"`
 }
 
 func NewClient() Webhook {
@@ -42,10 +44,16 @@ func NewClient() Webhook {
 		return nil
 	}
 
+	// No webhook is configured.
+	if opts.WebhookURL == "" {
+		return nil
+	}
+
 	w := &webhookClient{
 		host:   opts.WebhookURL,
 		port:   opts.WebhookPort,
 		scheme: opts.WebhookScheme,
+		secret: opts.WebhookSecret,
 		http:   httpClient,
 	}
 
@@ -60,6 +68,7 @@ type webhookClient struct {
 	scheme string
 	host   string
 	port   int
+	secret string
 	http   *resty.Client
 	logger *logrus.Logger
 }
@@ -70,23 +79,26 @@ func (w *webhookClient) Connect(m map[string]string) (*IncomingConnectionWebhook
 		Hostname:  m["name"],
 		Namespace: m["domain"],
 		SourceIP:  m["ip_address"],
+		Session:   m["session"],
 	}
-	secret := "secret"
-	uuid := uuid.Generate()
-	mac := hmac.New(sha256.New, []byte(secret))
-	if _, err := mac.Write([]byte(fmt.Sprintf("%v", payload))); err != nil {
+
+	body, err := json.Marshal(payload)
+	if err != nil {
 		return nil, err
 	}
-	signature := mac.Sum(nil)
+
+	timestamp := clock.Now().Unix()
 
 	var res *IncomingConnectionWebhookResponse
 	resp, err := w.http.R().
 		SetHeaders(map[string]string{
-			WebhookIDHeader:        uuid,
+			"Content-Type":         "application/json",
+			WebhookIDHeader:        uuid.Generate(),
 			WebhookEventHeader:     WebhookIncomingConnectionEvent,
-			WebhookSignatureHeader: hex.EncodeToString(signature),
+			WebhookTimestampHeader: strconv.FormatInt(timestamp, 10),
+			WebhookSignatureHeader: Sign(w.secret, timestamp, body),
 		}).
-		SetBody(payload).
+		SetBody(body).
 		SetResult(&res).
 		Post(buildURL(w, "/"))
 	if err != nil {
@@ -0,0 +1,89 @@
+package models
+
+import (
+	"time"
+)
+
+// Actions recorded on the audit log, named by the kind of the target and the action over it.
+const (
+	AuditDeviceAccept   = "device.accept"
+	AuditDeviceReject   = "device.reject"
+	AuditDevicePending  = "device.pending"
+	AuditDeviceRemove   = "device.remove"
+	AuditDeviceRename   = "device.rename"
+	AuditDeviceTransfer = "device.transfer"
+	AuditDeviceTags     = "device.tags"
+
+	AuditDeviceGroupCreate = "device_group.create"
+	AuditDeviceGroupUpdate = "device_group.update"
+	AuditDeviceGroupRemove = "device_group.remove"
+
+	AuditTagRename = "tag.rename"
+	AuditTagRemove = "tag.remove"
+
+	AuditFirewallCreate = "firewall.create"
+	AuditFirewallUpdate = "firewall.update"
+	AuditFirewallRemove = "firewall.remove"
+
+	AuditIPSetCreate = "ip_set.create"
+	AuditIPSetUpdate = "ip_set.update"
+	AuditIPSetRemove = "ip_set.remove"
+
+	AuditPublicKeyCreate = "public_key.create"
+	AuditPublicKeyUpdate = "public_key.update"
+	AuditPublicKeyRemove = "public_key.remove"
+
+	AuditSessionApprovalDecide = "session_approval.decide"
+
+	AuditNamespaceCreate        = "namespace.create"
+	AuditNamespaceRename        = "namespace.rename"
+	AuditNamespaceRemove        = "namespace.remove"
+	AuditNamespaceSessionRecord = "namespace.session_record"
+	AuditNamespaceDeviceNaming  = "namespace.device_naming"
+	AuditNamespaceGeoAccess     = "namespace.geo_access"
+	AuditNamespaceSessionTypes  = "namespace.session_types"
+	AuditNamespacePolicy        = "namespace.policy"
+	AuditNamespaceTransfer      = "namespace.transfer"
+
+	AuditMemberAdd    = "member.add"
+	AuditMemberRemove = "member.remove"
+	AuditMemberRole   = "member.role"
+	AuditMemberScope  = "member.scope"
+
+	AuditInvitationCreate = "invitation.create"
+	AuditInvitationRemove = "invitation.remove"
+
+	AuditRoleCreate = "role.create"
+	AuditRoleUpdate = "role.update"
+	AuditRoleRemove = "role.remove"
+
+	AuditWebhookCreate = "webhook.create"
+	AuditWebhookUpdate = "webhook.update"
+	AuditWebhookRemove = "webhook.remove"
+)
+
+// AuditLog is a record of a mutating action over a resource of a namespace. The records are never changed, being
+// only removed after the retention time.
+type AuditLog struct {
+	ID       string `json:"id" bson:"_id,omitempty"`
+	TenantID string `json:"tenant_id" bson:"tenant_id"`
+	// Actor is the ID of the user who acted. It is empty when the action was not requested by a user.
+	Actor  string `json:"actor" bson:"actor"`
+	Action string `json:"action" bson:"action"`
+	// Target identifies the resource acted over, as a device's UID or a member's ID.
+	Target string `json:"target" bson:"target"`
+	// Before and After are the resource, as returned by the API, before and after the action. Before is empty when
+	// the resource was created and After is empty when it was removed.
+	Before    map[string]interface{} `json:"before,omitempty" bson:"before,omitempty"`
+	After     map[string]interface{} `json:"after,omitempty" bson:"after,omitempty"`
+	CreatedAt time.Time              `json:"created_at" bson:"created_at"`
+}
+
+// AuditLogFilter selects the audit logs of a namespace. The empty fields select all the audit logs.
+type AuditLogFilter struct {
+	Actor  string    `query:"actor"`
+	Action string    `query:"action"`
+	Target string    `query:"target"`
+	Since  time.Time `query:"since"`
+	Until  time.Time `query:"until"`
+}
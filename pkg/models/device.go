@@ -17,11 +17,14 @@ type Device struct {
 	LastSeen   time.Time       `json:"last_seen" bson:"last_seen"`
 	Online     bool            `json:"online" bson:",omitempty"`
 	Namespace  string          `json:"namespace" bson:",omitempty"`
-	Status     string          `json:"status" bson:"status,omitempty" validate:"oneof=accepted rejected pending unused"`
+	Status     string          `json:"status" bson:"status,omitempty" validate:"oneof=accepted rejected pending unused decommissioned"`
 	CreatedAt  time.Time       `json:"created_at" bson:"created_at,omitempty"`
 	RemoteAddr string          `json:"remote_addr" bson:"remote_addr"`
 	Position   *DevicePosition `json:"position" bson:"position"`
 	Tags       []string        `json:"tags" bson:"tags,omitempty"`
+	// DecommissionedAt is the time when the device was decommissioned. A decommissioned device is kept for audit
+	// until it is purged after the retention window.
+	DecommissionedAt *time.Time `json:"decommissioned_at,omitempty" bson:"decommissioned_at,omitempty"`
 }
 
 type DeviceAuthClaims struct {
@@ -49,6 +52,9 @@ type DeviceAuthResponse struct {
 	Token     string `json:"token"`
 	Name      string `json:"name"`
 	Namespace string `json:"namespace"`
+	// TenantID is the tenant where the device is registered. It differs from the requested one when the device was
+	// transferred to another namespace, and the agent must use it from now on.
+	TenantID string `json:"tenant_id"`
 }
 
 type DeviceIdentity struct {
@@ -74,3 +80,33 @@ type DevicePosition struct {
 	Latitude  float64 `json:"latitude" bson:"latitude"`
 	Longitude float64 `json:"longitude" bson:"longitude"`
 }
+
+// DeviceConnectionEvent records a change on the connection state of a device to the SSH server.
+type DeviceConnectionEvent struct {
+	UID       string    `json:"uid"`
+	TenantID  string    `json:"tenant_id" bson:"tenant_id"`
+	Online    bool      `json:"online" bson:"online"`
+	Timestamp time.Time `json:"timestamp" bson:"timestamp"`
+}
+
+// DeviceAvailability is the availability of a device in a period, computed from its connection events.
+type DeviceAvailability struct {
+	UID            string    `json:"uid"`
+	From           time.Time `json:"from"`
+	To             time.Time `json:"to"`
+	Uptime         float64   `json:"uptime"`
+	OnlineSeconds  int64     `json:"online_seconds"`
+	Disconnections int       `json:"disconnections"`
+}
+
+// DeviceTransfer records that a device was transferred from a namespace to another one.
+//
+// As the device's UID is derived from its tenant, the record maps the UID used by the agent before the transfer to
+// the tenant where the device is now registered.
+type DeviceTransfer struct {
+	UID       string    `json:"uid"`
+	NewUID    string    `json:"new_uid" bson:"new_uid"`
+	From      string    `json:"from"`
+	TenantID  string    `json:"tenant_id" bson:"tenant_id"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+}
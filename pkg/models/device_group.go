@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// DeviceGroupFields contains the fields of a DeviceGroup that can be set by the user.
+//
+// A DeviceGroup can have either a static list of Devices or a Filter expression, never both. When Filter is used, its
+// members are the devices of the namespace matched by the filter at the moment it is evaluated.
+type DeviceGroupFields struct {
+	Name        string   `json:"name" validate:"required,min=3,max=255,excludes=/@&:"`
+	Description string   `json:"description,omitempty" bson:"description,omitempty"`
+	Devices     []string `json:"devices,omitempty" bson:"devices,omitempty" validate:"required_without=Filter,excluded_with=Filter,unique"`
+	Filter      []Filter `json:"filter,omitempty" bson:"filter,omitempty" validate:"required_without=Devices,excluded_with=Devices"`
+}
+
+func (g *DeviceGroupFields) Validate() error {
+	return validator.New().Struct(g)
+}
+
+type DeviceGroup struct {
+	ID                string    `json:"id,omitempty" bson:"_id,omitempty"`
+	TenantID          string    `json:"tenant_id" bson:"tenant_id"`
+	CreatedAt         time.Time `json:"created_at" bson:"created_at"`
+	DeviceGroupFields `bson:",inline"`
+}
+
+type DeviceGroupUpdate struct {
+	DeviceGroupFields `bson:",inline"`
+}
+
+// DeviceGroupBulkResult is the result of an operation applied to each device of a DeviceGroup.
+type DeviceGroupBulkResult struct {
+	// Succeeded contains the UIDs of devices where the operation was applied.
+	Succeeded []string `json:"succeeded"`
+	// Failed maps the UIDs of devices where the operation has failed to the failure's reason.
+	Failed map[string]string `json:"failed"`
+}
@@ -1,26 +1,48 @@
 package models
 
 import (
+	"errors"
+	"net"
 	"regexp"
+	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 )
 
 // FirewallFilter contains the filter rule of a Public Key.
 //
-// A FirewallFilter can contain either Hostname, string, or Tags, slice of strings never both.
+// A FirewallFilter can contain either Hostname, string, Tags, slice of strings, or Group, the ID of a DeviceGroup, never
+// more than one.
 type FirewallFilter struct {
-	Hostname string   `json:"hostname,omitempty" bson:"hostname,omitempty" validate:"required_without=Tags,excluded_with=Tags,regexp"`
-	Tags     []string `json:"tags,omitempty" bson:"tags,omitempty" validate:"required_without=Hostname,excluded_with=Hostname,max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	Hostname string   `json:"hostname,omitempty" bson:"hostname,omitempty" validate:"required_without_all=Tags Group,excluded_with=Tags Group,regexp"`
+	Tags     []string `json:"tags,omitempty" bson:"tags,omitempty" validate:"required_without_all=Hostname Group,excluded_with=Hostname Group,max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	Group    string   `json:"group,omitempty" bson:"group,omitempty" validate:"required_without_all=Hostname Tags,excluded_with=Hostname Tags"`
 }
 
 type FirewallRuleFields struct {
-	Priority int            `json:"priority"`
-	Action   string         `json:"action" validate:"required,oneof=allow deny"`
-	Active   bool           `json:"active"`
-	SourceIP string         `json:"source_ip" bson:"source_ip" validate:"required,regexp"`
-	Username string         `json:"username" validate:"required,regexp"`
-	Filter   FirewallFilter `json:"filter" bson:"filter" validate:"required"`
+	Priority int    `json:"priority"`
+	Action   string `json:"action" validate:"required,oneof=allow deny"`
+	Active   bool   `json:"active"`
+	// SourceIP is a list of IP addresses, IPv4 or IPv6 networks in CIDR notation and names of IP sets prefixed with
+	// "@", separated by commas or spaces, where "*" matches any address. An entry prefixed with "!" excludes the
+	// addresses it matches, e.g. "10.20.0.0/16, !10.20.5.0/24".
+	SourceIP string `json:"source_ip" bson:"source_ip" validate:"required,source_ip"`
+	// SourceIPRegexp is a regular expression the source IP must also match. It keeps working the rules created when
+	// the source IP was a regular expression.
+	SourceIPRegexp string         `json:"source_ip_regexp,omitempty" bson:"source_ip_regexp,omitempty" validate:"omitempty,regexp"`
+	Username       string         `json:"username" validate:"required,regexp"`
+	Filter         FirewallFilter `json:"filter" bson:"filter" validate:"required"`
+	// Schedule restricts when the rule is applied. A rule without a schedule is applied whenever it is active.
+	Schedule *FirewallSchedule `json:"schedule,omitempty" bson:"schedule,omitempty"`
+	// SessionTypes restricts the rule to the sessions of these types. An empty list matches any type.
+	SessionTypes []string `json:"session_types,omitempty" bson:"session_types,omitempty" validate:"unique,dive,oneof=term exec scp web"`
+	// Commands restricts the rule to exec sessions whose command line matches one of these patterns, where "*"
+	// matches any sequence of characters, e.g. "systemctl status *". An empty list matches any command.
+	Commands []string `json:"commands,omitempty" bson:"commands,omitempty" validate:"unique,dive,required,max=255"`
+	// Countries restricts the rule to the connections from these countries, ISO 3166-1 alpha-2 codes, resolved from the
+	// source IP. An empty list matches any country.
+	Countries []string `json:"countries,omitempty" bson:"countries,omitempty" validate:"unique,dive,iso3166_1_alpha2"`
 }
 
 func (f *FirewallRuleFields) Validate() error {
@@ -32,9 +54,177 @@ func (f *FirewallRuleFields) Validate() error {
 		return err == nil
 	})
 
+	_ = v.RegisterValidation("source_ip", func(fl validator.FieldLevel) bool {
+		_, err := ParseFirewallSourceIP(fl.Field().String())
+
+		return err == nil
+	})
+
 	return v.Struct(f)
 }
 
+// FirewallSchedule defines when a firewall rule is applied.
+//
+// The rule is applied between Start and End, when they are set, on the Weekdays and inside one of the Ranges, both
+// evaluated in the schedule's Timezone. Empty Weekdays or Ranges do not restrict the rule.
+type FirewallSchedule struct {
+	Weekdays []string            `json:"weekdays,omitempty" bson:"weekdays,omitempty" validate:"unique,dive,oneof=sunday monday tuesday wednesday thursday friday saturday"`
+	Ranges   []FirewallTimeRange `json:"ranges,omitempty" bson:"ranges,omitempty" validate:"dive"`
+	// Timezone is the IANA time zone name used to evaluate the weekdays and the ranges. The default is UTC.
+	Timezone string     `json:"timezone,omitempty" bson:"timezone,omitempty" validate:"omitempty,timezone"`
+	Start    *time.Time `json:"start,omitempty" bson:"start,omitempty"`
+	End      *time.Time `json:"end,omitempty" bson:"end,omitempty" validate:"omitempty,gtfield=Start"`
+}
+
+// FirewallTimeRange is a range of the day, in the "15:04" format, from the time From, inclusive, to the time To,
+// exclusive. A range where To is before From crosses midnight, and its part after midnight belongs to the weekday when
+// the range started.
+type FirewallTimeRange struct {
+	From string `json:"from" bson:"from" validate:"required,datetime=15:04"`
+	To   string `json:"to" bson:"to" validate:"required,datetime=15:04,nefield=From"`
+}
+
+// Active checks if the schedule applies its rule at the time t.
+func (s *FirewallSchedule) Active(t time.Time) bool {
+	if s.Start != nil && t.Before(*s.Start) {
+		return false
+	}
+
+	if s.End != nil && !t.Before(*s.End) {
+		return false
+	}
+
+	location := time.UTC
+	if s.Timezone != "" {
+		var err error
+		if location, err = time.LoadLocation(s.Timezone); err != nil {
+			return false
+		}
+	}
+
+	t = t.In(location)
+
+	if len(s.Ranges) == 0 {
+		return s.onWeekday(t.Weekday())
+	}
+
+	// As the ranges use a fixed format with leading zeros, they are compared as strings. The part after midnight of a
+	// range crossing it belongs to the range started on the previous day, so it is checked against that weekday.
+	minute := t.Format("15:04")
+	for _, r := range s.Ranges {
+		if r.From < r.To && minute >= r.From && minute < r.To && s.onWeekday(t.Weekday()) {
+			return true
+		}
+
+		if r.From > r.To && minute >= r.From && s.onWeekday(t.Weekday()) {
+			return true
+		}
+
+		if r.From > r.To && minute < r.To && s.onWeekday(t.AddDate(0, 0, -1).Weekday()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// onWeekday checks if the schedule applies on a weekday. A schedule without weekdays applies on every day.
+func (s *FirewallSchedule) onWeekday(weekday time.Weekday) bool {
+	if len(s.Weekdays) == 0 {
+		return true
+	}
+
+	name := strings.ToLower(weekday.String())
+	for _, day := range s.Weekdays {
+		if day == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ErrFirewallSourceIPInvalid is returned when a firewall rule's source IP cannot be parsed.
+var ErrFirewallSourceIPInvalid = errors.New("invalid firewall source ip")
+
+// FirewallSourceIP is an entry of a firewall rule's source IP.
+type FirewallSourceIP struct {
+	// Exclude indicates that the addresses matched by the entry are excluded from the rule.
+	Exclude bool
+	// Any indicates that the entry matches any address.
+	Any bool
+	// Network is the network matched by the entry, when it is an IP address or a CIDR.
+	Network *net.IPNet
+	// Set is the name of the IP set matched by the entry.
+	Set string
+}
+
+// ParseFirewallSourceIP parses the source IP of a firewall rule, which must have at least one entry not excluded.
+func ParseFirewallSourceIP(source string) ([]FirewallSourceIP, error) {
+	fields := strings.FieldsFunc(source, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n'
+	})
+
+	entries := make([]FirewallSourceIP, 0, len(fields))
+	included := false
+	for _, field := range fields {
+		entry := FirewallSourceIP{}
+		if strings.HasPrefix(field, "!") {
+			entry.Exclude = true
+			field = field[1:]
+		}
+
+		switch {
+		case field == "*":
+			entry.Any = true
+		case strings.HasPrefix(field, "@"):
+			entry.Set = field[1:]
+			if entry.Set == "" {
+				return nil, ErrFirewallSourceIPInvalid
+			}
+		default:
+			network, err := ParseIPNetwork(field)
+			if err != nil {
+				return nil, err
+			}
+
+			entry.Network = network
+		}
+
+		included = included || !entry.Exclude
+		entries = append(entries, entry)
+	}
+
+	if !included {
+		return nil, ErrFirewallSourceIPInvalid
+	}
+
+	return entries, nil
+}
+
+// ParseIPNetwork parses an IP address or a network in CIDR notation, returning an address as a single host network.
+func ParseIPNetwork(value string) (*net.IPNet, error) {
+	if strings.Contains(value, "/") {
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil, ErrFirewallSourceIPInvalid
+		}
+
+		return network, nil
+	}
+
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return nil, ErrFirewallSourceIPInvalid
+	}
+
+	if ipv4 := ip.To4(); ipv4 != nil {
+		return &net.IPNet{IP: ipv4, Mask: net.CIDRMask(32, 32)}, nil
+	}
+
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+}
+
 type FirewallRule struct {
 	ID                 string `json:"id,omitempty" bson:"_id,omitempty"`
 	TenantID           string `json:"tenant_id" bson:"tenant_id"`
@@ -44,3 +234,61 @@ type FirewallRule struct {
 type FirewallRuleUpdate struct {
 	FirewallRuleFields `bson:",inline"`
 }
+
+// FirewallEvaluation is a connection to a device evaluated against the firewall rules of the device's namespace.
+type FirewallEvaluation struct {
+	Namespace string `json:"domain" query:"domain" validate:"required"`
+	Device    string `json:"name" query:"name" validate:"required"`
+	Username  string `json:"username" query:"username" validate:"required"`
+	IPAddress string `json:"ip_address" query:"ip_address" validate:"required"`
+	// Type is the session's type and Command is the command line of an exec session.
+	Type    string `json:"type,omitempty" query:"type"`
+	Command string `json:"command,omitempty" query:"command"`
+}
+
+// FirewallSimulation is a hypothetical connection to a device of the namespace evaluated against its firewall rules.
+type FirewallSimulation struct {
+	// Device is the name of the device.
+	Device    string `json:"device" validate:"required"`
+	Username  string `json:"username" validate:"required"`
+	IPAddress string `json:"ip_address" validate:"required,ip"`
+	Type      string `json:"type,omitempty" validate:"omitempty,oneof=term exec scp web"`
+	Command   string `json:"command,omitempty"`
+}
+
+const (
+	// FirewallTraceMatched indicates that the rule matched the connection.
+	FirewallTraceMatched = "matched"
+	// FirewallTraceScheduleInactive indicates that the rule was skipped because its schedule is not active.
+	FirewallTraceScheduleInactive = "schedule_inactive"
+	// FirewallTraceSourceIPMismatch indicates that the connection's source IP does not match the rule.
+	FirewallTraceSourceIPMismatch = "source_ip_mismatch"
+	// FirewallTraceUsernameMismatch indicates that the connection's username does not match the rule.
+	FirewallTraceUsernameMismatch = "username_mismatch"
+	// FirewallTraceFilterMismatch indicates that the device does not match the rule's filter.
+	FirewallTraceFilterMismatch = "filter_mismatch"
+	// FirewallTraceSessionTypeMismatch indicates that the session's type is not one of the rule's session types.
+	FirewallTraceSessionTypeMismatch = "session_type_mismatch"
+	// FirewallTraceCommandMismatch indicates that the session's command matches none of the rule's commands.
+	FirewallTraceCommandMismatch = "command_mismatch"
+	// FirewallTraceCountryMismatch indicates that the connection's country is not one of the rule's countries.
+	FirewallTraceCountryMismatch = "country_mismatch"
+)
+
+// FirewallTraceStep is the result of a firewall rule evaluated against a connection.
+type FirewallTraceStep struct {
+	RuleID   string `json:"rule_id"`
+	Priority int    `json:"priority"`
+	Action   string `json:"action"`
+	// Result is one of the FirewallTrace constants.
+	Result string `json:"result"`
+}
+
+// FirewallDecision is the result of a connection evaluated against the firewall rules of a namespace.
+type FirewallDecision struct {
+	Allowed bool `json:"allowed"`
+	// Rule is the rule that decided the connection. It is nil when no rule matched.
+	Rule *FirewallRule `json:"rule,omitempty"`
+	// Trace contains the rules evaluated, in order, until one of them matched.
+	Trace []FirewallTraceStep `json:"trace"`
+}
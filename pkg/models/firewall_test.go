@@ -0,0 +1,67 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFirewallScheduleActive(t *testing.T) {
+	// 2026-01-02 is a Friday.
+	friday := func(hour, minute int) time.Time {
+		return time.Date(2026, time.January, 2, hour, minute, 0, 0, time.UTC)
+	}
+
+	nights := &FirewallSchedule{Weekdays: []string{"friday"}, Ranges: []FirewallTimeRange{{From: "22:00", To: "02:00"}}}
+
+	cases := []struct {
+		description string
+		schedule    *FirewallSchedule
+		time        time.Time
+		expected    bool
+	}{
+		{
+			description: "active on the weekday before midnight",
+			schedule:    nights,
+			time:        friday(23, 0),
+			expected:    true,
+		},
+		{
+			description: "active on the next day after midnight",
+			schedule:    nights,
+			time:        friday(25, 0),
+			expected:    true,
+		},
+		{
+			description: "inactive after midnight when the previous day is not listed",
+			schedule:    nights,
+			time:        friday(1, 0),
+			expected:    false,
+		},
+		{
+			description: "inactive after the range ends",
+			schedule:    nights,
+			time:        friday(26, 0),
+			expected:    false,
+		},
+		{
+			description: "active inside a range on the weekday",
+			schedule:    &FirewallSchedule{Weekdays: []string{"friday"}, Ranges: []FirewallTimeRange{{From: "09:00", To: "18:00"}}},
+			time:        friday(9, 0),
+			expected:    true,
+		},
+		{
+			description: "inactive on another weekday",
+			schedule:    &FirewallSchedule{Weekdays: []string{"monday"}},
+			time:        friday(12, 0),
+			expected:    false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.schedule.Active(tc.time))
+		})
+	}
+}
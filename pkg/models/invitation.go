@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	InvitationPending  = "pending"
+	InvitationAccepted = "accepted"
+	InvitationDeclined = "declined"
+)
+
+// InvitationFields contains the fields of an invitation set by the member who invites.
+type InvitationFields struct {
+	// Email is the address the invitation is sent to. It is accepted by the user with this email, even when the user
+	// signs up after the invitation.
+	Email string `json:"email" bson:"email" validate:"required,email"`
+	// Role is the role of the user on the namespace after accepting the invitation.
+	Role string `json:"role" bson:"role" validate:"required,max=30,hostname_rfc1123,excludes=.,ne=owner"`
+}
+
+func (i *InvitationFields) Validate() error {
+	return validator.New().Struct(i)
+}
+
+// Invitation is an invitation to join a namespace, pending until the invited user accepts or declines it.
+type Invitation struct {
+	ID       string `json:"id,omitempty" bson:"_id,omitempty"`
+	TenantID string `json:"tenant_id" bson:"tenant_id"`
+	// Token identifies the invitation on the link sent by email. It is never returned by the API.
+	Token  string `json:"-" bson:"token"`
+	Status string `json:"status" bson:"status"`
+	// InvitedBy is the ID of the member who sent the invitation.
+	InvitedBy        string    `json:"invited_by" bson:"invited_by"`
+	CreatedAt        time.Time `json:"created_at" bson:"created_at"`
+	ExpiresAt        time.Time `json:"expires_at" bson:"expires_at"`
+	InvitationFields `bson:",inline"`
+}
+
+// InvitationAnswer is the token of an invitation accepted or declined by the invited user.
+type InvitationAnswer struct {
+	Token string `json:"token" validate:"required"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// IPSetFields contains the fields of a named set of IP addresses and networks.
+//
+// An IPSet is referenced by its name, prefixed with "@", on the source IP of the namespace's firewall rules.
+type IPSetFields struct {
+	Name        string   `json:"name" bson:"name" validate:"required,max=64,hostname_rfc1123,excludes=."`
+	Description string   `json:"description,omitempty" bson:"description,omitempty" validate:"max=255"`
+	Prefixes    []string `json:"prefixes" bson:"prefixes" validate:"required,min=1,dive,cidr|ip"`
+}
+
+func (s *IPSetFields) Validate() error {
+	return validator.New().Struct(s)
+}
+
+type IPSet struct {
+	ID          string    `json:"id,omitempty" bson:"_id,omitempty"`
+	TenantID    string    `json:"tenant_id" bson:"tenant_id"`
+	CreatedAt   time.Time `json:"created_at" bson:"created_at"`
+	IPSetFields `bson:",inline"`
+}
+
+type IPSetUpdate struct {
+	IPSetFields `bson:",inline"`
+}
@@ -16,14 +16,131 @@ type Namespace struct {
 	DevicesCount int                `json:"devices_count" bson:"devices_count,omitempty"`
 	CreatedAt    time.Time          `json:"created_at" bson:"created_at"`
 	Billing      *Billing           `json:"billing" bson:"billing,omitempty"`
+	// Transfers records the transfers of the namespace's ownership, from the oldest.
+	Transfers []OwnershipTransfer `json:"transfers,omitempty" bson:"transfers,omitempty"`
+}
+
+// OwnershipTransfer is a transfer of a namespace's ownership from its owner to another member.
+type OwnershipTransfer struct {
+	// From is the ID of the previous owner, demoted to administrator.
+	From          string    `json:"from" bson:"from"`
+	To            string    `json:"to" bson:"to"`
+	TransferredAt time.Time `json:"transferred_at" bson:"transferred_at"`
 }
 
 type NamespaceSettings struct {
-	SessionRecord bool `json:"session_record" bson:"session_record,omitempty"`
+	SessionRecord bool          `json:"session_record" bson:"session_record,omitempty"`
+	DeviceNaming  *DeviceNaming `json:"device_naming,omitempty" bson:"device_naming,omitempty"`
+	GeoAccess     *GeoAccess    `json:"geo_access,omitempty" bson:"geo_access,omitempty"`
+	// SessionTypes enables or disables each session type. A namespace without it allows every session type.
+	SessionTypes *SessionTypes `json:"session_types,omitempty" bson:"session_types,omitempty"`
+}
+
+// SessionTypes enables or disables the types of the SSH sessions, and the forwardings requested on them, on a
+// namespace's devices.
+type SessionTypes struct {
+	Term bool `json:"term" bson:"term"`
+	Exec bool `json:"exec" bson:"exec"`
+	SCP  bool `json:"scp" bson:"scp"`
+	Web  bool `json:"web" bson:"web"`
+	// PortForwarding has no effect yet, as the SSH server refuses every port forwarding.
+	PortForwarding  bool `json:"port_forwarding" bson:"port_forwarding"`
+	AgentForwarding bool `json:"agent_forwarding" bson:"agent_forwarding"`
+}
+
+// Allows checks if a session type, or a forwarding, is enabled. A type not known by the settings is never allowed, so
+// a session whose type could not be determined cannot bypass them.
+func (t *SessionTypes) Allows(kind string) bool {
+	switch kind {
+	case SessionTypeTerm:
+		return t.Term
+	case SessionTypeExec:
+		return t.Exec
+	case SessionTypeSCP:
+		return t.SCP
+	case SessionTypeWeb:
+		return t.Web
+	case SessionPortForwarding:
+		return t.PortForwarding
+	case SessionAgentForwarding:
+		return t.AgentForwarding
+	default:
+		return false
+	}
+}
+
+const (
+	// GeoAccessAllow allows only the connections from the listed countries.
+	GeoAccessAllow = "allow"
+	// GeoAccessDeny denies the connections from the listed countries.
+	GeoAccessDeny = "deny"
+)
+
+// GeoAccess restricts the SSH connections to a namespace's devices by the client's country.
+type GeoAccess struct {
+	Mode string `json:"mode" bson:"mode" validate:"required,oneof=allow deny"`
+	// Countries is a list of ISO 3166-1 alpha-2 country codes.
+	Countries []string `json:"countries" bson:"countries" validate:"required,min=1,unique,dive,iso3166_1_alpha2"`
+}
+
+// Allows checks if a connection from a country, an ISO 3166-1 alpha-2 code, is allowed. A connection whose country is
+// unknown, an empty string, is only allowed in the deny mode.
+func (g *GeoAccess) Allows(country string) bool {
+	listed := false
+	for _, c := range g.Countries {
+		if c == country {
+			listed = true
+
+			break
+		}
+	}
+
+	if g.Mode == GeoAccessDeny {
+		return !listed
+	}
+
+	return listed
+}
+
+const (
+	// DeviceNamingCollisionAllow keeps the generated name even when another device already uses it.
+	DeviceNamingCollisionAllow = "allow"
+	// DeviceNamingCollisionSuffix appends the next sequential number, from 2, after the highest one used to the
+	// generated name.
+	DeviceNamingCollisionSuffix = "suffix"
+	// DeviceNamingCollisionUID appends the first characters of the device's UID to the generated name.
+	DeviceNamingCollisionUID = "uid"
+	// DeviceNamingCollisionReject refuses the registration of the device.
+	DeviceNamingCollisionReject = "reject"
+)
+
+// DeviceNaming defines how a device is named when it registers on a namespace.
+type DeviceNaming struct {
+	// Template is the device's name template. It accepts the placeholders {hostname}, {mac}, {mac4}, {os}, {arch} and
+	// {seq}, where {seq} is replaced by the next number after the highest one used by the names of the template.
+	Template string `json:"template" bson:"template" validate:"required,max=64"`
+	// Collision is the policy applied when the generated name is already in use on the namespace.
+	Collision string `json:"collision" bson:"collision" validate:"omitempty,oneof=allow suffix uid reject"`
 }
 
 type Member struct {
 	ID       string `json:"id,omitempty" bson:"id,omitempty"`
 	Username string `json:"username,omitempty" bson:"username,omitempty" validate:"min=3,max=30,alphanum,ascii"`
-	Role     string `json:"role" bson:"role" validate:"required,oneof=administrator operator observer"`
+	// Role is the name of a built-in role, except the owner, or of a custom role of the namespace.
+	Role string `json:"role" bson:"role" validate:"required,max=30,hostname_rfc1123,excludes=.,ne=owner"`
+	// Scope restricts the member to a subset of the namespace's devices. A member without a scope reaches every device.
+	//
+	// On the SSH connections, the scope is applied to the web terminal's sessions and to the sessions authenticated by
+	// a public key the member added to the namespace. The sessions authenticated by the device's password carry no
+	// member.
+	Scope *MemberScope `json:"scope,omitempty" bson:"scope,omitempty"`
+}
+
+// MemberScope contains the devices a namespace member is restricted to.
+//
+// A MemberScope can contain either Tags, matching the devices with any of the tags, or Group, the ID of a DeviceGroup,
+// never both.
+type MemberScope struct {
+	Tags  []string `json:"tags,omitempty" bson:"tags,omitempty" validate:"required_without=Group,excluded_with=Group,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	Group string   `json:"group,omitempty" bson:"group,omitempty" validate:"required_without=Tags,excluded_with=Tags"`
 }
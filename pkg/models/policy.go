@@ -0,0 +1,83 @@
+package models
+
+import (
+	"github.com/go-playground/validator/v10"
+)
+
+// Policy is a declarative document describing the access policy of a namespace: its firewall rules, its public keys and
+// the tags of its devices.
+//
+// A section set to nil is not managed by the document, so it is neither exported nor changed when the document is
+// applied. An empty section removes every item of the namespace.
+type Policy struct {
+	FirewallRules []PolicyFirewallRule `json:"firewall_rules"`
+	PublicKeys    []PolicyPublicKey    `json:"public_keys"`
+	// Tags maps the name of a device to its tags. A device not listed has no tags.
+	Tags map[string][]string `json:"tags" validate:"dive,keys,required,endkeys,max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+}
+
+// Validate checks the tags of the document and the fields of its firewall rules and public keys.
+func (p *Policy) Validate() error {
+	if err := validator.New().Struct(p); err != nil {
+		return err
+	}
+
+	for i := range p.FirewallRules {
+		if err := p.FirewallRules[i].Validate(); err != nil {
+			return err
+		}
+	}
+
+	for i := range p.PublicKeys {
+		if err := p.PublicKeys[i].Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PolicyFirewallRule is a firewall rule of a Policy.
+//
+// ID identifies the rule on the namespace. A rule without ID is matched to an existing rule with the same fields.
+type PolicyFirewallRule struct {
+	ID string `json:"id,omitempty"`
+	FirewallRuleFields
+}
+
+// PolicyPublicKey is a public key of a Policy.
+type PolicyPublicKey struct {
+	// Data is the public key in the authorized_keys format.
+	Data string `json:"data"`
+	PublicKeyFields
+}
+
+const (
+	PolicyChangeCreate = "create"
+	PolicyChangeUpdate = "update"
+	PolicyChangeDelete = "delete"
+)
+
+const (
+	PolicyResourceFirewallRule = "firewall_rule"
+	PolicyResourcePublicKey    = "public_key"
+	PolicyResourceTags         = "tags"
+)
+
+// PolicyChange is a change required to make a namespace match a Policy.
+type PolicyChange struct {
+	// Action is one of the PolicyChange constants.
+	Action string `json:"action"`
+	// Resource is one of the PolicyResource constants.
+	Resource string `json:"resource"`
+	// ID identifies the item changed: the ID of a firewall rule, the fingerprint of a public key or the name of a
+	// device. It is empty for a firewall rule to be created.
+	ID string `json:"id,omitempty"`
+}
+
+// PolicyDiff is the list of changes required to make a namespace match a Policy.
+type PolicyDiff struct {
+	Changes []PolicyChange `json:"changes"`
+	// Applied indicates that the changes were applied to the namespace.
+	Applied bool `json:"applied"`
+}
@@ -9,10 +9,12 @@ import (
 
 // PublicKeyFilter contains the filter rule of a Public Key.
 //
-// A PublicKeyFilter can contain either Hostname, string, or Tags, slice of strings never both.
+// A PublicKeyFilter can contain either Hostname, string, Tags, slice of strings, or Group, the ID of a DeviceGroup, never
+// more than one.
 type PublicKeyFilter struct {
-	Hostname string   `json:"hostname,omitempty" bson:"hostname,omitempty" validate:"required_without=Tags,excluded_with=Tags,regexp"`
-	Tags     []string `json:"tags,omitempty" bson:"tags,omitempty" validate:"required_without=Hostname,excluded_with=Hostname,max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	Hostname string   `json:"hostname,omitempty" bson:"hostname,omitempty" validate:"required_without_all=Tags Group,excluded_with=Tags Group,regexp"`
+	Tags     []string `json:"tags,omitempty" bson:"tags,omitempty" validate:"required_without_all=Hostname Group,excluded_with=Hostname Group,max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	Group    string   `json:"group,omitempty" bson:"group,omitempty" validate:"required_without_all=Hostname Tags,excluded_with=Hostname Tags"`
 }
 
 type PublicKeyFields struct {
@@ -39,6 +41,8 @@ type PublicKey struct {
 	CreatedAt       time.Time `json:"created_at" bson:"created_at"`
 	TenantID        string    `json:"tenant_id" bson:"tenant_id"`
 	PublicKeyFields `bson:",inline"`
+	// CreatedBy is the ID of the user who added the key, whose member scope restricts the sessions it authenticates.
+	CreatedBy string `json:"created_by,omitempty" bson:"created_by,omitempty"`
 }
 
 type PublicKeyUpdate struct {
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// RoleFields contains the fields of a custom role of a namespace, a named set of the actions allowed to the members
+// assigned to it.
+type RoleFields struct {
+	// Name identifies the role on the namespace's members, being unique in the namespace and different from the names
+	// of the built-in roles.
+	Name        string `json:"name" bson:"name" validate:"required,max=30,hostname_rfc1123,excludes=."`
+	Description string `json:"description,omitempty" bson:"description,omitempty" validate:"max=255"`
+	// Permissions are the names of the actions allowed to the role.
+	Permissions []string `json:"permissions" bson:"permissions" validate:"required,min=1,unique"`
+}
+
+func (r *RoleFields) Validate() error {
+	return validator.New().Struct(r)
+}
+
+type Role struct {
+	ID         string    `json:"id,omitempty" bson:"_id,omitempty"`
+	TenantID   string    `json:"tenant_id" bson:"tenant_id"`
+	CreatedAt  time.Time `json:"created_at" bson:"created_at"`
+	RoleFields `bson:",inline"`
+}
+
+type RoleUpdate struct {
+	RoleFields `bson:",inline"`
+}
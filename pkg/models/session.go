@@ -4,6 +4,20 @@ import (
 	"time"
 )
 
+// Types of the sessions.
+const (
+	SessionTypeTerm = "term" // interactive session with a pty.
+	SessionTypeExec = "exec" // command executed without a pty.
+	SessionTypeSCP  = "scp"  // file copy.
+	SessionTypeWeb  = "web"  // session opened from the web terminal.
+)
+
+// Forwardings requested on the sessions.
+const (
+	SessionPortForwarding  = "port_forwarding"
+	SessionAgentForwarding = "agent_forwarding"
+)
+
 type Session struct {
 	UID           string    `json:"uid"`
 	DeviceUID     UID       `json:"device_uid,omitempty" bson:"device_uid"`
@@ -19,6 +33,10 @@ type Session struct {
 	Recorded      bool      `json:"recorded" bson:"recorded"`
 	Type          string    `json:"type" bson:"type"`
 	Term          string    `json:"term" bson:"term"`
+	// FirewallRuleID is the ID of the firewall rule that blocked the session, if any.
+	FirewallRuleID string `json:"firewall_rule_id,omitempty" bson:"firewall_rule_id,omitempty"`
+	// Country is the ISO 3166-1 alpha-2 code of the country resolved from the session's IP address, if any.
+	Country string `json:"country,omitempty" bson:"country,omitempty"`
 }
 
 type ActiveSession struct {
@@ -45,3 +63,35 @@ type SessionRecorded struct {
 	Width   int    `json:"width" bson:"width,omitempty"`
 	Height  int    `json:"height" bson:"height,omitempty"`
 }
+
+// Statuses of a session approval.
+const (
+	SessionApprovalPending  = "pending"
+	SessionApprovalApproved = "approved"
+	SessionApprovalDenied   = "denied"
+)
+
+// SessionApproval is a session held by the SSH server until a member of the namespace approves or denies it, as
+// required by the incoming connection webhook.
+type SessionApproval struct {
+	// UID is the UID of the session.
+	UID       string `json:"uid" bson:"uid" validate:"required"`
+	DeviceUID UID    `json:"device_uid" bson:"device_uid" validate:"required"`
+	TenantID  string `json:"tenant_id" bson:"tenant_id"`
+	Username  string `json:"username" bson:"username"`
+	IPAddress string `json:"ip_address" bson:"ip_address"`
+	// Message is the reason of the approval given by the webhook.
+	Message   string    `json:"message,omitempty" bson:"message,omitempty"`
+	Status    string    `json:"status" bson:"status"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+	// ExpiresAt is the time when the SSH server stops waiting for the decision and closes the session.
+	ExpiresAt time.Time `json:"expires_at" bson:"expires_at" validate:"required"`
+	// DecidedBy is the ID of the user who approved or denied the session.
+	DecidedBy string     `json:"decided_by,omitempty" bson:"decided_by,omitempty"`
+	DecidedAt *time.Time `json:"decided_at,omitempty" bson:"decided_at,omitempty"`
+}
+
+// SessionApprovalDecision is the decision of a member of the namespace about a session approval.
+type SessionApprovalDecision struct {
+	Approved bool `json:"approved"`
+}
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// SSHLimit is the rate limiting state of the SSH connections from a source IP address or to a device.
+type SSHLimit struct {
+	// Failures is the number of failed attempts since the last ban or the last successful attempt.
+	Failures int `json:"failures"`
+	// Bans is the number of times the source IP address or the device was banned. Each ban lasts twice the previous.
+	Bans int `json:"bans"`
+	// BlockedUntil is the time until which the attempts are refused.
+	BlockedUntil time.Time `json:"blocked_until"`
+}
+
+// Blocked checks if the attempts are refused at time t.
+func (l *SSHLimit) Blocked(t time.Time) bool {
+	return t.Before(l.BlockedUntil)
+}
+
+// SSHLimits is the rate limiting state of a source IP address and of a device.
+type SSHLimits struct {
+	IPAddress *SSHLimit `json:"ip_address,omitempty"`
+	Device    *SSHLimit `json:"device,omitempty"`
+}
+
+// SSHAttempt is the result of an attempt to authenticate on a device through the SSH server.
+type SSHAttempt struct {
+	IPAddress string `json:"ip_address" validate:"required,ip"`
+	// Device is the UID of the device.
+	Device  string `json:"device" validate:"required"`
+	Success bool   `json:"success"`
+}
@@ -31,6 +31,25 @@ type UserAuthRequest struct {
 	Password string `json:"password"`
 }
 
+// LoginLockout is the state of the failed logins of a username or of a source IP address.
+type LoginLockout struct {
+	// Failures is the number of failed logins since the last lockout or the last successful login.
+	Failures int `json:"failures"`
+	// LockedUntil is the time until which the logins are refused.
+	LockedUntil time.Time `json:"locked_until"`
+}
+
+// Locked checks if the logins are refused at time t.
+func (l *LoginLockout) Locked(t time.Time) bool {
+	return t.Before(l.LockedUntil)
+}
+
+// LoginLockouts is the state of the failed logins of a username and of a source IP address.
+type LoginLockouts struct {
+	Username  *LoginLockout `json:"username,omitempty"`
+	IPAddress *LoginLockout `json:"ip_address,omitempty"`
+}
+
 type UserAuthResponse struct {
 	Token  string `json:"token"`
 	User   string `json:"user"`
@@ -39,6 +58,15 @@ type UserAuthResponse struct {
 	Tenant string `json:"tenant"`
 	Role   string `json:"role"`
 	Email  string `json:"email"`
+	// Namespaces are the memberships of the user, listed with a token not bound to a namespace.
+	Namespaces []Membership `json:"namespaces,omitempty"`
+}
+
+// Membership is a namespace of which a user is a member, with the user's role on it.
+type Membership struct {
+	TenantID string `json:"tenant_id"`
+	Name     string `json:"name"`
+	Role     string `json:"role"`
 }
 
 type UserAuthClaims struct {
@@ -47,6 +75,8 @@ type UserAuthClaims struct {
 	Tenant   string `json:"tenant"`
 	ID       string `json:"id"`
 	Role     string `json:"role"`
+	// Scoped indicates a user-scoped token, not bound to a namespace, which must set the namespace on each request.
+	Scoped bool `json:"scoped,omitempty"`
 
 	AuthClaims           `mapstruct:",squash"`
 	jwt.RegisteredClaims `mapstruct:",squash"`
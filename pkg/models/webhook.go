@@ -0,0 +1,90 @@
+package models
+
+import (
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Events of a namespace delivered to its webhooks.
+const (
+	WebhookEventDeviceRegistered = "device_registered" // a new device was registered as pending.
+	WebhookEventDeviceAccepted   = "device_accepted"   // a pending device was accepted.
+	WebhookEventDeviceOffline    = "device_offline"    // a device disconnected.
+	WebhookEventSessionStarted   = "session_started"   // a session to a device was opened.
+	WebhookEventSessionFinished  = "session_finished"  // a session to a device was closed.
+	WebhookEventMemberAdded      = "member_added"      // a user was added to the namespace.
+)
+
+// WebhookFields contains the fields of a subscription of an URL to the events of a namespace.
+type WebhookFields struct {
+	// URL is the address where the events are delivered, only through HTTP or HTTPS.
+	URL    string   `json:"url" bson:"url" validate:"required,url,startswith=http://|startswith=https://,max=2048"`
+	Events []string `json:"events" bson:"events" validate:"required,min=1,unique,dive,oneof=device_registered device_accepted device_offline session_started session_finished member_added"`
+	Active bool     `json:"active" bson:"active"`
+}
+
+func (w *WebhookFields) Validate() error {
+	return validator.New().Struct(w)
+}
+
+type Webhook struct {
+	ID       string `json:"id,omitempty" bson:"_id,omitempty"`
+	TenantID string `json:"tenant_id" bson:"tenant_id"`
+	// Secret is the key used to sign the deliveries, generated when it is not set on the webhook creation. It is only
+	// returned when the webhook is created.
+	Secret        string    `json:"secret,omitempty" bson:"secret" validate:"omitempty,min=16,max=128"`
+	CreatedAt     time.Time `json:"created_at" bson:"created_at"`
+	WebhookFields `bson:",inline"`
+}
+
+func (w *Webhook) Validate() error {
+	return validator.New().Struct(w)
+}
+
+type WebhookUpdate struct {
+	// Secret replaces the key used to sign the deliveries when it is set.
+	Secret        string `json:"secret,omitempty" bson:"secret,omitempty" validate:"omitempty,min=16,max=128"`
+	WebhookFields `bson:",inline"`
+}
+
+func (w *WebhookUpdate) Validate() error {
+	return validator.New().Struct(w)
+}
+
+// WebhookEvent is the body of a delivery of an event to a webhook.
+type WebhookEvent struct {
+	// ID identifies the event, being the same on all deliveries of the event.
+	ID        string      `json:"id"`
+	Event     string      `json:"event"`
+	TenantID  string      `json:"tenant_id"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// WebhookDeliveryTask is a delivery of an event to a webhook, enqueued to be sent in background.
+type WebhookDeliveryTask struct {
+	WebhookID string `json:"webhook_id"`
+	TenantID  string `json:"tenant_id"`
+	EventID   string `json:"event_id"`
+	Event     string `json:"event"`
+	// Body is the encoded WebhookEvent, kept to send the same body on each attempt.
+	Body []byte `json:"body"`
+}
+
+// WebhookDelivery is the result of an attempt to deliver an event to a webhook.
+type WebhookDelivery struct {
+	ID        string `json:"id,omitempty" bson:"_id,omitempty"`
+	WebhookID string `json:"webhook_id" bson:"webhook_id"`
+	TenantID  string `json:"tenant_id" bson:"tenant_id"`
+	EventID   string `json:"event_id" bson:"event_id"`
+	Event     string `json:"event" bson:"event"`
+	// Attempt is the number of the attempt, starting from one.
+	Attempt int `json:"attempt" bson:"attempt"`
+	// StatusCode is the HTTP status code of the response, if any.
+	StatusCode int `json:"status_code,omitempty" bson:"status_code,omitempty"`
+	// Error describes why the delivery failed, if it did.
+	Error     string    `json:"error,omitempty" bson:"error,omitempty"`
+	Success   bool      `json:"success" bson:"success"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+}
@@ -92,6 +92,16 @@ func ValidateFieldTag(tag string) bool {
 	return true
 }
 
+// ValidateFieldDeviceName validate the data for the field Name from structure models.Device.
+func ValidateFieldDeviceName(name string) bool {
+	// Field's name that have a tag value.
+	const Field = "Name"
+	// Structure that contains the field above.
+	s := models.Device{}
+
+	return ValidateField(s, Field, name)
+}
+
 // ValidateFieldUsername validate the data for the field Username from structure models.UserData.
 func ValidateFieldUsername(username string) bool {
 	// Field's name that have a tag value.
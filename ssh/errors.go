@@ -10,6 +10,14 @@ var (
 	ErrInvalidSessionTarget = errors.New(fmt.Errorf("invalid session target"), fmt.Errorf("invalid session target"))
 	ErrBillingBlock         = errors.New(fmt.Errorf("reached the device limit"), fmt.Errorf("you cannot connect to this device because the namespace is not eligible for the free plan.\\nPlease contact the namespace owner's to upgrade the plan.\\nSee our pricing plans on https://www.shellhub.io/pricing to estimate the cost of your use cases on ShellHub Cloud or go to https://cloud.shellhub.io/settings/billing to upgrade the plan"))
 	ErrFirewallBlock        = errors.New(fmt.Errorf("a firewall rule block this action"), fmt.Errorf("a firewall rule block this action"))
+	ErrCountryBlock         = errors.New(fmt.Errorf("the client's country is not allowed"), fmt.Errorf("connections from your country are not allowed to this device"))
+	ErrSessionTypeBlock     = errors.New(fmt.Errorf("the session type is disabled on the namespace"), fmt.Errorf("this type of session is not allowed on the device's namespace"))
+	ErrForwardingBlock      = errors.New(fmt.Errorf("the agent forwarding is disabled on the namespace"), fmt.Errorf("agent forwarding is not allowed on the device's namespace"))
+	ErrAttemptBlock         = errors.New(fmt.Errorf("too many failed authentication attempts"), fmt.Errorf("too many failed authentication attempts, try again later"))
 	ErrFindDevice           = errors.New(fmt.Errorf("cloud not find the device"), fmt.Errorf("cloud not find the device"))
+	ErrWebhookUnavailable   = errors.New(fmt.Errorf("could not get the decision of the webhook"), fmt.Errorf("connection could not be verified by the Webhook endpoint"))
+	ErrWebhookDeny          = errors.New(fmt.Errorf("the webhook denied the connection"), fmt.Errorf("Connection rejected by Webhook endpoint"))
+	ErrApprovalDenied       = errors.New(fmt.Errorf("the session approval was denied"), fmt.Errorf("the session was denied by a member of the namespace"))
+	ErrApprovalTimeout      = errors.New(fmt.Errorf("the session approval expired"), fmt.Errorf("the session was not approved in time"))
 	ErrLookupDevice         = errors.New(fmt.Errorf("could not lookup for device data"), fmt.Errorf("could not lookup for device data"))
 )
@@ -11,7 +11,6 @@ import (
 	"net/http"
 	"os"
 	"strings"
-	"time"
 
 	sshserver "github.com/gliderlabs/ssh"
 	"github.com/kelseyhightower/envconfig"
@@ -78,19 +77,16 @@ func (s *Server) sessionHandler(session sshserver.Session) {
 	}
 
 	if wh := webhook.NewClient(); wh != nil {
-		res, err := wh.Connect(sess.Lookup)
-		if errors.Is(err, webhook.ErrForbidden) {
-			session.Write([]byte("Connection rejected by Webhook endpoint\n")) // nolint:errcheck
+		if err := sess.evaluateWebhook(session.Context(), wh, client.NewClient()); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"session": session.Context().Value(sshserver.ContextKeySessionID),
+			}).Error(errors.GetInternal(err))
+
+			session.Write([]byte(fmt.Sprintf("%s\n", errors.GetExternal(err)))) // nolint:errcheck
 			session.Close()
 
 			return
 		}
-
-		if sess.Pty {
-			session.Write([]byte(fmt.Sprintf("Wait %d seconds while the agent starts\n", res.Timeout))) // nolint:errcheck
-		}
-
-		time.Sleep(time.Duration(res.Timeout) * time.Second)
 	}
 
 	conn, err := s.tunnel.Dial(context.Background(), sess.Target)
@@ -262,6 +258,14 @@ func (s *Server) publicKeyHandler(ctx sshserver.Context, pubKey sshserver.Public
 }
 
 func (s *Server) passwordHandler(ctx sshserver.Context, pass string) bool {
+	// Rejects the passwords from a source IP address blocked by too many failed authentications. The connections from
+	// the loopback, as the web terminal ones, are checked with the client's address when the session starts.
+	if host, _, err := net.SplitHostPort(ctx.RemoteAddr().String()); err == nil && !net.ParseIP(host).IsLoopback() {
+		if err := client.NewClient().CheckSSHAttempt(host, ""); errors.Is(err, client.ErrTooManyRequests) {
+			return false
+		}
+	}
+
 	// Store password in session context for later use in session handling
 	ctx.SetValue("password", pass)
 
@@ -31,8 +31,10 @@ type Session struct {
 	Type          string `json:"type"`
 	Term          string `json:"term"`
 	Authenticated bool   `json:"authenticated"`
-	Lookup        map[string]string
-	Pty           bool
+	// FirewallRuleID is the ID of the firewall rule that blocked the session, if any.
+	FirewallRuleID string `json:"firewall_rule_id,omitempty"`
+	Lookup         map[string]string
+	Pty            bool
 }
 
 type ConfigOptions struct {
@@ -58,7 +60,9 @@ func handlePty(s *Session) {
 
 	env := loadEnv(s.session.Environ())
 
-	if value, ok := env["WS"]; ok && value == "true" {
+	// The WS variable is only trusted from the web terminal, whose connections come from the loopback, as any client can
+	// set the environment of its session.
+	if value, ok := env["WS"]; ok && value == "true" && isLoopback(s.session.RemoteAddr()) {
 		env["WS"] = "false"
 		s.Type = Web
 
@@ -78,11 +82,22 @@ func handlePty(s *Session) {
 		s.Type = SCP
 	case !isPty && cmd != "":
 		s.Type = Exec
-	case isPty:
+	default:
+		// A session with a pty, or an interactive shell opened without it, as "ssh -T".
 		s.Type = Term
 	}
 }
 
+// isLoopback checks if an address is on the loopback, as the connections opened by the web terminal.
+func isLoopback(addr net.Addr) bool {
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return false
+	}
+
+	return host == "127.0.0.1" || host == "::1"
+}
+
 func NewSession(target string, session sshserver.Session) (*Session, error) {
 	// Splits the target into a user, 0, and  the true target, 1.
 	// Example: username@shellhub.00-00-00-00-00-00@localhost.
@@ -109,13 +124,21 @@ func NewSession(target string, session sshserver.Session) (*Session, error) {
 
 	handlePty(s)
 
-	if host == "127.0.0.1" || host == "::1" {
+	// The member opening the session is known on the web terminal's connections. The native SSH clients are bound to
+	// the member who added the namespace public key authenticating them, so the member's scope is applied to both.
+	var member, fingerprint string
+
+	if isLoopback(session.RemoteAddr()) {
 		env := loadEnv(session.Environ())
 		if value, ok := env["IP_ADDRESS"]; ok {
 			s.IPAddress = value
 		}
+
+		member = env["MEMBER_ID"]
 	} else {
 		s.IPAddress = host
+
+		fingerprint, _ = session.Context().Value("public_key").(string)
 	}
 
 	var lookup map[string]string
@@ -150,7 +173,19 @@ func NewSession(target string, session sshserver.Session) (*Session, error) {
 		}
 	}
 
+	if member != "" {
+		lookup["member"] = member
+	}
+
+	if fingerprint != "" {
+		lookup["public_key"] = fingerprint
+	}
+
 	uid, errs := c.Lookup(lookup)
+	if len(errs) > 0 && errors.Is(errs[0], client.ErrForbidden) {
+		return nil, ErrCountryBlock // The namespace does not allow connections from the client's country.
+	}
+
 	if len(errs) > 0 || uid == "" {
 		return nil, ErrLookupDevice // Cloud not lookup for device's data.
 	}
@@ -158,10 +193,47 @@ func NewSession(target string, session sshserver.Session) (*Session, error) {
 	s.Target = uid
 	s.Lookup = lookup
 
-	if envs.IsEnterprise() || envs.IsCloud() { // Avoid firewall evaluation in community instance.
-		if err := c.FirewallEvaluate(lookup); err != nil {
-			return nil, ErrFirewallBlock // A firewall rule block this action.
+	// Refuses the source IP addresses and the devices blocked by too many failed authentications before a connection
+	// to the device is opened.
+	if err := c.CheckSSHAttempt(s.IPAddress, s.Target); errors.Is(err, client.ErrTooManyRequests) {
+		return nil, ErrAttemptBlock
+	}
+
+	// Refuses the session types, and the agent forwarding, disabled on the device's namespace. Like the firewall, the
+	// session is refused when they could not be evaluated.
+	if err := c.EvaluateSessionType(s.Target, s.Type); err != nil {
+		return nil, ErrSessionTypeBlock
+	}
+
+	if sshserver.AgentRequested(session) {
+		if err := c.EvaluateSessionType(s.Target, models.SessionAgentForwarding); err != nil {
+			return nil, ErrForwardingBlock
+		}
+	}
+
+	// The session's type and command are evaluated with the connection, so a command not allowed by the firewall is
+	// never started on the device.
+	evaluation := map[string]string{
+		"type":    s.Type,
+		"command": session.RawCommand(),
+	}
+
+	for key, value := range lookup {
+		evaluation[key] = value
+	}
+
+	decision, err := c.FirewallEvaluate(evaluation)
+	if err != nil || !decision.Allowed {
+		if decision != nil && decision.Rule != nil {
+			s.FirewallRuleID = decision.Rule.ID
+
+			// Records the blocked session, with the rule that blocked it, as a finished session.
+			if err := s.register(session); err == nil {
+				s.finish(nil) // nolint:errcheck
+			}
 		}
+
+		return nil, ErrFirewallBlock // A firewall rule block this action.
 	}
 
 	if envs.IsCloud() && envs.HasBilling() {
@@ -212,6 +284,13 @@ func (s *Session) connect(passwd string, key *rsa.PrivateKey, session sshserver.
 	}
 
 	sshConn, reqs, err := NewClientConnWithDeadline(conn, "tcp", config)
+	if err := c.RecordSSHAttempt(&models.SSHAttempt{IPAddress: s.IPAddress, Device: s.Target, Success: err == nil}); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"session": s.UID,
+			"err":     err,
+		}).Warning("Failed to record the authentication attempt")
+	}
+
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
 			"session": s.UID,
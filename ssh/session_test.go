@@ -1,6 +1,7 @@
 package main
 
 import (
+	"net"
 	"testing"
 
 	"github.com/gliderlabs/ssh"
@@ -14,6 +15,7 @@ func TestHandlePty(t *testing.T) {
 		session := &Session{session: sessionMock}
 
 		sessionMock.On("Environ").Return([]string{"WS=true"}).Once()
+		sessionMock.On("RemoteAddr").Return(&net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 2222}).Once()
 		sessionMock.On("Pty").Return(ssh.Pty{Term: "xterm.js", Window: ssh.Window{Width: 100, Height: 40}}, nil, true).Once()
 		handlePty(session)
 		assert.Equal(t, true, session.Pty)
@@ -65,6 +67,33 @@ func TestHandlePty(t *testing.T) {
 		assert.Equal(t, SCP, session.Type)
 		assert.Equal(t, "", session.Term)
 
+		sessionMock.AssertExpectations(t)
+	})
+	t.Run("HandleWSFromRemoteClient", func(t *testing.T) {
+		sessionMock := &mocks.Session{}
+		session := &Session{session: sessionMock}
+
+		sessionMock.On("Environ").Return([]string{"WS=true"}).Once()
+		sessionMock.On("RemoteAddr").Return(&net.TCPAddr{IP: net.ParseIP("203.0.113.10"), Port: 50000}).Once()
+		sessionMock.On("Pty").Return(ssh.Pty{}, nil, false).Once()
+		sessionMock.On("Command").Return([]string{"scp"}).Once()
+		handlePty(session)
+		assert.Equal(t, SCP, session.Type)
+
+		sessionMock.AssertExpectations(t)
+	})
+
+	t.Run("HandleShellWithoutPty", func(t *testing.T) {
+		sessionMock := &mocks.Session{}
+		session := &Session{session: sessionMock}
+
+		sessionMock.On("Environ").Return([]string{}).Once()
+		sessionMock.On("Pty").Return(ssh.Pty{}, nil, false).Once()
+		sessionMock.On("Command").Return([]string{}).Once()
+		handlePty(session)
+		assert.Equal(t, false, session.Pty)
+		assert.Equal(t, Term, session.Type)
+
 		sessionMock.AssertExpectations(t)
 	})
 }
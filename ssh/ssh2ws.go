@@ -140,6 +140,14 @@ func HandlerWebsocket(ws *websocket.Conn) {
 		return
 	}
 
+	// The member ID is set by the gateway from the user's token, restricting the session to the member's devices.
+	if err = session.Setenv("MEMBER_ID", ws.Request().Header.Get("X-ID")); err != nil {
+		session.Close()
+		ws.Close()
+
+		return
+	}
+
 	if err = session.Setenv("WS", "true"); err != nil {
 		session.Close()
 		ws.Close()
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	client "github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/api/webhook"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/ssh/pkg/errors"
+)
+
+var (
+	// approvalInterval is how often a session waiting for its approval checks whether it was decided.
+	approvalInterval = 2 * time.Second
+	// approvalTimeout is how long a session waits for its approval when the webhook does not set it.
+	approvalTimeout = 5 * time.Minute
+)
+
+// evaluateWebhook asks the incoming connection webhook whether the session can be opened. The webhook can allow it,
+// deny it, hold it until a member of the namespace approves it and replace the username used to log in on the device.
+//
+// The session is rejected when the webhook cannot be reached or responds with an unknown decision.
+func (s *Session) evaluateWebhook(ctx context.Context, wh webhook.Webhook, c client.Client) error {
+	lookup := map[string]string{"session": s.UID}
+	for key, value := range s.Lookup {
+		lookup[key] = value
+	}
+
+	res, err := wh.Connect(lookup)
+	if errors.Is(err, webhook.ErrForbidden) {
+		return ErrWebhookDeny
+	}
+
+	if err != nil || res == nil {
+		return ErrWebhookUnavailable
+	}
+
+	switch res.Decision {
+	case "", webhook.WebhookDecisionAllow:
+	case webhook.WebhookDecisionDeny:
+		if res.Message != "" {
+			return errors.New(fmt.Errorf("the webhook denied the connection"), fmt.Errorf("%s", res.Message))
+		}
+
+		return ErrWebhookDeny
+	case webhook.WebhookDecisionApproval:
+		if err := s.waitApproval(ctx, res, c); err != nil {
+			return err
+		}
+	default:
+		return ErrWebhookUnavailable
+	}
+
+	if res.Username != "" {
+		s.User = res.Username
+	}
+
+	if res.Timeout > 0 {
+		if s.Pty {
+			s.session.Write([]byte(fmt.Sprintf("Wait %d seconds while the agent starts\n", res.Timeout))) // nolint:errcheck
+		}
+
+		time.Sleep(time.Duration(res.Timeout) * time.Second)
+	}
+
+	return nil
+}
+
+// waitApproval holds the session until a member of the namespace approves or denies it, it expires or the client
+// disconnects.
+func (s *Session) waitApproval(ctx context.Context, res *webhook.IncomingConnectionWebhookResponse, c client.Client) error {
+	timeout := approvalTimeout
+	if res.ApprovalTimeout > 0 {
+		timeout = time.Duration(res.ApprovalTimeout) * time.Second
+	}
+
+	approval := &models.SessionApproval{
+		UID:       s.UID,
+		DeviceUID: models.UID(s.Target),
+		Username:  s.User,
+		IPAddress: s.IPAddress,
+		Message:   res.Message,
+		ExpiresAt: clock.Now().Add(timeout),
+	}
+
+	if err := c.CreateSessionApproval(approval); err != nil {
+		return ErrWebhookUnavailable
+	}
+
+	if s.Pty {
+		message := res.Message
+		if message == "" {
+			message = "Waiting for the approval of the session"
+		}
+
+		s.session.Write([]byte(fmt.Sprintf("%s (%s)\n", message, s.UID))) // nolint:errcheck
+	}
+
+	expired := time.NewTimer(timeout)
+	defer expired.Stop()
+
+	ticker := time.NewTicker(approvalInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.New(ctx.Err(), fmt.Errorf("the session was closed"))
+		case <-expired.C:
+			return ErrApprovalTimeout
+		case <-ticker.C:
+			approval, err := c.GetSessionApproval(s.UID)
+			if err != nil {
+				// A failed check is retried on the next tick.
+				continue
+			}
+
+			switch approval.Status {
+			case models.SessionApprovalApproved:
+				return nil
+			case models.SessionApprovalDenied:
+				return ErrApprovalDenied
+			}
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/webhook"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	clockmocks "github.com/shellhub-io/shellhub/pkg/clock/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/ssh/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeWebhook is a webhook responding with a fixed response and keeping the last request.
+type fakeWebhook struct {
+	res     *webhook.IncomingConnectionWebhookResponse
+	err     error
+	request map[string]string
+}
+
+func (w *fakeWebhook) Connect(m map[string]string) (*webhook.IncomingConnectionWebhookResponse, error) {
+	w.request = m
+
+	return w.res, w.err
+}
+
+func TestEvaluateWebhook(t *testing.T) {
+	approvalInterval = time.Millisecond
+
+	now := time.Now()
+
+	clockMock := &clockmocks.Clock{}
+	clock.DefaultBackend = clockMock
+	clockMock.On("Now").Return(now)
+
+	approval := &models.SessionApproval{
+		UID:       "uid",
+		DeviceUID: "device",
+		Username:  "user",
+		IPAddress: "127.0.0.1",
+		ExpiresAt: now.Add(approvalTimeout),
+	}
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	cases := []struct {
+		description   string
+		ctx           context.Context
+		webhook       *fakeWebhook
+		requiredMocks func(clientMock *mocks.Client)
+		username      string
+		// expected is the error shown to the user.
+		expected error
+	}{
+		{
+			description:   "fails when the webhook forbids the connection",
+			webhook:       &fakeWebhook{err: webhook.ErrForbidden},
+			requiredMocks: func(_ *mocks.Client) {},
+			username:      "user",
+			expected:      errors.GetExternal(ErrWebhookDeny),
+		},
+		{
+			description:   "fails when the webhook is unreachable",
+			webhook:       &fakeWebhook{err: webhook.ErrConnectionFailed},
+			requiredMocks: func(_ *mocks.Client) {},
+			username:      "user",
+			expected:      errors.GetExternal(ErrWebhookUnavailable),
+		},
+		{
+			description:   "fails when the decision is unknown",
+			webhook:       &fakeWebhook{res: &webhook.IncomingConnectionWebhookResponse{Decision: "maybe"}},
+			requiredMocks: func(_ *mocks.Client) {},
+			username:      "user",
+			expected:      errors.GetExternal(ErrWebhookUnavailable),
+		},
+		{
+			description: "fails when the webhook denies the connection with a message",
+			webhook: &fakeWebhook{res: &webhook.IncomingConnectionWebhookResponse{
+				Decision: webhook.WebhookDecisionDeny,
+				Message:  "outside of the maintenance window",
+			}},
+			requiredMocks: func(_ *mocks.Client) {},
+			username:      "user",
+			expected:      fmt.Errorf("outside of the maintenance window"),
+		},
+		{
+			description: "succeeds replacing the username",
+			webhook: &fakeWebhook{res: &webhook.IncomingConnectionWebhookResponse{
+				Decision: webhook.WebhookDecisionAllow,
+				Username: "root",
+			}},
+			requiredMocks: func(_ *mocks.Client) {},
+			username:      "root",
+			expected:      nil,
+		},
+		{
+			description: "fails when the approval is denied",
+			webhook:     &fakeWebhook{res: &webhook.IncomingConnectionWebhookResponse{Decision: webhook.WebhookDecisionApproval}},
+			requiredMocks: func(clientMock *mocks.Client) {
+				clientMock.On("CreateSessionApproval", approval).Return(nil).Once()
+				clientMock.On("GetSessionApproval", "uid").
+					Return(&models.SessionApproval{Status: models.SessionApprovalDenied}, nil).Once()
+			},
+			username: "user",
+			expected: errors.GetExternal(ErrApprovalDenied),
+		},
+		{
+			description: "fails when the session is closed while waiting for the approval",
+			ctx:         canceled,
+			webhook:     &fakeWebhook{res: &webhook.IncomingConnectionWebhookResponse{Decision: webhook.WebhookDecisionApproval}},
+			requiredMocks: func(clientMock *mocks.Client) {
+				clientMock.On("CreateSessionApproval", approval).Return(nil).Once()
+			},
+			username: "user",
+			expected: fmt.Errorf("the session was closed"),
+		},
+		{
+			description: "succeeds when the approval is approved",
+			webhook: &fakeWebhook{res: &webhook.IncomingConnectionWebhookResponse{
+				Decision: webhook.WebhookDecisionApproval,
+				Username: "root",
+			}},
+			requiredMocks: func(clientMock *mocks.Client) {
+				clientMock.On("CreateSessionApproval", approval).Return(nil).Once()
+				clientMock.On("GetSessionApproval", "uid").
+					Return(&models.SessionApproval{Status: models.SessionApprovalPending}, nil).Once()
+				clientMock.On("GetSessionApproval", "uid").
+					Return(&models.SessionApproval{Status: models.SessionApprovalApproved}, nil).Once()
+			},
+			username: "root",
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			clientMock := &mocks.Client{}
+			tc.requiredMocks(clientMock)
+
+			ctx := tc.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+
+			sess := &Session{
+				UID:       "uid",
+				User:      "user",
+				Target:    "device",
+				IPAddress: "127.0.0.1",
+				Lookup:    map[string]string{"username": "user", "name": "device", "domain": "namespace"},
+			}
+
+			err := sess.evaluateWebhook(ctx, tc.webhook, clientMock)
+			assert.Equal(t, "uid", tc.webhook.request["session"])
+			assert.Equal(t, tc.username, sess.User)
+			assert.Equal(t, tc.expected, errors.GetExternal(err))
+
+			clientMock.AssertExpectations(t)
+		})
+	}
+}